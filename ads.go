@@ -0,0 +1,90 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// adSlotMaxLength is the maximum length of the text of an ad slot. Ad slots
+// are usually empty when the document is saved, or only contain a label like
+// "Advertisement", so elements with more text are kept.
+const adSlotMaxLength = 200
+
+// AdPattern recognizes the placeholders injected into the content by the ad
+// services, which rarely use class names with "ad" in them but are easy to
+// identify by their attributes.
+type AdPattern struct {
+	// Attribute is the name of the attribute. A name ending with "*" matches
+	// every attribute starting with the given prefix.
+	Attribute string
+
+	// Value is an optional expression that must match the attribute value.
+	Value *regexp.Regexp
+}
+
+var adPatternsMu sync.RWMutex
+
+// adPatterns is the registry of patterns used to find ad slots.
+var adPatterns = []AdPattern{
+	{Attribute: "data-ad-*"},
+	{Attribute: "data-adunit*"},
+	{Attribute: "data-google-query-id"},
+	{Attribute: "data-google-container-id"},
+	{Attribute: "data-dfp*"},
+	{Attribute: "data-freestar-ad"},
+	{Attribute: "data-native-ad"},
+	{Attribute: "id", Value: regexp.MustCompile(`^(div-gpt-ad|google_ads_iframe|gpt-ad|dfp-ad|ad-slot)`)},
+	{Attribute: "class", Value: regexp.MustCompile(`(^|\s)adsbygoogle(\s|$)`)},
+}
+
+// RegisterAdPattern adds a pattern to the registry used to find the ad slots.
+// It affects all the parsers, and is safe for concurrent use.
+func RegisterAdPattern(pattern AdPattern) {
+	adPatternsMu.Lock()
+	defer adPatternsMu.Unlock()
+
+	adPatterns = append(adPatterns, pattern)
+}
+
+// matches returns true if one of the attributes of node matches the pattern.
+func (p AdPattern) matches(node *html.Node) bool {
+	prefix := strings.TrimSuffix(p.Attribute, "*")
+	isPrefix := prefix != p.Attribute
+
+	for _, attr := range node.Attr {
+		if attr.Key != p.Attribute && (!isPrefix || !strings.HasPrefix(attr.Key, prefix)) {
+			continue
+		}
+
+		if p.Value == nil || p.Value.MatchString(attr.Val) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAdSlot returns true if node matches one of the registered ad patterns.
+func isAdSlot(node *html.Node) bool {
+	adPatternsMu.RLock()
+	defer adPatternsMu.RUnlock()
+
+	for _, pattern := range adPatterns {
+		if pattern.matches(node) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeAdSlots removes the ad slots from the document before the content is
+// scored, as long as they do not contain text that may be part of the article.
+func (r *Readability) removeAdSlots(root *html.Node) {
+	r.removeNodes(getElementsByTagName(root, "*"), r.reportingFilter(RemovalAdSlot, func(node *html.Node) bool {
+		return node != root && isAdSlot(node) && len(r.getInnerText(node, true)) <= adSlotMaxLength
+	}))
+}
@@ -0,0 +1,41 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRemoveAdSlots(t *testing.T) {
+	RegisterAdPattern(AdPattern{Attribute: "data-promo-slot", Value: regexp.MustCompile(`^\d+$`)})
+
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+				<div data-ad-slot="1234567890" style="min-height:250px"></div>
+				<p>Duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore.</p>
+				<div id="div-gpt-ad-1" data-google-query-id="CLr3"><span>Advertisement</span></div>
+				<div data-promo-slot="7"><span>Sponsored offer</span></div>
+				<p>Sunt in culpa qui officia deserunt mollit anim id est laborum, sed ut perspiciatis.</p>
+			</article>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.RemoveAdSlots = true
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, removed := range []string{"data-ad-slot", "Advertisement", "Sponsored offer"} {
+		if strings.Contains(a.Content, removed) {
+			t.Fatalf("ad slot was not removed: %s", a.Content)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package readability
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// rxAltFilenameNoise matches the parts of the file name of an image that do
+// not describe it, like the sizes added by the content management systems,
+// "-1024x768" or "@2x", and the separators between words.
+var rxAltFilenameNoise = regexp.MustCompile(`(?i)[-_]?\d+x\d+$|@\dx$|[-_.+]+`)
+
+// rxAltFilenameWord matches the words of a file name that can be read, which
+// excludes the numbers and the hashes generated by the servers.
+var rxAltFilenameWord = regexp.MustCompile(`^\p{L}[\p{L}']*$`)
+
+// generateAltText adds an alternative text to the images of the content that
+// have none, taken from the caption of the figure, from the title attribute or
+// from the file name of the image. Images with an empty alternative text are
+// decorative and left as they are. See GenerateAltText.
+func (r *Readability) generateAltText(articleContent *html.Node) {
+	for _, img := range getElementsByTagName(articleContent, "img") {
+		if hasAttribute(img, "alt") {
+			continue
+		}
+
+		if alt := altText(img); alt != "" {
+			setAttribute(img, "alt", alt)
+		}
+	}
+}
+
+// altText returns the alternative text for an image without one.
+func altText(img *html.Node) string {
+	if caption := imageCaption(img); caption != "" {
+		return caption
+	}
+
+	if title := strings.Join(strings.Fields(getAttribute(img, "title")), "\x20"); title != "" {
+		return title
+	}
+
+	return filenameAltText(getAttribute(img, "src"))
+}
+
+// filenameAltText returns the words of the file name of the image, like
+// "Golden gate bridge" for "/uploads/golden-gate_bridge-1024x768.jpg", or an
+// empty string if the file name has no words, like "IMG_0042.jpg".
+func filenameAltText(src string) string {
+	if strings.HasPrefix(strings.ToLower(src), "data:") {
+		return ""
+	}
+
+	if parsed, err := url.Parse(src); err == nil {
+		src = parsed.Path
+	}
+
+	name := path.Base(src)
+	name = strings.TrimSuffix(name, path.Ext(name))
+
+	var words []string
+
+	for _, word := range strings.Fields(rxAltFilenameNoise.ReplaceAllString(name, "\x20")) {
+		if rxAltFilenameWord.MatchString(word) {
+			words = append(words, strings.ToLower(word))
+		}
+	}
+
+	// A single word is usually a code, like "IMG" or "DSC".
+	if len(words) < 2 {
+		return ""
+	}
+
+	alt := []rune(strings.Join(words, "\x20"))
+	alt[0] = unicode.ToUpper(alt[0])
+
+	return string(alt)
+}
@@ -0,0 +1,63 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilenameAltText(t *testing.T) {
+	tests := map[string]string{
+		"https://cixtor.com/uploads/golden-gate_bridge-1024x768.jpg": "Golden gate bridge",
+		"/images/Sunset+Over+The+Bay@2x.png?v=3":                     "Sunset over the bay",
+		"/images/IMG_0042.jpg":                                       "",
+		"/images/8f14e45fceea167a5a36dedd4bea2543.webp":              "",
+		"/images/logo.svg":                                           "",
+		"data:image/png;base64,iVBORw0KGgo=":                         "",
+	}
+
+	for input, expected := range tests {
+		if alt := filenameAltText(input); alt != expected {
+			t.Fatalf("filenameAltText(%q) = %q, expecting %q", input, alt, expected)
+		}
+	}
+}
+
+func TestGenerateAltText(t *testing.T) {
+	input := `<html>
+		<head><title>hello world</title></head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<figure><img src="/one.jpg"><figcaption>The bridge at night</figcaption></figure>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<p><img src="/two.jpg" title="The old harbour"> <img src="/golden-gate-bridge.jpg"> <img src="/spacer.jpg" alt=""></p>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			</article>
+		</body>
+	</html>`
+
+	parser := New()
+	parser.GenerateAltText = true
+
+	a, err := parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{"The bridge at night", "The old harbour", "Golden gate bridge", ""}
+
+	if len(a.Images) != len(expected) {
+		t.Fatalf("expecting %d images: %#v", len(expected), a.Images)
+	}
+
+	for i, image := range a.Images {
+		if image.Alt != expected[i] {
+			t.Fatalf("unexpected alternative text of image %d: %q", i, image.Alt)
+		}
+	}
+
+	if !strings.Contains(a.Content, `alt="Golden gate bridge"`) {
+		t.Fatalf("missing alternative text in the content:\n%s", a.Content)
+	}
+}
@@ -0,0 +1,91 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// copyAttributes copies the given attributes, when present, from one element
+// to another.
+func copyAttributes(from *html.Node, to *html.Node, names ...string) {
+	for _, name := range names {
+		if hasAttribute(from, name) {
+			setAttribute(to, name, getAttribute(from, name))
+		}
+	}
+}
+
+// convertAMPImage converts an <amp-img> or <amp-anim> element into an <img>
+// element with the same source, dimensions and alternative text.
+func convertAMPImage(node *html.Node) *html.Node {
+	img := createElement("img")
+	img.DataAtom = atom.Img
+	copyAttributes(node, img, "src", "srcset", "sizes", "alt", "title", "width", "height")
+
+	return img
+}
+
+// convertAMPMedia converts an <amp-video> or <amp-audio> element into a <video>
+// or <audio> element, moving the <source> and <track> children with it. The
+// placeholders and fallbacks are dropped.
+func convertAMPMedia(node *html.Node) *html.Node {
+	tag := strings.TrimPrefix(node.Data, "amp-")
+	media := createElement(tag)
+	media.DataAtom = atom.Lookup([]byte(tag))
+	copyAttributes(node, media, "src", "poster", "width", "height", "controls", "loop", "muted", "preload", "title")
+
+	for _, child := range children(node) {
+		if tag := tagName(child); tag == "source" || tag == "track" {
+			node.RemoveChild(child)
+			media.AppendChild(child)
+		}
+	}
+
+	return media
+}
+
+// convertAMPIframe converts an <amp-iframe> element into an <iframe> element.
+func convertAMPIframe(node *html.Node) *html.Node {
+	if getAttribute(node, "src") == "" {
+		return nil
+	}
+
+	iframe := createElement("iframe")
+	iframe.DataAtom = atom.Iframe
+	copyAttributes(node, iframe, "src", "width", "height", "title", "allowfullscreen")
+
+	return iframe
+}
+
+// convertAMPYouTube converts an <amp-youtube> element into the <iframe> used to
+// embed the video.
+func convertAMPYouTube(node *html.Node) *html.Node {
+	return ampPlayer(node, "https://www.youtube.com/embed/")
+}
+
+// convertAMPVimeo converts an <amp-vimeo> element into the <iframe> used to
+// embed the video.
+func convertAMPVimeo(node *html.Node) *html.Node {
+	return ampPlayer(node, "https://player.vimeo.com/video/")
+}
+
+// ampPlayer returns an <iframe> element embedding the video identified by the
+// data-videoid attribute of the AMP component, or nil if there is none.
+func ampPlayer(node *html.Node, prefix string) *html.Node {
+	videoID := strings.TrimSpace(getAttribute(node, "data-videoid"))
+
+	if videoID == "" {
+		return nil
+	}
+
+	iframe := createElement("iframe")
+	iframe.DataAtom = atom.Iframe
+	setAttribute(iframe, "src", prefix+url.PathEscape(videoID))
+	copyAttributes(node, iframe, "width", "height", "title")
+	setAttribute(iframe, "allowfullscreen", "")
+
+	return iframe
+}
@@ -0,0 +1,48 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAMPComponents(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<amp-video width="640" height="360" poster="/poster.jpg" controls>
+					<source src="/video.mp4" type="video/mp4">
+					<div fallback>Your browser does not support HTML5 video.</div>
+				</amp-video>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<amp-youtube data-videoid="dQw4w9WgXcQ" width="480" height="270"></amp-youtube>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{
+		`<video poster="https://cixtor.com/poster.jpg" width="640" height="360" controls="">`,
+		`<source src="https://cixtor.com/video.mp4" type="video/mp4"/>`,
+		`<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" width="480" height="270" allowfullscreen=""></iframe>`,
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(a.Content, fragment) {
+			t.Fatalf("expecting %s in the content:\n%s", fragment, a.Content)
+		}
+	}
+
+	if strings.Contains(a.Content, "does not support") {
+		t.Fatalf("the fallback of the video was not removed:\n%s", a.Content)
+	}
+}
@@ -0,0 +1,181 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// fragmentID returns the fragment of a link pointing to a section of the same
+// document, or an empty string if the link points to a different document.
+func (r *Readability) fragmentID(href string) string {
+	if strings.HasPrefix(href, "#") {
+		if frag, err := url.PathUnescape(href[1:]); err == nil {
+			return frag
+		}
+
+		return href[1:]
+	}
+
+	if r.documentURI == nil {
+		return ""
+	}
+
+	link, err := url.Parse(href)
+
+	if err != nil || link.Fragment == "" {
+		return ""
+	}
+
+	link = r.documentURI.ResolveReference(link)
+
+	if link.Scheme != r.documentURI.Scheme ||
+		link.Host != r.documentURI.Host ||
+		link.Path != r.documentURI.Path ||
+		link.RawQuery != r.documentURI.RawQuery {
+		return ""
+	}
+
+	return link.Fragment
+}
+
+// anchorID returns the ID of an element, or the name of an <a> element, which
+// are the two ways to define the target of a fragment link.
+func anchorID(node *html.Node) string {
+	if value := id(node); value != "" {
+		return value
+	}
+
+	if tagName(node) == "a" {
+		return strings.TrimSpace(getAttribute(node, "name"))
+	}
+
+	return ""
+}
+
+// anchorText returns the normalized text used to find the target of a fragment
+// link in the content. Empty anchors, like <a name="top"></a>, use the text of
+// the element that follows them.
+func (r *Readability) anchorText(node *html.Node) string {
+	for node != nil {
+		if text := r.getInnerText(node, true); text != "" {
+			return text
+		}
+
+		node = nextElementSibling(node)
+	}
+
+	return ""
+}
+
+// findAnchorTargets returns the text of the elements referenced by the fragment
+// links of the document, indexed by their ID. The content grabber rebuilds
+// parts of the document, so the IDs are matched again after the extraction.
+func (r *Readability) findAnchorTargets(doc *html.Node) map[string]string {
+	targets := map[string]string{}
+
+	for _, link := range getElementsByTagName(doc, "a") {
+		if frag := r.fragmentID(getAttribute(link, "href")); frag != "" {
+			targets[frag] = ""
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	for _, node := range getElementsByTagName(doc, "*") {
+		anchor := anchorID(node)
+
+		if text, ok := targets[anchor]; !ok || text != "" {
+			continue
+		}
+
+		targets[anchor] = r.anchorText(node)
+	}
+
+	return targets
+}
+
+// repairFragmentLinks rewrites the fragment links of the content so the ones
+// whose target is part of the article point to it with a relative link, adding
+// the ID back to the target if it was lost during the extraction. Links whose
+// target is not part of the article point to the original document instead.
+// The names of the legacy <a name> targets become IDs, since the attribute is
+// not kept in the content, so the links into the article keep working.
+func (r *Readability) repairFragmentLinks(articleContent *html.Node) {
+	for _, link := range getElementsByTagName(articleContent, "a") {
+		if name := anchorID(link); name != "" && id(link) == "" {
+			setAttribute(link, "id", name)
+		}
+	}
+
+	if len(r.anchorTargets) == 0 {
+		return
+	}
+
+	elements := getElementsByTagName(articleContent, "*")
+	present := map[string]bool{}
+
+	for _, node := range elements {
+		if anchor := anchorID(node); anchor != "" {
+			present[anchor] = true
+		}
+	}
+
+	for _, link := range getElementsByTagName(articleContent, "a") {
+		frag := r.fragmentID(getAttribute(link, "href"))
+
+		if frag == "" {
+			continue
+		}
+
+		if !present[frag] {
+			if target := r.findAnchorTarget(elements, r.anchorTargets[frag]); target != nil {
+				setAttribute(target, "id", frag)
+				present[frag] = true
+			}
+		}
+
+		if present[frag] {
+			setAttribute(link, "href", "#"+frag)
+			continue
+		}
+
+		page := *r.documentURI
+		page.Fragment = frag
+		setAttribute(link, "href", page.String())
+	}
+}
+
+// findAnchorTarget returns the innermost element of the list whose text is the
+// same as the text of the original target, if it does not have an ID already.
+// Elements whose text is entirely made of links are ignored.
+func (r *Readability) findAnchorTarget(elements []*html.Node, text string) *html.Node {
+	if text == "" {
+		return nil
+	}
+
+	var target *html.Node
+
+	for _, node := range elements {
+		// Skip the links to the target, like the entries of a table of
+		// contents, which usually have the same text.
+		if r.getInnerText(node, true) != text || r.getLinkDensity(node) == 1 {
+			continue
+		}
+
+		if target != nil && !isDescendant(node, target) {
+			break
+		}
+
+		target = node
+	}
+
+	if target == nil || id(target) != "" {
+		return nil
+	}
+
+	return target
+}
@@ -0,0 +1,82 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFragmentLinks(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<ul>
+					<li><a href="#first">First section</a></li>
+					<li><a href="page.html#second">Second section</a></li>
+					<li><a href="#comments">Comments</a></li>
+				</ul>
+				<div id="first"><p>First section</p></div>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<h2 id="second">Second section</h2>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+			</article>
+			<div id="comments">No comments yet</div>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog/page.html")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{
+		`<a href="#first">First section</a>`,
+		`<p id="first">First section</p>`,
+		`<a href="#second">Second section</a>`,
+		`<h2 id="second">Second section</h2>`,
+		`<a href="https://cixtor.com/blog/page.html#comments">Comments</a>`,
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(a.Content, fragment) {
+			t.Fatalf("expecting %s in the content:\n%s", fragment, a.Content)
+		}
+	}
+}
+
+func TestNamedAnchors(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p><a href="#notes">Jump to the notes</a></p>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<p><a name="notes">Notes</a> ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<p><a name="legacy">Legacy</a> duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog/page.html")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{
+		`<a href="#notes">Jump to the notes</a>`,
+		`<a id="notes">Notes</a>`,
+		`<a id="legacy">Legacy</a>`,
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(a.Content, fragment) {
+			t.Fatalf("expecting %s in the content:\n%s", fragment, a.Content)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package readability
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// AssetStore saves the resources downloaded for an article, like images, and
+// returns the URL that the content of the article must use to reference the
+// stored copy, for example a relative path in an offline archive.
+type AssetStore interface {
+	Store(sourceURL string, contentType string, data []byte) (string, error)
+}
+
+// embedImages downloads the images in the article content with the Fetcher
+// and rewrites their URLs to point to a local copy. The copy is saved in the
+// AssetStore or, if there is no store, embedded as a data URI. Images that
+// fail to download keep their original URL.
+func (r *Readability) embedImages(articleContent *html.Node) {
+	embedded := make(map[string]string)
+
+	// Sources of <picture> elements would be used instead of the local copy.
+	r.forEachNode(getElementsByTagName(articleContent, "picture"), func(picture *html.Node, _ int) {
+		if len(getElementsByTagName(picture, "img")) > 0 {
+			r.removeNodes(getElementsByTagName(picture, "source"), nil)
+		}
+	})
+
+	r.forEachNode(getElementsByTagName(articleContent, "img"), func(img *html.Node, _ int) {
+		src := getAttribute(img, "src")
+
+		if src == "" || strings.HasPrefix(src, "data:") {
+			return
+		}
+
+		localURL, ok := embedded[src]
+
+		if !ok {
+			var err error
+
+			if localURL, err = r.embedImage(src); err != nil {
+				localURL = ""
+			}
+
+			embedded[src] = localURL
+		}
+
+		if localURL == "" {
+			return
+		}
+
+		setAttribute(img, "src", localURL)
+		removeAttribute(img, "srcset")
+		removeAttribute(img, "sizes")
+	})
+}
+
+// embedImage downloads a single image and returns the URL of the local copy.
+func (r *Readability) embedImage(imageURL string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, imageURL, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	res, err := r.fetcher().Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return "", fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	var body io.Reader = res.Body
+
+	// Read one byte more than the limit to know if the image is larger.
+	if r.MaxInlineImageBytes > 0 {
+		body = io.LimitReader(res.Body, int64(r.MaxInlineImageBytes)+1)
+	}
+
+	data, err := ioutil.ReadAll(body)
+
+	if err != nil {
+		return "", err
+	}
+
+	if r.MaxInlineImageBytes > 0 && len(data) > r.MaxInlineImageBytes {
+		return "", fmt.Errorf("image is larger than %d bytes", r.MaxInlineImageBytes)
+	}
+
+	contentType := res.Header.Get("Content-Type")
+
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	if !strings.HasPrefix(contentType, "image/") {
+		return "", fmt.Errorf("unexpected content type: %s", contentType)
+	}
+
+	if r.AssetStore != nil {
+		return r.AssetStore.Store(imageURL, contentType, data)
+	}
+
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,115 @@
+package readability
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type memoryAssetStore map[string][]byte
+
+func (store memoryAssetStore) Store(sourceURL string, contentType string, data []byte) (string, error) {
+	name := "assets/" + sourceURL[strings.LastIndex(sourceURL, "/")+1:]
+	store[name] = data
+	return name, nil
+}
+
+func TestEmbedImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "image/gif")
+		w.Write([]byte("GIF89a"))
+	}))
+	defer server.Close()
+
+	page := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<p>lorem ipsum</p>
+			<p><img src="/photo.gif" srcset="/photo-2x.gif 2x" alt="photo"></p>
+		</body>
+		</html>`
+
+	parser := New()
+	parser.EmbedImages = true
+	a, err := parser.Parse(strings.NewReader(page), server.URL+"/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, `<img src="data:image/gif;base64,R0lGODlh" alt="photo"/>`) {
+		t.Fatalf("image was not embedded: %s", a.Content)
+	}
+
+	if len(a.Images) != 1 || a.Images[0].URL != server.URL+"/photo.gif" {
+		t.Fatalf("image metadata should keep the original URL: %#v", a.Images)
+	}
+
+	store := memoryAssetStore{}
+	parser.AssetStore = store
+	a, err = parser.Parse(strings.NewReader(page), server.URL+"/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, `<img src="assets/photo.gif" alt="photo"/>`) || len(store["assets/photo.gif"]) == 0 {
+		t.Fatalf("image was not stored: %s", a.Content)
+	}
+}
+
+// countingBody is an endless response body, starting with the prefix, that
+// counts the bytes read.
+type countingBody struct {
+	prefix string
+	read   int
+}
+
+func (body *countingBody) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+
+		if body.read+i < len(body.prefix) {
+			p[i] = body.prefix[body.read+i]
+		}
+	}
+
+	body.read += len(p)
+
+	return len(p), nil
+}
+
+func (body *countingBody) Close() error {
+	return nil
+}
+
+type bodyFetcher struct {
+	body io.ReadCloser
+}
+
+func (f bodyFetcher) Do(req *http.Request) (*http.Response, error) {
+	header := http.Header{}
+	header.Set("Content-Type", "image/png")
+
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: f.body}, nil
+}
+
+func TestEmbedImageLimit(t *testing.T) {
+	body := &countingBody{}
+	parser := New()
+	parser.Fetcher = bodyFetcher{body: body}
+	parser.MaxInlineImageBytes = 1024
+	parser.AssetStore = memoryAssetStore{}
+
+	if _, err := parser.embedImage("https://cixtor.com/huge.png"); err == nil {
+		t.Fatalf("the image larger than the limit should be rejected")
+	}
+
+	if body.read > 64*1024 {
+		t.Fatalf("the body was read past the limit: %d bytes", body.read)
+	}
+}
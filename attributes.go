@@ -0,0 +1,92 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedAttributes are the attributes kept in the content of the article.
+// Every other attribute, like event handlers, inline styles and the data
+// attributes used by trackers, is removed before the content is returned.
+var allowedAttributes = []string{
+	// Links, images and media.
+	"href", "src", "srcset", "sizes", "alt", "title", "poster", "controls",
+	"type", "kind", "srclang", "label", "media", "width", "height",
+	"allowfullscreen", "cite",
+	// Tables and lists.
+	"colspan", "rowspan", "headers", "scope", "span", "summary", "start",
+	"reversed", "value",
+	// Text semantics.
+	"datetime", "lang", "dir", "open",
+	// Set by the parser itself, see cleanClasses and repairFragmentLinks.
+	"id", "class",
+}
+
+// formAttributes are the attributes also kept when Forms is FormsKeep.
+var formAttributes = []string{
+	"action", "method", "name", "placeholder", "for", "checked", "selected",
+	"disabled", "readonly", "required", "multiple", "rows", "cols",
+}
+
+// isAllowedAttribute determines if the attribute is kept in the content.
+// Entries of AllowedAttributes ending with an asterisk, like "data-*", match
+// every attribute with the same prefix.
+func (r *Readability) isAllowedAttribute(key string) bool {
+	// The internal marks are removed by the stages that set them.
+	if strings.HasPrefix(key, "data-readability-") {
+		return true
+	}
+
+	if indexOf(allowedAttributes, key) != -1 {
+		return true
+	}
+
+	if r.Forms == FormsKeep && indexOf(formAttributes, key) != -1 {
+		return true
+	}
+
+	for _, allowed := range r.AllowedAttributes {
+		allowed = strings.ToLower(allowed)
+
+		if allowed == key || (strings.HasSuffix(allowed, "*") && strings.HasPrefix(key, allowed[:len(allowed)-1])) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scrubAttributes removes the attributes that are not allowed from the node
+// and its descendants. SVG images are left untouched, their attributes are
+// needed to draw them. The style attributes are sanitized if SanitizeStyles
+// is enabled.
+func (r *Readability) scrubAttributes(node *html.Node) {
+	if tagName(node) == "svg" {
+		return
+	}
+
+	var attrs []html.Attribute
+
+	for _, attr := range node.Attr {
+		if attr.Namespace != "" || (r.SanitizeStyles && attr.Key == "style") {
+			continue
+		}
+
+		if r.isAllowedAttribute(attr.Key) {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	if r.SanitizeStyles {
+		if style := r.sanitizedStyle(node); style != "" {
+			attrs = append(attrs, html.Attribute{Key: "style", Val: style})
+		}
+	}
+
+	node.Attr = attrs
+
+	for child := firstElementChild(node); child != nil; child = nextElementSibling(child) {
+		r.scrubAttributes(child)
+	}
+}
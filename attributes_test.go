@@ -0,0 +1,51 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScrubAttributes(t *testing.T) {
+	page := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p onmouseover="track()" data-track-id="42" aria-label="intro" lang="en">Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<p><a href="/next" onclick="track()" data-track-id="43" title="Next">Ut enim ad minim veniam</a>, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<p><time datetime="2020-01-02" itemprop="datePublished">January 2</time></p>
+			</article>
+		</body>
+		</html>`
+
+	a, err := New().Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, attr := range []string{"onmouseover", "onclick", "data-track-id", "aria-label", "itemprop"} {
+		if strings.Contains(a.Content, attr+"=") {
+			t.Fatalf("attribute %s was not removed: %s", attr, a.Content)
+		}
+	}
+
+	for _, attr := range []string{`lang="en"`, `href="https://cixtor.com/next"`, `title="Next"`, `datetime="2020-01-02"`} {
+		if !strings.Contains(a.Content, attr) {
+			t.Fatalf("attribute %s was removed: %s", attr, a.Content)
+		}
+	}
+
+	parser := New()
+	parser.AllowedAttributes = []string{"data-*", "aria-label"}
+	a, err = parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, `data-track-id="43"`) || !strings.Contains(a.Content, `aria-label="intro"`) || strings.Contains(a.Content, "onclick=") {
+		t.Fatalf("unexpected attributes: %s", a.Content)
+	}
+}
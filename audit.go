@@ -0,0 +1,93 @@
+package readability
+
+import (
+	"strconv"
+
+	"golang.org/x/net/html"
+)
+
+// AccessibilityIssueKind identifies the rule broken by the content.
+type AccessibilityIssueKind string
+
+const (
+	// IssueMissingAlt is used for the images without an alternative text.
+	// Images with an empty alternative text are decorative and accepted.
+	IssueMissingAlt AccessibilityIssueKind = "missing-alt"
+
+	// IssueHeadingSkip is used for the headings that skip one or more levels
+	// after the previous heading, like an <h4> after an <h2>.
+	IssueHeadingSkip AccessibilityIssueKind = "heading-skip"
+
+	// IssueTableHeaders is used for the data tables without header cells.
+	IssueTableHeaders AccessibilityIssueKind = "table-headers"
+)
+
+// AccessibilityIssue describes an accessibility problem of the content of the
+// article, found by the audit enabled with AuditAccessibility.
+type AccessibilityIssue struct {
+	// Kind is the rule broken by the element.
+	Kind AccessibilityIssueKind
+
+	// Criterion is the success criterion of the Web Content Accessibility
+	// Guidelines related to the rule, like "1.1.1".
+	Criterion string
+
+	// Path is the path of the element in the content of the article, like
+	// "div>div#readability-page-1>p:nth-of-type(2)>img".
+	Path string
+
+	// Detail describes the issue, for example the tag names of the headings.
+	Detail string
+}
+
+// auditAccessibility returns the accessibility issues of the content of the
+// article, in document order.
+func (r *Readability) auditAccessibility(articleContent *html.Node) []AccessibilityIssue {
+	var issues []AccessibilityIssue
+
+	level := 0
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		switch tag := tagName(node); tag {
+		case "img":
+			if !hasAttribute(node, "alt") && getAttribute(node, "role") != "presentation" {
+				issues = append(issues, AccessibilityIssue{
+					Kind:      IssueMissingAlt,
+					Criterion: "1.1.1",
+					Path:      domPath(node),
+					Detail:    getAttribute(node, "src"),
+				})
+			}
+
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			current, _ := strconv.Atoi(tag[1:])
+
+			if level != 0 && current > level+1 {
+				issues = append(issues, AccessibilityIssue{
+					Kind:      IssueHeadingSkip,
+					Criterion: "1.3.1",
+					Path:      domPath(node),
+					Detail:    "h" + strconv.Itoa(level) + " followed by " + tag,
+				})
+			}
+
+			level = current
+
+		case "table":
+			if len(getElementsByTagName(node, "th")) > 0 {
+				continue
+			}
+
+			if rows, columns := r.getRowAndColumnCount(node); rows > 1 && columns > 1 {
+				issues = append(issues, AccessibilityIssue{
+					Kind:      IssueTableHeaders,
+					Criterion: "1.3.1",
+					Path:      domPath(node),
+					Detail:    strconv.Itoa(rows) + " rows and " + strconv.Itoa(columns) + " columns",
+				})
+			}
+		}
+	}
+
+	return issues
+}
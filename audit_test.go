@@ -0,0 +1,73 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAuditAccessibility(t *testing.T) {
+	input := `<html>
+		<head><title>hello world</title></head>
+		<body>
+			<article>
+				<h2>Chapter one</h2>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<p><img src="/chart.png"> <img src="/divider.png" alt=""> <img src="/bridge.jpg" alt="The bridge"></p>
+				<h4>Details</h4>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<table>
+					<tr><td>Year</td><td>Sales</td></tr>
+					<tr><td>2023</td><td>120</td></tr>
+					<tr><td>2024</td><td>150</td></tr>
+				</table>
+				<table>
+					<tr><th>Year</th><th>Profit</th></tr>
+					<tr><td>2024</td><td>15</td></tr>
+				</table>
+				<h3>Summary</h3>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			</article>
+		</body>
+	</html>`
+
+	parser := New()
+	parser.AuditAccessibility = true
+
+	res, err := parser.Analyze(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []AccessibilityIssueKind{IssueMissingAlt, IssueHeadingSkip, IssueTableHeaders}
+
+	if len(res.AccessibilityIssues) != len(expected) {
+		t.Fatalf("expecting %d issues: %#v", len(expected), res.AccessibilityIssues)
+	}
+
+	for i, issue := range res.AccessibilityIssues {
+		if issue.Kind != expected[i] {
+			t.Fatalf("unexpected issue %d: %#v", i, issue)
+		}
+	}
+
+	if issue := res.AccessibilityIssues[0]; issue.Detail != "https://cixtor.com/chart.png" || !strings.HasSuffix(issue.Path, ">p:nth-of-type(2)>img:nth-of-type(1)") {
+		t.Fatalf("unexpected image issue: %#v", issue)
+	}
+
+	if issue := res.AccessibilityIssues[1]; issue.Detail != "h2 followed by h4" || issue.Criterion != "1.3.1" {
+		t.Fatalf("unexpected heading issue: %#v", issue)
+	}
+}
+
+func TestAuditAccessibilityDisabled(t *testing.T) {
+	res, err := New().Analyze(strings.NewReader(`<html><body><p><img src="/a.png"> Lorem ipsum dolor sit amet.</p></body></html>`), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if res.AccessibilityIssues != nil {
+		t.Fatalf("the audit should only run when enabled: %#v", res.AccessibilityIssues)
+	}
+}
@@ -0,0 +1,138 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// attributionAttr marks the elements that credit the author of a blockquote,
+// which are often short enough to be removed by the conditional cleaning.
+const attributionAttr = "data-readability-attribution"
+
+// rxAttribution matches the lines starting with a dash that usually follow a
+// quote to credit its author, like "— Ada Lovelace".
+var rxAttribution = regexp.MustCompile(`^\s*(?:[—–―]|--?)\s*\S`)
+
+// rxAttributionDash matches the dash at the beginning of an attribution.
+var rxAttributionDash = regexp.MustCompile(`^\s*(?:[—–―]|--?)\s*`)
+
+// attributionMaxLength is the maximum length of the text of an attribution
+// placed after a blockquote.
+const attributionMaxLength = 200
+
+// Quote is a blockquote found in the content of the article.
+type Quote struct {
+	// Text is the text of the quote, without the attribution.
+	Text string
+
+	// Cite is the absolute URL of the source of the quote, as found in the
+	// cite attribute of the blockquote.
+	Cite string
+
+	// Attribution is the text that credits the author of the quote, without
+	// the leading dash, as found in a <footer> inside the blockquote, in the
+	// <figcaption> of the figure wrapping it, or in a short line starting
+	// with a dash right after it.
+	Attribution string
+}
+
+// quoteAttribution returns the element with the attribution of the blockquote,
+// or nil if there is none.
+func (r *Readability) quoteAttribution(blockquote *html.Node) *html.Node {
+	for _, footer := range getElementsByTagName(blockquote, "footer") {
+		if closestAncestor(footer, "blockquote") == blockquote {
+			return footer
+		}
+	}
+
+	if parent := blockquote.Parent; parent != nil && tagName(parent) == "figure" {
+		for _, child := range children(parent) {
+			if tagName(child) == "figcaption" {
+				return child
+			}
+		}
+	}
+
+	next := nextElementSibling(blockquote)
+
+	if next == nil || tagName(next) == "blockquote" {
+		return nil
+	}
+
+	text := r.getInnerText(next, true)
+
+	if len(text) > attributionMaxLength || !rxAttribution.MatchString(text) {
+		return nil
+	}
+
+	return next
+}
+
+// markQuoteAttributions protects the attributions of the blockquotes of the
+// content from the cleaning.
+func (r *Readability) markQuoteAttributions(articleContent *html.Node) {
+	for _, blockquote := range getElementsByTagName(articleContent, "blockquote") {
+		if attribution := r.quoteAttribution(blockquote); attribution != nil {
+			setAttribute(attribution, attributionAttr, "true")
+		}
+	}
+}
+
+// isQuoteAttribution determines if a Node was marked by markQuoteAttributions.
+func (r *Readability) isQuoteAttribution(node *html.Node) bool {
+	return hasAttribute(node, attributionAttr)
+}
+
+// extractQuotes returns the blockquotes of the content with their source and
+// attribution.
+func (r *Readability) extractQuotes(articleContent *html.Node) []Quote {
+	var quotes []Quote
+
+	for _, blockquote := range getElementsByTagName(articleContent, "blockquote") {
+		attribution := r.quoteAttribution(blockquote)
+
+		quote := Quote{
+			Text: strings.Join(strings.Fields(textContentExcept(blockquote, attribution)), "\x20"),
+			Cite: toAbsoluteURI(strings.TrimSpace(getAttribute(blockquote, "cite")), r.documentURI),
+		}
+
+		if quote.Text == "" {
+			continue
+		}
+
+		if attribution != nil {
+			quote.Attribution = rxAttributionDash.ReplaceAllString(r.getInnerText(attribution, true), "")
+		}
+
+		quotes = append(quotes, quote)
+	}
+
+	return quotes
+}
+
+// textContentExcept returns the text content of the node, leaving out the text
+// of the skipped descendant.
+func textContentExcept(node *html.Node, skip *html.Node) string {
+	var buffer strings.Builder
+	var finder func(*html.Node)
+
+	finder = func(n *html.Node) {
+		if n == skip {
+			return
+		}
+
+		if n.Type == html.TextNode {
+			buffer.WriteString(n.Data)
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			finder(c)
+		}
+	}
+
+	finder(node)
+
+	return buffer.String()
+}
@@ -0,0 +1,61 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuotes(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<blockquote cite="/speeches/1">
+					<p>Ut enim ad minim veniam, quis nostrud exercitation.</p>
+					<footer>— <cite>Marcus Tullius Cicero</cite></footer>
+				</blockquote>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+				<blockquote><p>Excepteur sint occaecat cupidatat non proident.</p></blockquote>
+				<div class="attribution">– Seneca</div>
+				<p>Sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium.</p>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []Quote{
+		{
+			Text:        "Ut enim ad minim veniam, quis nostrud exercitation.",
+			Cite:        "https://cixtor.com/speeches/1",
+			Attribution: "Marcus Tullius Cicero",
+		},
+		{
+			Text:        "Excepteur sint occaecat cupidatat non proident.",
+			Attribution: "Seneca",
+		},
+	}
+
+	if len(a.Quotes) != len(expected) {
+		t.Fatalf("unexpected quotes: %#v", a.Quotes)
+	}
+
+	for i, quote := range expected {
+		if a.Quotes[i] != quote {
+			t.Fatalf("unexpected quote %d: %#v", i, a.Quotes[i])
+		}
+	}
+
+	for _, fragment := range []string{`<blockquote cite="https://cixtor.com/speeches/1">`, "<footer>", "– Seneca"} {
+		if !strings.Contains(a.Content, fragment) {
+			t.Fatalf("expecting %s in the content:\n%s", fragment, a.Content)
+		}
+	}
+}
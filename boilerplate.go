@@ -0,0 +1,118 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// boilerplateTrailingBlocks is the maximum number of blocks at the end of the
+// content considered by trimBoilerplate. Syndication footers are a couple of
+// lines long, so paragraphs further up are left alone.
+const boilerplateTrailingBlocks = 3
+
+// boilerplateMaxLength is the maximum length of the text of a block removed
+// by trimBoilerplate, which avoids removing the last paragraphs of articles
+// that talk about copyright or social networks.
+const boilerplateMaxLength = 200
+
+// boilerplateLinkDensity is the link density above which a short trailing
+// block is considered a list of links to the social networks of the website.
+const boilerplateLinkDensity = 0.5
+
+// defaultBoilerplatePatterns is the list of expressions matching the footers
+// added to the articles distributed to other websites.
+var defaultBoilerplatePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^this (article|story|post|piece) (was )?(originally|first) (appeared|published|posted|ran)\b`),
+	regexp.MustCompile(`(?i)^(originally|first) (appeared|published|posted) (on|in|at|by)\b`),
+	regexp.MustCompile(`(?i)^(republished|reprinted|reproduced) (with permission )?(from|by|courtesy)\b`),
+	regexp.MustCompile(`(?i)^(©|\(c\)|copyright\b)`),
+	regexp.MustCompile(`(?i)\ball rights reserved\.?$`),
+	regexp.MustCompile(`(?i)^(follow|like) us on\b`),
+}
+
+// boilerplateElems is a list of HTML tag names that can hold a footer.
+var boilerplateElems = []string{"p", "div", "section", "small", "h4", "h5", "h6"}
+
+// trimBoilerplate removes the syndication footers at the end of the content,
+// like "This article originally appeared on…", copyright lines and "Follow us
+// on…" links. Only the last few short blocks are considered, and the trimming
+// stops at the first block that looks like part of the article. A trailing
+// block is removed when its text matches one of the BoilerplatePatterns, when
+// it is mostly links, or when it repeats a previous block of the content.
+func (r *Readability) trimBoilerplate(articleContent *html.Node) {
+	blocks := r.trailingBlockCandidates(articleContent)
+
+	var removed []*html.Node
+
+	for i := len(blocks) - 1; i >= 0 && len(blocks)-i <= boilerplateTrailingBlocks; i-- {
+		if !r.isBoilerplate(blocks[i], blocks[:i]) {
+			break
+		}
+
+		removed = append(removed, blocks[i])
+	}
+
+	r.removeNodes(removed, r.reportingFilter(RemovalBoilerplate, nil))
+}
+
+// trailingBlockCandidates returns the innermost blocks with text of the
+// content, in the same order found in the document.
+func (r *Readability) trailingBlockCandidates(articleContent *html.Node) []*html.Node {
+	var blocks []*html.Node
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		if node == articleContent || indexOf(boilerplateElems, tagName(node)) == -1 {
+			continue
+		}
+
+		if hasBoilerplateElem(node) || strings.TrimSpace(r.getInnerText(node, true)) == "" {
+			continue
+		}
+
+		blocks = append(blocks, node)
+	}
+
+	return blocks
+}
+
+// hasBoilerplateElem returns true if node contains another block that can hold
+// a footer, in which case the inner block is checked instead.
+func hasBoilerplateElem(node *html.Node) bool {
+	for _, elem := range getElementsByTagName(node, "*") {
+		if elem != node && indexOf(boilerplateElems, tagName(elem)) != -1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isBoilerplate returns true if the block, found after the previous blocks,
+// looks like a syndication footer.
+func (r *Readability) isBoilerplate(block *html.Node, previous []*html.Node) bool {
+	text := strings.TrimSpace(r.getInnerText(block, true))
+
+	if len(text) > boilerplateMaxLength {
+		return false
+	}
+
+	for _, pattern := range r.BoilerplatePatterns {
+		if pattern != nil && pattern.MatchString(text) {
+			return true
+		}
+	}
+
+	if r.getLinkDensity(block) > boilerplateLinkDensity {
+		return true
+	}
+
+	for _, node := range previous {
+		if isNearDuplicate(text, r.getInnerText(node, true)) {
+			return true
+		}
+	}
+
+	return false
+}
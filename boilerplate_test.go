@@ -0,0 +1,98 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+const boilerplateTestPage = `<html>
+	<head>
+		<title>hello world</title>
+	</head>
+	<body>
+		<article>
+			<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+			<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+			<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+			<p>This article originally appeared on Example News.</p>
+			<p>Follow us on <a href="https://social.example/news">Social</a> and <a href="https://video.example/news">Video</a>.</p>
+			<p>© 2024 Example News. All rights reserved.</p>
+		</article>
+	</body>
+	</html>`
+
+func TestTrimBoilerplate(t *testing.T) {
+	parser := New()
+	parser.TrimBoilerplate = true
+	parser.ReportRemovals = true
+
+	a, err := parser.Analyze(strings.NewReader(boilerplateTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, removed := range []string{"originally appeared", "Follow us", "All rights reserved"} {
+		if strings.Contains(a.HTML(), removed) {
+			t.Fatalf("footer %q was not removed: %s", removed, a.HTML())
+		}
+	}
+
+	// Only the trailing blocks are checked, the repeated paragraph is kept.
+	if strings.Count(a.HTML(), "Lorem ipsum") != 2 || !strings.Contains(a.HTML(), "Duis aute") {
+		t.Fatalf("paragraph was removed: %s", a.HTML())
+	}
+
+	found := 0
+
+	for _, removal := range a.Removals {
+		if removal.Reason == RemovalBoilerplate {
+			found++
+		}
+	}
+
+	if found != 3 {
+		t.Fatalf("unexpected boilerplate removals: %#v", a.Removals)
+	}
+}
+
+func TestTrimBoilerplateDisabled(t *testing.T) {
+	a, err := New().Parse(strings.NewReader(boilerplateTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, "originally appeared") {
+		t.Fatalf("footer was removed: %s", a.Content)
+	}
+}
+
+func TestBoilerplateNearDuplicate(t *testing.T) {
+	parser := New()
+	parser.TrimBoilerplate = true
+	parser.BoilerplatePatterns = nil
+
+	page := strings.NewReplacer(
+		"<p>This article originally appeared on Example News.</p>", "",
+		"<p>© 2024 Example News. All rights reserved.</p>", `<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo.</p>`+
+			`<p><a href="https://social.example/news">Social</a> · <a href="https://video.example/news">Video</a></p>`,
+	).Replace(boilerplateTestPage)
+
+	a, err := parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	// The links are removed, and so is the repeated paragraph before them,
+	// but the trimming stops at the paragraph with a few links in it.
+	if strings.Contains(a.Content, "Social</a> ·") || strings.Count(a.Content, "Ut enim") != 1 {
+		t.Fatalf("trailing blocks were not removed: %s", a.Content)
+	}
+
+	if strings.Count(a.Content, "Lorem ipsum") != 2 || !strings.Contains(a.Content, "Follow us") {
+		t.Fatalf("paragraph was removed: %s", a.Content)
+	}
+}
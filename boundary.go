@@ -0,0 +1,262 @@
+package readability
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// segmentURLAttributes is a list of attributes used by infinite scroll scripts
+// to remember the URL of each article appended to the page.
+var segmentURLAttributes = []string{
+	"data-url",
+	"data-permalink",
+	"data-canonical",
+	"data-article-url",
+	"data-post-url",
+	"data-history-url",
+}
+
+// articleSegments returns the containers of the articles in a document where
+// the next articles are appended to the first one, as done by the websites
+// with infinite scroll. If the document contains only one article, the list
+// is empty.
+//
+// The containers are siblings with their own <h1> heading, confirmed by the
+// presence of a byline in more than one of them, or by a URL attribute that
+// does not match the canonical URL of the document.
+func (r *Readability) articleSegments(doc *html.Node) []*html.Node {
+	var headings []*html.Node
+
+	for _, heading := range getElementsByTagName(doc, "h1") {
+		if r.isProbablyVisible(heading) {
+			headings = append(headings, heading)
+		}
+	}
+
+	if len(headings) < 2 {
+		return nil
+	}
+
+	ancestor := commonAncestor(headings)
+
+	if ancestor == nil {
+		return nil
+	}
+
+	var segments []*html.Node
+
+	for _, child := range children(ancestor) {
+		switch count := len(getElementsByTagName(child, "h1")); {
+		case count == 1:
+			segments = append(segments, child)
+		case count > 1:
+			// Two headings in the same container, not an article boundary.
+			return nil
+		}
+	}
+
+	if len(segments) < 2 {
+		return nil
+	}
+
+	bylines := 0
+	mismatches := 0
+	canonicalURL := r.canonicalURL(doc)
+
+	for _, segment := range segments {
+		if r.hasBylineElement(segment) {
+			bylines++
+		}
+
+		if segmentURL := r.segmentURL(segment); segmentURL != "" && segmentURL != canonicalURL {
+			mismatches++
+		}
+	}
+
+	if bylines < 2 && mismatches == 0 {
+		return nil
+	}
+
+	return segments
+}
+
+// commonAncestor returns the deepest node containing all the given nodes.
+func commonAncestor(nodes []*html.Node) *html.Node {
+	var ancestors []*html.Node
+
+	for parent := nodes[0].Parent; parent != nil; parent = parent.Parent {
+		ancestors = append(ancestors, parent)
+	}
+
+	for _, ancestor := range ancestors {
+		containsAll := true
+
+		for _, node := range nodes[1:] {
+			if !isDescendant(node, ancestor) {
+				containsAll = false
+				break
+			}
+		}
+
+		if containsAll {
+			return ancestor
+		}
+	}
+
+	return nil
+}
+
+// isDescendant returns true if ancestor is one of the ancestors of node.
+func isDescendant(node *html.Node, ancestor *html.Node) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent == ancestor {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasBylineElement returns true if node contains an element that looks like
+// the byline of an article.
+func (r *Readability) hasBylineElement(node *html.Node) bool {
+	for _, elem := range getElementsByTagName(node, "*") {
+		rel := getAttribute(elem, "rel")
+		itemprop := getAttribute(elem, "itemprop")
+		matchString := r.matchString(elem)
+
+		if rel == "author" || strings.Contains(itemprop, "author") || rxByline.MatchString(matchString) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canonicalURL returns the absolute URL declared as the canonical URL of the
+// document, either with the og:url property or with a canonical link.
+func (r *Readability) canonicalURL(doc *html.Node) string {
+	for _, meta := range getElementsByTagName(doc, "meta") {
+		if getAttribute(meta, "property") == "og:url" {
+			return toAbsoluteURI(getAttribute(meta, "content"), r.documentURI)
+		}
+	}
+
+	for _, link := range getElementsByTagName(doc, "link") {
+		if strings.EqualFold(getAttribute(link, "rel"), "canonical") {
+			return toAbsoluteURI(getAttribute(link, "href"), r.documentURI)
+		}
+	}
+
+	if r.documentURI != nil {
+		return r.documentURI.String()
+	}
+
+	return ""
+}
+
+// segmentURL returns the absolute URL of the article in the segment, or an
+// empty string if the infinite scroll script did not leave one behind.
+func (r *Readability) segmentURL(segment *html.Node) string {
+	for _, attr := range segmentURLAttributes {
+		if value := strings.TrimSpace(getAttribute(segment, attr)); value != "" {
+			return toAbsoluteURI(value, r.documentURI)
+		}
+	}
+
+	return ""
+}
+
+// removeAppendedArticles removes all the articles appended to the first one
+// by the infinite scroll scripts, so they are not merged into the content.
+func (r *Readability) removeAppendedArticles(doc *html.Node) {
+	if segments := r.articleSegments(doc); len(segments) > 1 {
+		r.removeNodes(segments[1:], nil)
+	}
+}
+
+// segmentDocument returns a document with the head of doc and a body with a
+// copy of the segment. For segments other than the first one, the metadata of
+// the head describes a different article, so only a title is kept, taken from
+// the first heading of the segment.
+func segmentDocument(doc *html.Node, segment *html.Node, first bool) *html.Node {
+	root := createElement("html")
+	body := createElement("body")
+
+	if heads := getElementsByTagName(doc, "head"); first && len(heads) > 0 {
+		root.AppendChild(cloneNode(heads[0]))
+	} else {
+		head := createElement("head")
+		title := createElement("title")
+
+		title.AppendChild(createTextNode(firstHeading(segment)))
+
+		head.AppendChild(title)
+		root.AppendChild(head)
+	}
+
+	root.AppendChild(body)
+	body.AppendChild(cloneNode(segment))
+
+	return documentFromNode(root)
+}
+
+// ParseAll parses input like Parse, but when the document contains several
+// articles appended one after the other, as done by websites with infinite
+// scroll, it returns all of them instead of only the first one. Each article
+// uses the URL left by the infinite scroll script, if any, as its page URL.
+func (r *Readability) ParseAll(input io.Reader, pageURL string) ([]Article, error) {
+	doc, err := html.Parse(input)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	// The page URL is needed to resolve the URLs of the segments.
+	if r.documentURI, err = url.ParseRequestURI(pageURL); err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %v", err)
+	}
+
+	segments := r.articleSegments(doc)
+
+	if len(segments) < 2 {
+		result, err := r.analyzeDocument(doc, pageURL, time.Now())
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []Article{result.article()}, nil
+	}
+
+	var segmentURLs []string
+
+	for _, segment := range segments {
+		segmentURLs = append(segmentURLs, r.segmentURL(segment))
+	}
+
+	articles := make([]Article, 0, len(segments))
+
+	for i, segment := range segments {
+		segmentURL := segmentURLs[i]
+
+		if segmentURL == "" {
+			segmentURL = pageURL
+		}
+
+		result, err := r.analyzeDocument(segmentDocument(doc, segment, i == 0), segmentURL, time.Now())
+
+		if err != nil {
+			return nil, err
+		}
+
+		articles = append(articles, result.article())
+	}
+
+	return articles, nil
+}
@@ -0,0 +1,60 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+const infiniteScrollPage = `<html>
+	<head>
+		<title>First story - Example News</title>
+		<meta property="og:url" content="https://cixtor.com/first">
+	</head>
+	<body>
+		<header><a href="/">Example News</a></header>
+		<main>
+			<article data-url="/first">
+				<h1>First story</h1>
+				<p class="byline">By Jane Doe</p>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt.</p>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea.</p>
+			</article>
+			<article data-url="/second">
+				<h1>Second story</h1>
+				<p class="byline">By John Doe</p>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat.</p>
+				<p>Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit.</p>
+			</article>
+		</main>
+	</body>
+	</html>`
+
+func TestAppendedArticles(t *testing.T) {
+	a, err := New().Parse(strings.NewReader(infiniteScrollPage), "https://cixtor.com/first")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.TextContent, "Lorem ipsum") || strings.Contains(a.TextContent, "Duis aute") {
+		t.Fatalf("only the first article should be kept: %s", a.TextContent)
+	}
+
+	articles, err := New().ParseAll(strings.NewReader(infiniteScrollPage), "https://cixtor.com/first")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(articles) != 2 {
+		t.Fatalf("expecting two articles: %d", len(articles))
+	}
+
+	if articles[1].Title != "Second story" || articles[1].Byline != "By John Doe" {
+		t.Fatalf("unexpected second article: %q by %q", articles[1].Title, articles[1].Byline)
+	}
+
+	if !strings.Contains(articles[1].TextContent, "Duis aute") || strings.Contains(articles[1].TextContent, "Lorem ipsum") {
+		t.Fatalf("unexpected content in the second article: %s", articles[1].TextContent)
+	}
+}
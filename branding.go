@@ -0,0 +1,136 @@
+package readability
+
+import (
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// getThemeColor returns the value of the theme-color meta tag. Documents can
+// declare one color for each color scheme, the one for the light scheme, or
+// for any scheme, is preferred.
+func getThemeColor(doc *html.Node) string {
+	themeColor := ""
+
+	for _, meta := range getElementsByTagName(doc, "meta") {
+		if !strings.EqualFold(strings.TrimSpace(getAttribute(meta, "name")), "theme-color") {
+			continue
+		}
+
+		content := strings.TrimSpace(getAttribute(meta, "content"))
+
+		if content == "" {
+			continue
+		}
+
+		media := strings.ToLower(getAttribute(meta, "media"))
+
+		if media == "" || strings.Contains(media, "light") {
+			return content
+		}
+
+		if themeColor == "" {
+			themeColor = content
+		}
+	}
+
+	return themeColor
+}
+
+// getTileColor returns the value of the msapplication-TileColor meta tag.
+func getTileColor(doc *html.Node) string {
+	for _, meta := range getElementsByTagName(doc, "meta") {
+		if strings.EqualFold(strings.TrimSpace(getAttribute(meta, "name")), "msapplication-TileColor") {
+			return strings.TrimSpace(getAttribute(meta, "content"))
+		}
+	}
+
+	return ""
+}
+
+// getPublisherLogo returns the absolute URL of the logo of the publisher, as
+// declared in the publisher property of the JSON-LD data or, as a fallback,
+// with the og:logo property. It must be called before the scripts are removed
+// from the document.
+func (r *Readability) getPublisherLogo(doc *html.Node) string {
+	for _, script := range getElementsByTagName(doc, "script") {
+		if !strings.EqualFold(strings.TrimSpace(getAttribute(script, "type")), "application/ld+json") {
+			continue
+		}
+
+		var data interface{}
+
+		if err := json.Unmarshal([]byte(textContent(script)), &data); err != nil {
+			continue
+		}
+
+		if logo := jsonPublisherLogo(data); logo != "" {
+			return toAbsoluteURI(logo, r.documentURI)
+		}
+	}
+
+	for _, meta := range getElementsByTagName(doc, "meta") {
+		if strings.EqualFold(getAttribute(meta, "property"), "og:logo") {
+			if logo := strings.TrimSpace(getAttribute(meta, "content")); logo != "" {
+				return toAbsoluteURI(logo, r.documentURI)
+			}
+		}
+	}
+
+	return ""
+}
+
+// jsonPublisherLogo walks the JSON-LD data looking for the logo of the first
+// publisher.
+func jsonPublisherLogo(data interface{}) string {
+	switch value := data.(type) {
+	case []interface{}:
+		for _, item := range value {
+			if logo := jsonPublisherLogo(item); logo != "" {
+				return logo
+			}
+		}
+	case map[string]interface{}:
+		if publisher, ok := value["publisher"].(map[string]interface{}); ok {
+			if logo := jsonImageURL(publisher["logo"]); logo != "" {
+				return logo
+			}
+		}
+
+		for key, item := range value {
+			if key == "publisher" {
+				continue
+			}
+
+			if logo := jsonPublisherLogo(item); logo != "" {
+				return logo
+			}
+		}
+	}
+
+	return ""
+}
+
+// jsonImageURL returns the URL of a schema.org image, which is either a URL or
+// an ImageObject.
+func jsonImageURL(data interface{}) string {
+	switch value := data.(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case []interface{}:
+		for _, item := range value {
+			if url := jsonImageURL(item); url != "" {
+				return url
+			}
+		}
+	case map[string]interface{}:
+		for _, key := range []string{"url", "contentUrl", "@id"} {
+			if url, ok := value[key].(string); ok && strings.TrimSpace(url) != "" {
+				return strings.TrimSpace(url)
+			}
+		}
+	}
+
+	return ""
+}
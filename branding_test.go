@@ -0,0 +1,76 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestThemeColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<meta name="theme-color" content="#4285f4">`, "#4285f4"},
+		{`<meta name="theme-color" media="(prefers-color-scheme: dark)" content="#000">`, "#000"},
+		{`<meta name="description" content="#fff">`, ""},
+	}
+
+	for _, tt := range tests {
+		doc, _ := html.Parse(strings.NewReader("<html><head>" + tt.input + "</head></html>"))
+
+		if color := getThemeColor(doc); color != tt.expected {
+			t.Fatalf("%s: unexpected color: %q", tt.input, color)
+		}
+	}
+}
+
+func TestBranding(t *testing.T) {
+	input := `<html><head>
+		<title>Branding</title>
+		<meta name="msapplication-TileColor" content="#da532c">
+		<script type="application/ld+json">{
+			"@context": "https://schema.org",
+			"@graph": [{
+				"@type": "NewsArticle",
+				"headline": "Branding",
+				"publisher": {"@type": "Organization", "name": "Cixtor", "logo": {"@type": "ImageObject", "url": "/logo.png"}}
+			}]
+		}</script>
+	</head><body><p>lorem ipsum</p></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Logo != "https://cixtor.com/logo.png" || a.TileColor != "#da532c" || a.ThemeColor != "" {
+		t.Fatalf("unexpected branding: %q %q %q", a.Logo, a.TileColor, a.ThemeColor)
+	}
+
+	card, err := New().Preview(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if card.ThemeColor != "#da532c" {
+		t.Fatalf("the tile color was not used in the card: %q", card.ThemeColor)
+	}
+}
+
+func TestPublisherLogoFallback(t *testing.T) {
+	input := `<html><head><meta property="og:logo" content="https://cdn.cixtor.com/logo.svg"></head><body></body></html>`
+
+	m, err := New().ExtractMetadata(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if m.Logo != "https://cdn.cixtor.com/logo.svg" {
+		t.Fatalf("unexpected logo: %q", m.Logo)
+	}
+}
@@ -0,0 +1,151 @@
+package readability
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// monthNames is a list of month names, and their common abbreviations, in the
+// languages supported to find dates in bylines, grouped by language.
+var monthNames = []string{
+	// English
+	"january", "february", "march", "april", "may", "june", "july",
+	"august", "september", "october", "november", "december",
+	"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sept", "sep", "oct", "nov", "dec",
+	// German
+	"januar", "jänner", "februar", "märz", "mai", "juni", "juli",
+	"oktober", "dezember", "mär", "okt", "dez",
+	// French
+	"janvier", "février", "mars", "avril", "juin", "juillet", "août",
+	"septembre", "octobre", "novembre", "décembre", "janv", "févr", "avr", "juil",
+	// Spanish
+	"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio",
+	"agosto", "septiembre", "setiembre", "octubre", "noviembre", "diciembre",
+	"ene", "abr", "ago", "dic",
+	// Portuguese
+	"janeiro", "fevereiro", "março", "maio", "junho", "julho",
+	"setembro", "outubro", "novembro", "dezembro", "fev", "set", "out", "dez",
+	// Italian
+	"gennaio", "febbraio", "aprile", "maggio", "giugno", "luglio",
+	"settembre", "ottobre", "dicembre", "gen", "mag", "giu", "lug", "ott",
+	// Dutch
+	"januari", "februari", "maart", "mei", "augustus",
+}
+
+// rxBylineDate matches the dates commonly found in bylines, like "March 3,
+// 2024", "3 de marzo de 2024", "3. März 2024", "2024-03-03" or "03/03/2024".
+var rxBylineDate = regexp.MustCompile(`(?i)(` +
+	`(` + monthPattern() + `)\.?\s+\d{1,2}(st|nd|rd|th)?,?\s+\d{4}` +
+	`|\d{1,2}(\.|st|nd|rd|th|er|º)?\s+(de\s+)?(` + monthPattern() + `)\.?,?\s+(de\s+)?\d{4}` +
+	`|\d{4}-\d{2}-\d{2}([T ][\d:.]+(Z|[+-]\d{2}:?\d{2})?)?` +
+	`|\d{1,2}[./]\d{1,2}[./]\d{2,4}` +
+	`)([\s,]+(at\s+|um\s+|à\s+|a\s+las\s+|às\s+|alle\s+)?\d{1,2}[:.h]\d{2}(\s*[ap]\.?m\.?)?(\s+[A-Z]{2,4})?)?`)
+
+// rxBylineSeparator matches the characters used to separate the name of the
+// author from the date in bylines like "Jane Doe | March 3, 2024".
+var rxBylineSeparator = regexp.MustCompile(`\s*[|•·—–]\s*|\s+-\s+`)
+
+// rxBylineConnector matches the words, in the supported languages, used to
+// introduce the date after the name of the author, like "Jane Doe on ...".
+var rxBylineConnector = regexp.MustCompile(`(?i)(,|\s+(on|am|le|el|em|il|op|published|updated))\s*$`)
+
+// monthPattern returns the month names as alternatives of a regexp, longer
+// names first so the abbreviations, like "jan", do not shadow the names that
+// start with them, like "januar".
+func monthPattern() string {
+	names := append([]string{}, monthNames...)
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return utf8.RuneCountInString(names[i]) > utf8.RuneCountInString(names[j])
+	})
+
+	return strings.Join(names, "|")
+}
+
+// splitBylineDate separates the name of the author from the date included in
+// bylines like "Jane Doe | March 3, 2024" or "By Jane Doe on 3 March 2024".
+// If the byline does not contain a date, date is returned empty.
+func splitBylineDate(byline string) (author string, date string) {
+	var authors []string
+
+	// First, try with the parts delimited by the usual separators.
+	for _, part := range rxBylineSeparator.Split(byline, -1) {
+		part = strings.TrimSpace(part)
+
+		if part == "" {
+			continue
+		}
+
+		if date == "" && isBylineDate(part) {
+			date = rxBylineDate.FindString(part)
+			continue
+		}
+
+		authors = append(authors, part)
+	}
+
+	if date != "" {
+		return strings.Join(authors, " | "), date
+	}
+
+	// Then, look for a date at the end of the byline.
+	loc := rxBylineDate.FindStringIndex(byline)
+
+	if loc == nil || strings.TrimSpace(byline[loc[1]:]) != "" {
+		return byline, ""
+	}
+
+	author = strings.TrimSpace(byline[:loc[0]])
+	author = strings.TrimSpace(rxBylineConnector.ReplaceAllString(author, ""))
+
+	return author, strings.TrimSpace(byline[loc[0]:loc[1]])
+}
+
+// isBylineDate determines if the text is made only of a date.
+func isBylineDate(text string) bool {
+	loc := rxBylineDate.FindStringIndex(text)
+
+	if loc == nil {
+		return false
+	}
+
+	rest := text[:loc[0]] + text[loc[1]:]
+	rest = rxBylineConnector.ReplaceAllString(strings.TrimSpace(rest), "")
+
+	return strings.Trim(rest, " ,.:") == "" ||
+		strings.EqualFold(strings.TrimSpace(rest), "updated") ||
+		strings.EqualFold(strings.TrimSpace(rest), "published")
+}
+
+// insertByline inserts the byline removed by the content grabber as the first
+// paragraph of the content, unless the content already contains it, which
+// happens when the byline is inside the article and a later attempt is used.
+func (r *Readability) insertByline(articleContent *html.Node) {
+	if r.bylineNode == nil {
+		return
+	}
+
+	page := firstElementChild(articleContent)
+
+	if page == nil {
+		return
+	}
+
+	content := strings.Join(strings.Fields(textContent(articleContent)), "\x20")
+
+	if strings.Contains(content, r.articleByline) {
+		return
+	}
+
+	byline := createElement("p")
+
+	for child := r.bylineNode.FirstChild; child != nil; child = child.NextSibling {
+		byline.AppendChild(cloneNode(child))
+	}
+
+	page.InsertBefore(byline, page.FirstChild)
+}
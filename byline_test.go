@@ -0,0 +1,91 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitBylineDate(t *testing.T) {
+	tests := []struct {
+		byline string
+		author string
+		date   string
+	}{
+		{"Jane Doe", "Jane Doe", ""},
+		{"Jane Doe | March 3, 2024", "Jane Doe", "March 3, 2024"},
+		{"By Jane Doe on Mar. 3, 2024 at 10:30 a.m. EST", "By Jane Doe", "Mar. 3, 2024 at 10:30 a.m. EST"},
+		{"Von Max Mustermann, 3. März 2024", "Von Max Mustermann", "3. März 2024"},
+		{"Par Jean Dupont le 3 février 2024", "Par Jean Dupont", "3 février 2024"},
+		{"Por Juan Pérez · 3 de marzo de 2024", "Por Juan Pérez", "3 de marzo de 2024"},
+		{"Jane Doe — 2024-03-03T10:30:00Z", "Jane Doe", "2024-03-03T10:30:00Z"},
+		{"Published 03/03/2024", "", "03/03/2024"},
+		{"Jane Doe and John Doe", "Jane Doe and John Doe", ""},
+	}
+
+	for _, test := range tests {
+		author, date := splitBylineDate(test.byline)
+
+		if author != test.author || date != test.date {
+			t.Errorf("%q: want (%q, %q) got (%q, %q)", test.byline, test.author, test.date, author, date)
+		}
+	}
+}
+
+func TestKeepBylineInContent(t *testing.T) {
+	page := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<header><span class="byline">By <a href="/authors/jane">Jane Doe</a></span></header>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur, excepteur sint occaecat cupidatat non proident.</p>
+				<p>Sunt in culpa qui officia deserunt mollit anim id est laborum, sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium.</p>
+			</article>
+		</body>
+		</html>`
+
+	a, err := New().Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Byline != "By Jane Doe" || strings.Contains(a.TextContent, "Jane Doe") {
+		t.Fatalf("the byline was not removed from the content: %q", a.TextContent)
+	}
+
+	parser := New()
+	parser.KeepBylineInContent = true
+	a, err = parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := `<div id="readability-page-1" class="page"><p>By <a href="https://cixtor.com/authors/jane">Jane Doe</a></p>`
+
+	if a.Byline != "By Jane Doe" || !strings.HasPrefix(a.Content, expected) {
+		t.Fatalf("the byline is not the first paragraph: %s", a.Content)
+	}
+
+	if strings.Count(a.TextContent, "Jane Doe") != 1 {
+		t.Fatalf("the byline was inserted more than once: %q", a.TextContent)
+	}
+}
+
+func TestMonthPattern(t *testing.T) {
+	names := strings.Split(monthPattern(), "|")
+
+	if len(names) != len(monthNames) {
+		t.Fatalf("unexpected number of month names: %d", len(names))
+	}
+
+	for i := 1; i < len(names); i++ {
+		if len([]rune(names[i])) > len([]rune(names[i-1])) {
+			t.Fatalf("%q comes after the shorter %q", names[i], names[i-1])
+		}
+	}
+}
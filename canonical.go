@@ -0,0 +1,120 @@
+package readability
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxGoogleAMP matches the URLs of the AMP viewer of Google, like
+// "https://www.google.com/amp/s/example.com/article", capturing whether the
+// target uses HTTPS and the target itself.
+var rxGoogleAMP = regexp.MustCompile(`^https?://(?:www\.)?google\.[a-z.]+/amp/(s/)?(.+)$`)
+
+// rxAMPCache matches the URLs of the AMP cache, like
+// "https://example-com.cdn.ampproject.org/c/s/example.com/article", capturing
+// whether the target uses HTTPS and the target itself.
+var rxAMPCache = regexp.MustCompile(`^https?://[^/]+\.cdn\.ampproject\.org/[a-z]+/(s/)?(.+)$`)
+
+// ampCacheParams are the query parameters added to the links by the AMP cache.
+var ampCacheParams = []string{"amp_js_v", "amp_gsa", "usqp", "amp_tf", "aoh", "ampshare"}
+
+// canonicalLink returns the URL of the page behind a link to the AMP viewer of
+// Google, to the AMP cache or to the web cache of Google. Other links are
+// returned as is.
+func canonicalLink(href string) string {
+	if m := rxGoogleAMP.FindStringSubmatch(href); m != nil {
+		return ampTarget(m[1] != "", m[2], href)
+	}
+
+	if m := rxAMPCache.FindStringSubmatch(href); m != nil {
+		return ampTarget(m[1] != "", m[2], href)
+	}
+
+	if strings.HasPrefix(href, "https://webcache.googleusercontent.com/") ||
+		strings.HasPrefix(href, "http://webcache.googleusercontent.com/") {
+		return webCacheTarget(href)
+	}
+
+	return href
+}
+
+// ampTarget builds the URL of the target of an AMP link, removing the query
+// parameters added by the cache. If the target is not valid, fallback is
+// returned instead.
+func ampTarget(secure bool, target string, fallback string) string {
+	scheme := "http://"
+
+	if secure {
+		scheme = "https://"
+	}
+
+	u, err := url.Parse(scheme + target)
+
+	if err != nil || u.Host == "" {
+		return fallback
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+
+		for _, param := range ampCacheParams {
+			query.Del(param)
+		}
+
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// webCacheTarget returns the URL of the page stored in the web cache of Google,
+// found in the query, like "cache:a1B2c3:https://example.com/article+words".
+func webCacheTarget(href string) string {
+	u, err := url.Parse(href)
+
+	if err != nil {
+		return href
+	}
+
+	target := strings.TrimSpace(u.Query().Get("q"))
+
+	if !strings.HasPrefix(target, "cache:") {
+		return href
+	}
+
+	target = strings.TrimPrefix(target, "cache:")
+
+	// Skip the identifier of the cached copy, if any.
+	if i := strings.Index(target, ":"); i != -1 && !strings.HasPrefix(target[i:], "://") {
+		target = target[i+1:]
+	}
+
+	// The search terms follow the URL.
+	if i := strings.IndexAny(target, " +"); i != -1 {
+		target = target[:i]
+	}
+
+	if target == "" {
+		return href
+	}
+
+	if !strings.Contains(target, "://") {
+		target = "http://" + target
+	}
+
+	return target
+}
+
+// canonicalizeLinks rewrites the links to the AMP viewer of Google, to the AMP
+// cache and to the web cache of Google, so they point to the pages behind
+// them, see canonicalLink.
+func (r *Readability) canonicalizeLinks(articleContent *html.Node) {
+	for _, link := range getElementsByTagName(articleContent, "a") {
+		if href := getAttribute(link, "href"); href != "" {
+			setAttribute(link, "href", canonicalLink(href))
+		}
+	}
+}
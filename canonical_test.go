@@ -0,0 +1,53 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalLink(t *testing.T) {
+	tests := []struct {
+		href     string
+		expected string
+	}{
+		{"https://cixtor.com/blog", "https://cixtor.com/blog"},
+		{"https://www.google.com/amp/s/cixtor.com/blog/post.amp", "https://cixtor.com/blog/post.amp"},
+		{"https://www.google.co.uk/amp/cixtor.com/blog/post", "http://cixtor.com/blog/post"},
+		{"https://cixtor-com.cdn.ampproject.org/c/s/cixtor.com/blog/post?amp_js_v=0.1&usqp=mq331AQ&page=2", "https://cixtor.com/blog/post?page=2"},
+		{"https://cixtor-com.cdn.ampproject.org/v/cixtor.com/blog/post", "http://cixtor.com/blog/post"},
+		{"https://webcache.googleusercontent.com/search?q=cache:a1B2c3D4:https://cixtor.com/blog/post+lorem&cd=1&hl=en", "https://cixtor.com/blog/post"},
+		{"https://webcache.googleusercontent.com/search?q=cache:cixtor.com/blog/post", "http://cixtor.com/blog/post"},
+		{"https://webcache.googleusercontent.com/search?q=lorem", "https://webcache.googleusercontent.com/search?q=lorem"},
+	}
+
+	for _, test := range tests {
+		if href := canonicalLink(test.href); href != test.expected {
+			t.Errorf("%s: want %s got %s", test.href, test.expected, href)
+		}
+	}
+}
+
+func TestCanonicalizeLinks(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, <a href="https://www.google.com/amp/s/cixtor.com/blog/post">consectetur</a> adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+			</article>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.CanonicalizeLinks = true
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, `<a href="https://cixtor.com/blog/post">`) {
+		t.Fatalf("the link was not rewritten: %s", a.Content)
+	}
+}
@@ -0,0 +1,21 @@
+package readability
+
+import (
+	"github.com/cixtor/readability/heuristics"
+)
+
+// DefaultClassWeights returns a new map with the built-in class tokens and their
+// weights, which can be modified and assigned to ClassWeights.
+func DefaultClassWeights() map[string]int {
+	return heuristics.DefaultClassWeights()
+}
+
+// classWeightOptions returns the class tokens configured in the parser.
+func (r *Readability) classWeightOptions() heuristics.ClassWeightOptions {
+	return heuristics.ClassWeightOptions{
+		Weights:        r.ClassWeights,
+		PositiveTokens: r.AdditionalPositiveTokens,
+		NegativeTokens: r.AdditionalNegativeTokens,
+		MaxNameLength:  r.maxMatchStringLength(),
+	}
+}
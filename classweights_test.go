@@ -0,0 +1,39 @@
+package readability
+
+import (
+	"testing"
+)
+
+func TestClassWeights(t *testing.T) {
+	parser := New()
+	parser.flags.useWeightClasses = true
+
+	tests := []struct {
+		className string
+		weight    int
+	}{
+		{"post-body", 25},
+		{"sidebar widget", -25},
+		{"post promo", 0},
+		{"hid", -25},
+		{"hide-menu", 0},
+		{"chide", 0},
+	}
+
+	node := createElement("div")
+
+	for _, test := range tests {
+		setAttribute(node, "class", test.className)
+
+		if weight := parser.getClassWeight(node); weight != test.weight {
+			t.Fatalf("unexpected weight for %q: %d", test.className, weight)
+		}
+	}
+
+	parser.ClassWeights["promo"] = -50
+	setAttribute(node, "class", "post promo")
+
+	if weight := parser.getClassWeight(node); weight != -25 {
+		t.Fatalf("unexpected weight with a custom weight: %d", weight)
+	}
+}
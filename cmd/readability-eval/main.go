@@ -0,0 +1,227 @@
+// Command readability-eval runs the readability parser over a corpus of saved
+// pages and reports how much of the expected text was extracted, so changes
+// to the algorithm can be evaluated quantitatively.
+//
+// The corpus is a directory with one sub-directory per page, each one with the
+// saved page in source.html and the expected content in expected.html, which
+// is the same layout used by the scenarios of the test suite:
+//
+//	readability-eval -dir scenarios
+//
+// Precision is the fraction of the extracted words found in the expected text,
+// recall is the fraction of the expected words that were extracted, and F1 is
+// the harmonic mean of both. Words are compared as bags, ignoring the case.
+// The pages that cannot be parsed score zero, so they lower the average.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cixtor/readability"
+	"golang.org/x/net/html"
+)
+
+// score holds the token-level metrics of one page.
+type score struct {
+	name      string
+	precision float64
+	recall    float64
+	f1        float64
+	err       error
+}
+
+func main() {
+	dir := flag.String("dir", "scenarios", "directory with the saved pages")
+	pageURL := flag.String("url", "http://fakehost/test/page.html", "URL of the saved pages")
+	minF1 := flag.Float64("min-f1", 0, "exit with an error if the average F1 is lower")
+	flag.Parse()
+
+	scores, err := evaluate(*dir, *pageURL)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	average := report(os.Stdout, scores)
+
+	if average.f1 < *minF1 {
+		fmt.Fprintf(os.Stderr, "average F1 %.4f is lower than %.4f\n", average.f1, *minF1)
+		os.Exit(1)
+	}
+}
+
+// evaluate parses every page of the corpus and scores the extracted text.
+func evaluate(dir string, pageURL string) ([]score, error) {
+	entries, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %v", err)
+	}
+
+	var scores []score
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		scores = append(scores, evaluatePage(filepath.Join(dir, entry.Name()), entry.Name(), pageURL))
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].name < scores[j].name
+	})
+
+	return scores, nil
+}
+
+// evaluatePage parses one page of the corpus and scores the extracted text.
+func evaluatePage(dir string, name string, pageURL string) score {
+	result := score{name: name}
+
+	source, err := os.Open(filepath.Join(dir, "source.html"))
+
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	defer source.Close()
+
+	expected, err := ioutil.ReadFile(filepath.Join(dir, "expected.html"))
+
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	expectedDoc, err := html.Parse(strings.NewReader(string(expected)))
+
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	article, err := readability.New().Parse(source, pageURL)
+
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	// Both sides are tokenized from the markup, so the words of adjacent
+	// blocks are split the same way in the extracted and expected texts.
+	contentDoc, err := html.Parse(strings.NewReader(article.Content))
+
+	if err != nil {
+		result.err = err
+		return result
+	}
+
+	result.precision, result.recall, result.f1 = tokenScores(textContent(contentDoc), textContent(expectedDoc))
+
+	return result
+}
+
+// tokenScores returns the precision, recall and F1 of the extracted words
+// compared with the expected words.
+func tokenScores(extracted string, expected string) (float64, float64, float64) {
+	extractedWords := strings.Fields(strings.ToLower(extracted))
+	expectedWords := strings.Fields(strings.ToLower(expected))
+
+	if len(extractedWords) == 0 || len(expectedWords) == 0 {
+		if len(extractedWords) == len(expectedWords) {
+			return 1, 1, 1
+		}
+
+		return 0, 0, 0
+	}
+
+	counts := make(map[string]int, len(expectedWords))
+
+	for _, word := range expectedWords {
+		counts[word]++
+	}
+
+	common := 0
+
+	for _, word := range extractedWords {
+		if counts[word] > 0 {
+			counts[word]--
+			common++
+		}
+	}
+
+	if common == 0 {
+		return 0, 0, 0
+	}
+
+	precision := float64(common) / float64(len(extractedWords))
+	recall := float64(common) / float64(len(expectedWords))
+
+	return precision, recall, 2 * precision * recall / (precision + recall)
+}
+
+// textContent returns the text of node and its descendants.
+func textContent(node *html.Node) string {
+	var buffer strings.Builder
+	var finder func(*html.Node)
+
+	finder = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			buffer.WriteString(n.Data)
+			buffer.WriteString("\x20")
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			finder(c)
+		}
+	}
+
+	finder(node)
+
+	return buffer.String()
+}
+
+// report writes a table with the scores of each page, followed by the macro
+// average of all the pages, which is returned. The pages that failed count as
+// zero in the average.
+func report(w io.Writer, scores []score) score {
+	average := score{name: "average"}
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(table, "page\tprecision\trecall\tf1\t")
+
+	for _, s := range scores {
+		if s.err != nil {
+			fmt.Fprintf(table, "%s\terror: %v\t\t\t\n", s.name, s.err)
+			continue
+		}
+
+		fmt.Fprintf(table, "%s\t%.4f\t%.4f\t%.4f\t\n", s.name, s.precision, s.recall, s.f1)
+
+		average.precision += s.precision
+		average.recall += s.recall
+		average.f1 += s.f1
+	}
+
+	if len(scores) > 0 {
+		average.precision /= float64(len(scores))
+		average.recall /= float64(len(scores))
+		average.f1 /= float64(len(scores))
+	}
+
+	fmt.Fprintf(table, "%s\t%.4f\t%.4f\t%.4f\t\n", average.name, average.precision, average.recall, average.f1)
+	table.Flush()
+
+	return average
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestTokenScores(t *testing.T) {
+	precision, recall, f1 := tokenScores("the quick brown fox jumps", "The quick fox jumps over the dog")
+
+	if precision != 0.8 || math.Abs(recall-4.0/7.0) > 1e-9 || math.Abs(f1-2.0/3.0) > 1e-9 {
+		t.Fatalf("unexpected scores: %f %f %f", precision, recall, f1)
+	}
+}
+
+func TestReportErrors(t *testing.T) {
+	scores := []score{
+		{name: "good", precision: 1, recall: 1, f1: 1},
+		{name: "broken", err: errors.New("failed to parse input")},
+	}
+
+	var out bytes.Buffer
+
+	if average := report(&out, scores); average.f1 != 0.5 {
+		t.Fatalf("the page that failed should count as zero: %f", average.f1)
+	}
+
+	if !strings.Contains(out.String(), "error: failed to parse input") {
+		t.Fatalf("the error was not reported:\n%s", out.String())
+	}
+}
+
+func TestTextContentBlocks(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader("<p>first</p><p>second</p>"))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	if _, _, f1 := tokenScores(textContent(doc), "first second"); f1 != 1 {
+		t.Fatalf("the words of adjacent blocks were joined: %q", textContent(doc))
+	}
+}
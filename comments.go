@@ -0,0 +1,22 @@
+package readability
+
+import (
+	"regexp"
+
+	"golang.org/x/net/html"
+)
+
+// rxConditionalComment matches the data of the conditional comments of old
+// versions of Internet Explorer, both the ones that hide markup from other
+// browsers, like "[if lt IE 9]><script src="shiv.js"></script><![endif]",
+// and the markers that wrap markup revealed to every browser, like
+// "[if !IE]><!" and "<![endif]".
+var rxConditionalComment = regexp.MustCompile(`(?is)^\s*(\[if\s[^\]]*\]>.*|<!\[endif\])\s*$`)
+
+// isConditionalComment returns true if node is a conditional comment. The
+// markup hidden inside them is meant for Internet Explorer, which is never
+// what a reader wants to see, and the markers of the revealed markup have no
+// meaning once the markup is extracted.
+func isConditionalComment(node *html.Node) bool {
+	return node.Type == html.CommentNode && rxConditionalComment.MatchString(node.Data)
+}
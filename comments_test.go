@@ -0,0 +1,56 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+const commentsTestPage = `<html>
+	<head><title>hello world</title></head>
+	<body>
+		<article>
+			<!-- marker:start -->
+			<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.<!-- inline --></p>
+			<!--[if lt IE 9]><p>Your browser is out of date.</p><![endif]-->
+			<!--[if !IE]><!--><p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p><!--<![endif]-->
+			<!-- marker:end -->
+		</article>
+	</body>
+	</html>`
+
+func TestRemoveComments(t *testing.T) {
+	a, err := New().Parse(strings.NewReader(commentsTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Contains(a.Content, "<!--") {
+		t.Fatalf("the comments were not removed: %s", a.Content)
+	}
+
+	if !strings.Contains(a.Content, "Ut enim") {
+		t.Fatalf("the revealed content was removed: %s", a.Content)
+	}
+}
+
+func TestKeepComments(t *testing.T) {
+	parser := New()
+	parser.KeepComments = true
+
+	a, err := parser.Parse(strings.NewReader(commentsTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, comment := range []string{"<!-- marker:start -->", "<!-- inline -->", "<!-- marker:end -->"} {
+		if !strings.Contains(a.Content, comment) {
+			t.Fatalf("comment %q was removed: %s", comment, a.Content)
+		}
+	}
+
+	if strings.Contains(a.Content, "[if") || strings.Contains(a.Content, "[endif]") {
+		t.Fatalf("the conditional comments were not removed: %s", a.Content)
+	}
+}
@@ -0,0 +1,45 @@
+package readability
+
+import (
+	"regexp"
+
+	"golang.org/x/net/html"
+)
+
+// rxConsentVendor matches the class names and ids used by the consent
+// management platforms. The names are matched as whole words, or as the first
+// parts of words split by hyphens and underscores, like "truste-consent-track",
+// so words that only contain them, like "trusted", are left alone.
+var rxConsentVendor = regexp.MustCompile(`(?i)(^|[\s_-])(onetrust|optanon|cookiebot|cybotcookiebot\w*|didomi|qc-cmp2?|quantcast-choice|truste|trustarc|usercentrics|sp_message_container\w*|fc-consent|osano|iubenda|klaro|cookielaw|cc-window|cookieconsent|termly|cmp-container|cookie-?(banner|bar|consent|notice|notification|popup|wall|overlay)|consent-?(banner|manager|modal|overlay|popup|dialog|wall)|gdpr-?(banner|consent|notice|overlay|popup))([\s_-]|$)`)
+var rxConsentText = regexp.MustCompile(`(?i)cookie|consent|gdpr|privacy|datenschutz|rgpd|privacidad|confidentialité|privacy policy`)
+var rxFixedPosition = regexp.MustCompile(`(?i)position\s*:\s*(fixed|sticky)`)
+
+// removeConsentOverlays removes the cookie banners and consent dialogs from
+// the document before the content is scored. On short articles, they can have
+// more text than the article itself and end up selected as the content.
+//
+// The overlays are recognized by the class names and ids used by the most
+// common consent management platforms, or by their fixed position in the
+// inline styles combined with text about cookies and privacy.
+func (r *Readability) removeConsentOverlays(root *html.Node) {
+	r.removeNodes(getElementsByTagName(root, "*"), r.reportingFilter(RemovalConsent, func(node *html.Node) bool {
+		if node == root {
+			return false
+		}
+
+		vendor := rxConsentVendor.MatchString(r.matchString(node))
+
+		if !vendor && !rxFixedPosition.MatchString(getAttribute(node, "style")) {
+			return false
+		}
+
+		// The overlays are short, longer elements are likely the content.
+		text := r.getInnerText(node, true)
+
+		if len(text) >= 2*r.CharThresholds {
+			return false
+		}
+
+		return vendor || rxConsentText.MatchString(text)
+	}))
+}
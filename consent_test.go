@@ -0,0 +1,86 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestRemoveConsentOverlays(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>
+			</article>
+			<div id="onetrust-consent-sdk">
+				<div class="ot-sdk-container">
+					<p>We and our partners use cookies, and similar technologies, to store and access information on your device, to personalise ads and content, to measure ads and content, to get audience insights and to develop products. With your permission, we and our partners may use precise geolocation data and identification through device scanning.</p>
+					<button>Accept all</button>
+				</div>
+			</div>
+			<div style="position: fixed; bottom: 0">
+				<p>This website uses cookies to ensure you get the best experience, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua, read our privacy policy to learn more.</p>
+			</div>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.RemoveConsentOverlays = true
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Contains(a.TextContent, "cookies") || !strings.Contains(a.TextContent, "Lorem ipsum") {
+		t.Fatalf("consent overlays were not removed: %s", a.TextContent)
+	}
+}
+
+func TestConsentVendorWords(t *testing.T) {
+	tests := map[string]bool{
+		`<div id="onetrust-consent-sdk">`:    true,
+		`<div class="truste-consent-track">`: true,
+		`<div class="banner trustarc">`:      true,
+		`<div id="CybotCookiebotDialog">`:    true,
+		`<div class="site cookie-banner">`:   true,
+		`<div class="trusted-source">`:       false,
+		`<div class="trustee-board">`:        false,
+		`<div class="no-klaxon">`:            false,
+	}
+
+	for input, expected := range tests {
+		doc, err := html.Parse(strings.NewReader(input))
+
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
+		}
+
+		node := getElementsByTagName(doc, "div")[0]
+
+		if result := rxConsentVendor.MatchString(New().matchString(node)); result != expected {
+			t.Fatalf("rxConsentVendor(%s) = %t, expecting %t", input, result, expected)
+		}
+	}
+}
+
+func TestConsentVendorLongContent(t *testing.T) {
+	article := strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore. ", 16)
+	input := `<html><head><title>hello world</title></head><body>` +
+		`<div class="trustarc-source"><p>` + article + `</p></div>` +
+		`</body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.TextContent, "Lorem ipsum") {
+		t.Fatalf("the content was removed as a consent overlay: %q", a.TextContent)
+	}
+}
@@ -0,0 +1,119 @@
+package readability
+
+import (
+	"io"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// WriteContent writes the relevant text of the article with HTML tags to w,
+// serializing the elements one at a time instead of building the whole string
+// in memory. It writes the same text found in Content, or the text that would
+// have been there if the article was parsed with OmitContentStrings.
+func (a Article) WriteContent(w io.Writer) error {
+	if a.content == nil {
+		_, err := io.WriteString(w, a.Content)
+		return err
+	}
+
+	for child := a.content.FirstChild; child != nil; child = child.NextSibling {
+		if err := html.Render(w, child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteText writes the relevant text of the article without HTML tags to w,
+// the same text found in TextContent, or that would have been there with
+// OmitContentStrings. The text is streamed, unless there are TextTransforms,
+// which need the whole text at once.
+func (a Article) WriteText(w io.Writer) error {
+	if a.content == nil {
+		_, err := io.WriteString(w, a.TextContent)
+		return err
+	}
+
+	if len(a.transforms) > 0 {
+		text := strings.TrimSpace(textContent(a.content))
+
+		for _, transform := range a.transforms {
+			if transform != nil {
+				text = transform(text)
+			}
+		}
+
+		_, err := io.WriteString(w, text)
+		return err
+	}
+
+	tw := &trimWriter{w: w}
+
+	return tw.writeNode(a.content)
+}
+
+// trimWriter writes the text nodes of a tree without the leading and trailing
+// white space of the whole text, like strings.TrimSpace does.
+type trimWriter struct {
+	w       io.Writer
+	started bool
+	pending string
+}
+
+// writeNode writes the text nodes found in node and its descendants.
+func (tw *trimWriter) writeNode(node *html.Node) error {
+	if node.Type == html.TextNode {
+		return tw.writeText(node.Data)
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if err := tw.writeNode(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeText writes data, holding its trailing white space back until more
+// text is written, so the white space at the end of the text is dropped.
+func (tw *trimWriter) writeText(data string) error {
+	if !tw.started {
+		data = strings.TrimLeftFunc(data, unicode.IsSpace)
+
+		if data == "" {
+			return nil
+		}
+
+		tw.started = true
+	}
+
+	body := strings.TrimRightFunc(data, unicode.IsSpace)
+
+	if body == "" {
+		tw.pending += data
+		return nil
+	}
+
+	if _, err := io.WriteString(tw.w, tw.pending+body); err != nil {
+		return err
+	}
+
+	tw.pending = data[len(body):]
+
+	return nil
+}
+
+// fingerprint sets the length and the fingerprints of the article, streaming
+// its text once without rendering it, so the text of the Result is still only
+// computed on the first call to Text.
+func (r *Readability) fingerprint(result *Result) {
+	fw := newFingerprintWriter()
+	result.WriteText(fw)
+
+	result.Length = fw.length
+	result.Hash, result.SimHash = fw.finish()
+}
@@ -0,0 +1,134 @@
+package readability
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const contentTestPage = `<html>
+	<head><title>Streaming</title></head>
+	<body>
+		<article>
+			<p>  The first paragraph of the article, long enough to be kept.  </p>
+			<p>The second paragraph of the article, also long enough.</p>
+		</article>
+	</body>
+	</html>`
+
+func TestWriteContent(t *testing.T) {
+	a, err := New().Parse(strings.NewReader(contentTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	var content, text bytes.Buffer
+
+	if err := a.WriteContent(&content); err != nil {
+		t.Fatalf("cannot write content: %s", err)
+	}
+
+	if err := a.WriteText(&text); err != nil {
+		t.Fatalf("cannot write text: %s", err)
+	}
+
+	if content.String() != a.Content {
+		t.Fatalf("unexpected content:\n%s\n%s", content.String(), a.Content)
+	}
+
+	if text.String() != a.TextContent {
+		t.Fatalf("unexpected text:\n%q\n%q", text.String(), a.TextContent)
+	}
+}
+
+func TestOmitContentStrings(t *testing.T) {
+	expected, err := New().Parse(strings.NewReader(contentTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	parser := New()
+	parser.OmitContentStrings = true
+	a, err := parser.Parse(strings.NewReader(contentTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Content != "" || a.TextContent != "" {
+		t.Fatalf("the content strings were populated: %#v", a)
+	}
+
+	if a.Length != expected.Length {
+		t.Fatalf("unexpected length: %d != %d", a.Length, expected.Length)
+	}
+
+	var text bytes.Buffer
+
+	if err := a.WriteText(&text); err != nil || text.String() != expected.TextContent {
+		t.Fatalf("unexpected text: %q %v", text.String(), err)
+	}
+}
+
+func TestWriteTextTransforms(t *testing.T) {
+	parser := New()
+	parser.TextTransforms = []TextTransform{strings.ToUpper}
+	a, err := parser.Parse(strings.NewReader(contentTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	var text bytes.Buffer
+
+	if err := a.WriteText(&text); err != nil || text.String() != a.TextContent {
+		t.Fatalf("unexpected text: %q %v", text.String(), err)
+	}
+}
+
+func TestOmitContentStringsRenderers(t *testing.T) {
+	page := `<html><head><title>Streaming</title></head><body><article>
+		<h2>First part</h2>
+		<p>The first paragraph of the article, long enough to be kept by the parser.</p>
+		<h2>Second part</h2>
+		<p>The second paragraph of the article, also long enough to be kept.</p>
+	</article></body></html>`
+
+	expected, err := New().Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	parser := New()
+	parser.OmitContentStrings = true
+	a, err := parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for name, renderer := range map[string]ArticleRenderer{"html": HTMLRenderer{}, "ssml": SSMLRenderer{}} {
+		var omitted, full bytes.Buffer
+
+		if err := renderer.Render(&omitted, a); err != nil {
+			t.Fatalf("%s render failure: %s", name, err)
+		}
+
+		if err := renderer.Render(&full, expected); err != nil {
+			t.Fatalf("%s render failure: %s", name, err)
+		}
+
+		if omitted.String() != full.String() || !strings.Contains(omitted.String(), "first paragraph") {
+			t.Fatalf("%s rendering without the content strings differs:\n%s\n%s", name, omitted.String(), full.String())
+		}
+	}
+
+	outline := a.Outline()
+
+	if len(outline.Sections) != 2 || outline.Sections[1].Heading != "Second part" {
+		t.Fatalf("unexpected outline: %#v", outline)
+	}
+}
@@ -0,0 +1,101 @@
+package readability
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// CustomElementPolicy is how the parser treats the elements that are not part
+// of the HTML specification, like web components and framework placeholders.
+type CustomElementPolicy int
+
+const (
+	// CustomElementsKeep leaves the unknown elements as they are. They are
+	// not scored and their text counts as the text of their parent.
+	CustomElementsKeep CustomElementPolicy = iota
+
+	// CustomElementsUnwrap replaces the unknown elements with their children,
+	// as if they were transparent containers.
+	CustomElementsUnwrap
+
+	// CustomElementsBlock converts the unknown elements into <div> elements,
+	// so they are scored and cleaned like any other block.
+	CustomElementsBlock
+)
+
+// customElementConverters are the built-in conversions of known components
+// into their standard HTML equivalents. They replace the element with the
+// returned node, or remove it if the returned node is nil.
+var customElementConverters = map[string]func(*html.Node) *html.Node{
+	"amp-img":     convertAMPImage,
+	"amp-anim":    convertAMPImage,
+	"amp-video":   convertAMPMedia,
+	"amp-audio":   convertAMPMedia,
+	"amp-iframe":  convertAMPIframe,
+	"amp-youtube": convertAMPYouTube,
+	"amp-vimeo":   convertAMPVimeo,
+}
+
+var customElementPoliciesMu sync.RWMutex
+
+// customElementPolicies is the registry of the policies for specific tags.
+var customElementPolicies = map[string]CustomElementPolicy{}
+
+// RegisterCustomElement sets the policy used for the elements with the given
+// tag name, regardless of the CustomElements option of the parser. It affects
+// all the parsers, and is safe for concurrent use.
+func RegisterCustomElement(tag string, policy CustomElementPolicy) {
+	customElementPoliciesMu.Lock()
+	defer customElementPoliciesMu.Unlock()
+
+	customElementPolicies[strings.ToLower(tag)] = policy
+}
+
+// isCustomElement returns true if node is an HTML element that is not part of
+// the HTML specification. SVG and MathML elements are not custom elements.
+func isCustomElement(node *html.Node) bool {
+	return node.Type == html.ElementNode &&
+		node.Namespace == "" &&
+		atom.Lookup([]byte(node.Data)) == 0
+}
+
+// customElementPolicy returns the policy for the elements with the given tag.
+func (r *Readability) customElementPolicy(tag string) CustomElementPolicy {
+	customElementPoliciesMu.RLock()
+	defer customElementPoliciesMu.RUnlock()
+
+	if policy, ok := customElementPolicies[tag]; ok {
+		return policy
+	}
+
+	return r.CustomElements
+}
+
+// convertCustomElements applies the built-in conversions and the configured
+// policies to the custom elements of the document.
+func (r *Readability) convertCustomElements(root *html.Node) {
+	for _, node := range getElementsByTagName(root, "*") {
+		if node.Parent == nil || !isCustomElement(node) {
+			continue
+		}
+
+		if convert, ok := customElementConverters[node.Data]; ok {
+			if replacement := convert(node); replacement != nil {
+				replaceNode(node, replacement)
+			} else {
+				node.Parent.RemoveChild(node)
+			}
+			continue
+		}
+
+		switch r.customElementPolicy(node.Data) {
+		case CustomElementsUnwrap:
+			unwrapNode(node)
+		case CustomElementsBlock:
+			r.setNodeTag(node, "div")
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCustomElements(t *testing.T) {
+	input := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<amp-img src="/photo.jpg" width="640" height="480" alt="Photo"><noscript><img src="/photo.jpg"></noscript></amp-img>
+				<my-paragraph>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</my-paragraph>
+				<x-note>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</x-note>
+			</article>
+		</body>
+		</html>`
+
+	RegisterCustomElement("x-note", CustomElementsBlock)
+	defer RegisterCustomElement("x-note", CustomElementsKeep)
+
+	parser := New()
+	parser.CustomElements = CustomElementsUnwrap
+	a, err := parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{
+		`<img src="https://cixtor.com/photo.jpg" alt="Photo" width="640" height="480"/>`,
+		`Lorem ipsum dolor sit amet`,
+		`<p>Ut enim ad minim veniam`,
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(a.Content, fragment) {
+			t.Fatalf("expecting %s in the content:\n%s", fragment, a.Content)
+		}
+	}
+
+	if strings.Contains(a.Content, "my-paragraph") || strings.Contains(a.Content, "x-note") {
+		t.Fatalf("custom elements were not converted:\n%s", a.Content)
+	}
+}
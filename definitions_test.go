@@ -0,0 +1,70 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefinitionLists(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>Glossary</title>
+		</head>
+		<body>
+			<div class="glossary">
+				<h1>Glossary</h1>
+				<p>Newsrooms have their own vocabulary, with words that are rarely used outside of the profession, and which can be confusing for new reporters, editors and readers alike.</p>
+				<p>This glossary collects the terms that appear most often in our style guide, in alphabetical order, together with a short definition and a link to the section of the guide where they are explained in detail.</p>
+				<p>The definitions are deliberately brief, the sections of the style guide contain the examples and the exceptions to each one of the rules, as well as the history behind some of them.</p>
+				<div>
+					<dl>
+						<dt>Byline</dt>
+						<dd>A line of text accompanying a story that gives the <a href="/author">name of the author of the story</a></dd>
+						<dt>Dateline</dt>
+						<dd>A line at the beginning of a story stating <a href="/written">the place where it was written by the reporter</a></dd>
+						<dt>Lede</dt>
+						<dd>The opening sentence or paragraph of a news story, <a href="/points">summarizing the main points</a></dd>
+					</dl>
+				</div>
+			</div>
+			<div class="sidebar"><a href="/a">Home</a> <a href="/b">About</a></div>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, term := range []string{"<dt>Byline</dt>", "<dt>Dateline</dt>", "<dt>Lede</dt>", "summarizing the main points"} {
+		if !strings.Contains(a.Content, term) {
+			t.Fatalf("expecting %q in the content:\n%s", term, a.Content)
+		}
+	}
+
+	if strings.Contains(a.Content, "data-readability") {
+		t.Fatalf("readability attributes were not removed:\n%s", a.Content)
+	}
+}
+
+func TestDefinitionListsNavigation(t *testing.T) {
+	parser := New()
+	node := createElement("dl")
+
+	for _, text := range []string{"Home", "About"} {
+		dt := createElement("dt")
+		dd := createElement("dd")
+		link := createElement("a")
+		setAttribute(link, "href", "/"+text)
+		link.AppendChild(createTextNode(text + " page of the website with the latest news and stories"))
+		dt.AppendChild(createTextNode(text))
+		dd.AppendChild(link)
+		node.AppendChild(dt)
+		node.AppendChild(dd)
+	}
+
+	if parser.isDefinitionList(node) {
+		t.Fatalf("navigation lists should not be protected")
+	}
+}
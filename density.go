@@ -0,0 +1,287 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Engine is an algorithm used to find the content of the article.
+type Engine int
+
+const (
+	// EngineReadability scores the candidates with the heuristics of the
+	// Readability.js project. It is the default engine.
+	EngineReadability Engine = iota
+
+	// EngineDensity splits the document in blocks of text and keeps the ones
+	// with enough words and few links, similar to the Boilerpipe project. It
+	// is faster and does not depend on class names, but loses the markup.
+	EngineDensity
+)
+
+// String returns the name of the engine.
+func (e Engine) String() string {
+	switch e {
+	case EngineReadability:
+		return "readability"
+	case EngineDensity:
+		return "density"
+	}
+
+	return "unknown"
+}
+
+// Candidate is the text extracted by one of the engines.
+type Candidate struct {
+	Engine Engine
+	Text   string
+}
+
+// crossCheckAgreement is the minimum similarity between the content found by
+// both engines for them to be considered in agreement.
+const crossCheckAgreement = 0.8
+
+// densityBlockElems is a list of HTML tag names that split the text blocks.
+var densityBlockElems = []string{
+	"address", "article", "aside", "blockquote", "body", "br", "dd", "div",
+	"dl", "dt", "fieldset", "figcaption", "figure", "footer", "form", "h1",
+	"h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "main", "nav", "ol",
+	"p", "pre", "section", "table", "td", "th", "tr", "ul",
+}
+
+// densityHeadings is a list of HTML tag names kept as headings in the content.
+var densityHeadings = []string{"h1", "h2", "h3", "h4", "h5", "h6"}
+
+// textBlock is a piece of text between two block elements.
+type textBlock struct {
+	tag         string
+	text        strings.Builder
+	words       int
+	linkedWords int
+}
+
+// linkDensity returns the fraction of the words of the block inside links.
+func (b *textBlock) linkDensity() float64 {
+	if b.words == 0 {
+		return 0
+	}
+
+	return float64(b.linkedWords) / float64(b.words)
+}
+
+// textBlocks splits the text of node in blocks, one for each run of text
+// between two block elements.
+func textBlocks(node *html.Node) []*textBlock {
+	var blocks []*textBlock
+
+	current := &textBlock{}
+
+	flush := func(tag string) {
+		if current.words > 0 {
+			blocks = append(blocks, current)
+		}
+
+		current = &textBlock{tag: tag}
+	}
+
+	var walk func(*html.Node, string, bool)
+
+	walk = func(n *html.Node, tag string, inLink bool) {
+		switch n.Type {
+		case html.TextNode:
+			words := len(strings.Fields(n.Data))
+			current.text.WriteString(n.Data)
+			current.words += words
+
+			if inLink {
+				current.linkedWords += words
+			}
+
+			return
+		case html.ElementNode:
+			switch n.Data {
+			case "script", "style", "noscript", "template", "svg", "iframe", "select", "textarea", "button":
+				return
+			}
+		case html.DocumentNode:
+		default:
+			return
+		}
+
+		isBlock := indexOf(densityBlockElems, n.Data) != -1
+
+		if isBlock {
+			tag = n.Data
+			flush(tag)
+		}
+
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, tag, inLink || n.Data == "a")
+		}
+
+		if isBlock {
+			flush(tag)
+		}
+	}
+
+	walk(node, "", false)
+	flush("")
+
+	return blocks
+}
+
+// isContentBlock classifies a block as content using the number of words and
+// the link density of the block and its neighbors.
+func isContentBlock(prev *textBlock, curr *textBlock, next *textBlock) bool {
+	if curr.linkDensity() > 0.333 {
+		return false
+	}
+
+	if prev.linkDensity() <= 0.555 {
+		return curr.words > 16 || next.words > 15 || prev.words > 4
+	}
+
+	return curr.words > 40 || next.words > 17
+}
+
+// densityArticle finds the content of the document with the text density
+// engine. It returns nil if no block of text looks like content.
+func (r *Readability) densityArticle(doc *html.Node) *html.Node {
+	bodies := getElementsByTagName(doc, "body")
+
+	if len(bodies) == 0 {
+		return nil
+	}
+
+	blocks := textBlocks(bodies[0])
+	empty := &textBlock{}
+
+	page := createElement("div")
+	setAttribute(page, "id", "readability-page-1")
+	setAttribute(page, "class", "page")
+
+	for i, curr := range blocks {
+		prev, next := empty, empty
+
+		if i > 0 {
+			prev = blocks[i-1]
+		}
+
+		if i < len(blocks)-1 {
+			next = blocks[i+1]
+		}
+
+		if !isContentBlock(prev, curr, next) {
+			continue
+		}
+
+		tag := "p"
+
+		if indexOf(densityHeadings, curr.tag) != -1 {
+			tag = curr.tag
+		}
+
+		elem := createElement(tag)
+		elem.AppendChild(createTextNode(strings.Join(strings.Fields(curr.text.String()), "\x20")))
+		page.AppendChild(elem)
+	}
+
+	if page.FirstChild == nil {
+		return nil
+	}
+
+	articleContent := createElement("div")
+	articleContent.AppendChild(page)
+
+	return articleContent
+}
+
+// tokenSimilarity returns the F1 score of the words of a compared with the
+// words of b, a value between 0 for unrelated texts and 1 for the same words.
+func tokenSimilarity(a string, b string) float64 {
+	wordsA := strings.Fields(strings.ToLower(a))
+	wordsB := strings.Fields(strings.ToLower(b))
+
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		if len(wordsA) == len(wordsB) {
+			return 1
+		}
+
+		return 0
+	}
+
+	counts := make(map[string]int, len(wordsA))
+
+	for _, word := range wordsA {
+		counts[word]++
+	}
+
+	common := 0
+
+	for _, word := range wordsB {
+		if counts[word] > 0 {
+			counts[word]--
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(wordsA)+len(wordsB))
+}
+
+// grabContent finds the content of the article with the selected Engine and,
+// if CrossCheck is enabled, with the other engine too, returned second.
+func (r *Readability) grabContent() (*html.Node, *html.Node) {
+	var content *html.Node
+	var alternative *html.Node
+
+	if r.Engine == EngineDensity {
+		content = r.densityArticle(r.doc)
+
+		if r.CrossCheck {
+			alternative = r.grabArticle()
+		}
+
+		return content, alternative
+	}
+
+	if content = r.learnedArticle(); content == nil {
+		content = r.grabArticle()
+		r.learnSelector()
+	}
+
+	if r.CrossCheck {
+		alternative = r.densityArticle(r.doc)
+	}
+
+	return content, alternative
+}
+
+// crossCheck compares the content of the result with the content found by the
+// other engine. When both engines agree, the confidence is raised, otherwise
+// the text found by each engine is added to the candidates of the result.
+func (r *Readability) crossCheck(result *Result, alternative *html.Node) {
+	other := EngineDensity
+
+	if r.Engine == EngineDensity {
+		other = EngineReadability
+	}
+
+	alternativeText := ""
+
+	if alternative != nil {
+		alternativeText = strings.Join(strings.Fields(textContent(alternative)), "\x20")
+	}
+
+	result.Agreement = tokenSimilarity(result.Text(), alternativeText)
+
+	if result.Agreement >= crossCheckAgreement {
+		result.Confidence += (1 - result.Confidence) / 2
+		return
+	}
+
+	result.Candidates = []Candidate{
+		{Engine: r.Engine, Text: result.Text()},
+		{Engine: other, Text: alternativeText},
+	}
+}
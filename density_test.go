@@ -0,0 +1,60 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const densityPage = `<html>
+	<head>
+		<title>hello world</title>
+	</head>
+	<body>
+		<nav><a href="/">Home</a> <a href="/blog">Blog</a> <a href="/about">About</a></nav>
+		<div>
+			<h2>Chapter one</h2>
+			<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+			<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+		</div>
+		<footer><a href="/terms">Terms</a> <a href="/privacy">Privacy</a></footer>
+	</body>
+	</html>`
+
+func TestDensityEngine(t *testing.T) {
+	parser := New()
+	parser.Engine = EngineDensity
+	parser.CrossCheck = true
+	res, err := parser.Analyze(strings.NewReader(densityPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if res.Engine != EngineDensity || !strings.HasPrefix(res.HTML(), `<div id="readability-page-1" class="page"><h2>Chapter one</h2><p>Lorem ipsum`) {
+		t.Fatalf("unexpected content: %s", res.HTML())
+	}
+
+	if strings.Contains(res.Text(), "Home") || strings.Contains(res.Text(), "Privacy") {
+		t.Fatalf("boilerplate was not removed: %s", res.Text())
+	}
+
+	if res.Agreement < crossCheckAgreement || len(res.Candidates) != 0 {
+		t.Fatalf("engines should agree: %f %#v", res.Agreement, res.Candidates)
+	}
+}
+
+func TestTextBlocks(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<p>one <a href="/">two three</a></p><div>four<br>five six</div>`))
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	blocks := textBlocks(doc)
+
+	if len(blocks) != 3 || blocks[0].words != 3 || blocks[0].linkedWords != 2 || blocks[2].words != 2 {
+		t.Fatalf("unexpected blocks: %d", len(blocks))
+	}
+}
@@ -0,0 +1,59 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetails(t *testing.T) {
+	input := `<html>
+		<head>
+			<title>Frequently asked questions</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<details>
+					<summary>Is it free?</summary>
+					<div hidden>Yes, it is.</div>
+				</details>
+				<details>
+					<summary>Can I use it offline?</summary>
+					<div aria-hidden="true"><p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p></div>
+				</details>
+			</article>
+		</body>
+		</html>`
+
+	parser := New()
+	a, err := parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, fragment := range []string{"<summary>Is it free?</summary>", "<p>Yes, it is.</p>", "<p>Ut enim ad minim veniam"} {
+		if !strings.Contains(a.Content, fragment) {
+			t.Fatalf("expecting %s in the content:\n%s", fragment, a.Content)
+		}
+	}
+
+	if strings.Contains(a.Content, "hidden") {
+		t.Fatalf("the body of the details is still hidden:\n%s", a.Content)
+	}
+
+	if strings.Contains(a.Content, "<details open") {
+		t.Fatalf("details were expanded without the option:\n%s", a.Content)
+	}
+
+	parser.ExpandDetails = true
+	a, err = parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Count(a.Content, `<details open="">`) != 2 {
+		t.Fatalf("details were not expanded:\n%s", a.Content)
+	}
+}
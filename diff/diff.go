@@ -0,0 +1,215 @@
+// Package diff compares two HTML trees, usually the content extracted by the
+// readability parser and the content expected for the same page, and reports
+// the differences node by node. It is meant for regression suites of site
+// specific rules.
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cixtor/readability/internal/dom"
+	"golang.org/x/net/html"
+)
+
+// Kind is the type of a difference between two nodes.
+type Kind int
+
+const (
+	// Tag means that the nodes have different tag names. The children of
+	// the nodes are not compared.
+	Tag Kind = iota
+
+	// Attribute means that an attribute is missing or has a different value.
+	Attribute
+
+	// Text means that the text directly inside the nodes is different, after
+	// collapsing the whitespace.
+	Text
+
+	// Children means that the nodes have a different number of child
+	// elements. The children are compared in pairs up to the shortest list.
+	Children
+)
+
+// String returns the name of the kind of difference.
+func (k Kind) String() string {
+	switch k {
+	case Tag:
+		return "tag"
+	case Attribute:
+		return "attribute"
+	case Text:
+		return "text"
+	case Children:
+		return "children"
+	}
+
+	return "unknown"
+}
+
+// Difference describes one difference between the expected and actual trees.
+type Difference struct {
+	// Kind is the type of difference.
+	Kind Kind
+
+	// Path locates the node in the expected tree, for example "/div/p[2]",
+	// where the indexes count the siblings with the same tag name.
+	Path string
+
+	// Name is the name of the attribute for differences of Attribute kind.
+	Name string
+
+	// Expected is the value found in the expected tree.
+	Expected string
+
+	// Actual is the value found in the actual tree.
+	Actual string
+}
+
+// String returns a human readable description of the difference.
+func (d Difference) String() string {
+	if d.Kind == Attribute {
+		return fmt.Sprintf("%s: attribute %s is different\nwant: %q\ngot : %q", d.Path, d.Name, d.Expected, d.Actual)
+	}
+
+	return fmt.Sprintf("%s: %s is different\nwant: %q\ngot : %q", d.Path, d.Kind, d.Expected, d.Actual)
+}
+
+// Options changes how the nodes are compared.
+type Options struct {
+	// IgnoreAttributes is a list of attributes that are not compared.
+	IgnoreAttributes []string
+
+	// IgnoreTrailingSlash ignores the trailing slash of URLs in the href and
+	// src attributes, which some serializers add and others remove.
+	IgnoreTrailingSlash bool
+}
+
+// HTML parses both documents and compares them, see Nodes.
+func HTML(expected string, actual string, opts Options) ([]Difference, error) {
+	expectedNode, err := html.Parse(strings.NewReader(expected))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expected html: %v", err)
+	}
+
+	actualNode, err := html.Parse(strings.NewReader(actual))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse actual html: %v", err)
+	}
+
+	return Nodes(expectedNode, actualNode, opts), nil
+}
+
+// Nodes compares the element trees of expected and actual, and returns the
+// differences in document order. Comments are ignored, and text is compared
+// after collapsing the whitespace. If the trees are equivalent, the list is
+// empty.
+func Nodes(expected *html.Node, actual *html.Node, opts Options) []Difference {
+	var diffs []Difference
+
+	compare(&diffs, "", expected, actual, opts)
+
+	return diffs
+}
+
+// compare appends the differences between two nodes and their descendants.
+func compare(diffs *[]Difference, path string, expected *html.Node, actual *html.Node, opts Options) {
+	if expected.Data != actual.Data || expected.Type != actual.Type {
+		*diffs = append(*diffs, Difference{Kind: Tag, Path: path, Expected: expected.Data, Actual: actual.Data})
+		return
+	}
+
+	compareAttributes(diffs, path, expected, actual, opts)
+
+	if expectedText, actualText := ownText(expected), ownText(actual); expectedText != actualText {
+		*diffs = append(*diffs, Difference{Kind: Text, Path: path, Expected: expectedText, Actual: actualText})
+	}
+
+	expectedChildren := dom.Children(expected)
+	actualChildren := dom.Children(actual)
+
+	if len(expectedChildren) != len(actualChildren) {
+		*diffs = append(*diffs, Difference{
+			Kind:     Children,
+			Path:     path,
+			Expected: fmt.Sprint(len(expectedChildren)),
+			Actual:   fmt.Sprint(len(actualChildren)),
+		})
+	}
+
+	counts := map[string]int{}
+
+	for i := 0; i < len(expectedChildren) && i < len(actualChildren); i++ {
+		child := expectedChildren[i]
+		counts[child.Data]++
+
+		childPath := path + "/" + child.Data
+
+		if counts[child.Data] > 1 {
+			childPath += fmt.Sprintf("[%d]", counts[child.Data])
+		}
+
+		compare(diffs, childPath, child, actualChildren[i], opts)
+	}
+}
+
+// compareAttributes appends the differences between the attributes of nodes.
+func compareAttributes(diffs *[]Difference, path string, expected *html.Node, actual *html.Node, opts Options) {
+	var names []string
+
+	for _, nodes := range []*html.Node{expected, actual} {
+		for _, attr := range nodes.Attr {
+			if !contains(names, attr.Key) && !contains(opts.IgnoreAttributes, attr.Key) {
+				names = append(names, attr.Key)
+			}
+		}
+	}
+
+	for _, name := range names {
+		expectedVal, expectedOk := dom.Attribute(expected, name)
+		actualVal, actualOk := dom.Attribute(actual, name)
+
+		if opts.IgnoreTrailingSlash && (name == "href" || name == "src") {
+			expectedVal = strings.TrimSuffix(expectedVal, "/")
+			actualVal = strings.TrimSuffix(actualVal, "/")
+		}
+
+		if expectedOk != actualOk || expectedVal != actualVal {
+			*diffs = append(*diffs, Difference{
+				Kind:     Attribute,
+				Path:     path,
+				Name:     name,
+				Expected: expectedVal,
+				Actual:   actualVal,
+			})
+		}
+	}
+}
+
+// ownText returns the text of the direct children of node with the
+// whitespace collapsed.
+func ownText(node *html.Node) string {
+	var text []string
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.TextNode {
+			text = append(text, strings.Fields(child.Data)...)
+		}
+	}
+
+	return strings.Join(text, "\x20")
+}
+
+// contains returns true if list contains value.
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
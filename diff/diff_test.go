@@ -0,0 +1,36 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	expected := `<div id="page"><p>Hello <b>world</b></p><p><a href="https://cixtor.com/">link</a></p><ul><li>one</li></ul></div>`
+	actual := `<div id="main"><p>Hello   <i>world</i></p><p><a href="https://cixtor.com">link</a></p><ul><li>one</li><li>two</li></ul></div>`
+
+	diffs, err := HTML(expected, actual, Options{IgnoreTrailingSlash: true})
+
+	if err != nil {
+		t.Fatalf("diff failure: %s", err)
+	}
+
+	want := []Difference{
+		{Kind: Attribute, Path: "/html/body/div", Name: "id", Expected: "page", Actual: "main"},
+		{Kind: Tag, Path: "/html/body/div/p/b", Expected: "b", Actual: "i"},
+		{Kind: Children, Path: "/html/body/div/ul", Expected: "1", Actual: "2"},
+	}
+
+	if len(diffs) != len(want) {
+		t.Fatalf("unexpected differences: %v", diffs)
+	}
+
+	for i := range want {
+		if diffs[i] != want[i] {
+			t.Fatalf("unexpected difference #%d:\n%s", i, diffs[i])
+		}
+	}
+
+	if diffs, _ := HTML(expected, expected, Options{}); len(diffs) != 0 {
+		t.Fatalf("equal trees should not differ: %v", diffs)
+	}
+}
@@ -0,0 +1,153 @@
+package readability
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+)
+
+// Theme is the reader stylesheet used to export an article as a standalone
+// HTML document. Empty fields are left out of the stylesheet so the defaults
+// of the browser are used instead.
+type Theme struct {
+	// Foreground is the color of the text.
+	Foreground string
+
+	// Background is the color of the page.
+	Background string
+
+	// Link is the color of the hyperlinks.
+	Link string
+
+	// FontFamily is the list of fonts used for the text, for example
+	// "Georgia, serif".
+	FontFamily string
+
+	// FontSize is the base size of the text, for example "18px".
+	FontSize string
+
+	// Measure is the maximum width of the text column, for example "38em".
+	Measure string
+
+	// LineHeight is the height of each line of text, for example "1.6".
+	LineHeight string
+
+	// CSS is a custom stylesheet appended after the rules generated from the
+	// other fields, so it can override or extend them.
+	CSS string
+}
+
+// ThemeLight is a reader theme with dark text over a white background.
+var ThemeLight = Theme{
+	Foreground: "#1b1b1b",
+	Background: "#ffffff",
+	Link:       "#0a58ca",
+	FontFamily: "Georgia, \"Times New Roman\", serif",
+	FontSize:   "20px",
+	Measure:    "38em",
+	LineHeight: "1.6",
+}
+
+// ThemeDark is a reader theme with light text over a dark background.
+var ThemeDark = Theme{
+	Foreground: "#e0e0e0",
+	Background: "#1c1b22",
+	Link:       "#8ab4f8",
+	FontFamily: "Georgia, \"Times New Roman\", serif",
+	FontSize:   "20px",
+	Measure:    "38em",
+	LineHeight: "1.6",
+}
+
+// ThemeSepia is a reader theme with brown text over a warm background.
+var ThemeSepia = Theme{
+	Foreground: "#5b4636",
+	Background: "#f4ecd8",
+	Link:       "#8b4513",
+	FontFamily: "Georgia, \"Times New Roman\", serif",
+	FontSize:   "20px",
+	Measure:    "38em",
+	LineHeight: "1.6",
+}
+
+// stylesheet returns the CSS rules for the theme.
+func (t Theme) stylesheet() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("body {")
+	writeDeclaration(&buffer, "color", t.Foreground)
+	writeDeclaration(&buffer, "background-color", t.Background)
+	writeDeclaration(&buffer, "font-family", t.FontFamily)
+	writeDeclaration(&buffer, "font-size", t.FontSize)
+	writeDeclaration(&buffer, "line-height", t.LineHeight)
+	buffer.WriteString(" margin: 0; padding: 2em 1em; }\n")
+
+	buffer.WriteString("article {")
+	writeDeclaration(&buffer, "max-width", t.Measure)
+	buffer.WriteString(" margin: 0 auto; }\n")
+
+	if t.Link != "" {
+		buffer.WriteString("a {")
+		writeDeclaration(&buffer, "color", t.Link)
+		buffer.WriteString(" }\n")
+	}
+
+	buffer.WriteString("img, video, figure { max-width: 100%; height: auto; }\n")
+	buffer.WriteString("pre { overflow-x: auto; }\n")
+	buffer.WriteString(".byline { font-style: italic; opacity: 0.8; }\n")
+
+	if t.CSS != "" {
+		buffer.WriteString(t.CSS)
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
+
+// writeDeclaration writes a CSS declaration if the value is not empty.
+func writeDeclaration(buffer *bytes.Buffer, property string, value string) {
+	if value == "" {
+		return
+	}
+
+	fmt.Fprintf(buffer, " %s: %s;", property, value)
+}
+
+// ExportHTML writes the article as a standalone HTML document, with a header
+// containing the title and byline followed by the content, styled with the
+// given theme. Combined with EmbedImages, the document can be read offline.
+func ExportHTML(w io.Writer, article Article, theme Theme) error {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("<!DOCTYPE html>\n<html")
+
+	if article.Dir != "" {
+		fmt.Fprintf(&buffer, " dir=\"%s\"", html.EscapeString(article.Dir))
+	}
+
+	buffer.WriteString(">\n<head>\n<meta charset=\"utf-8\">\n")
+	buffer.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(&buffer, "<title>%s</title>\n", html.EscapeString(article.Title))
+	fmt.Fprintf(&buffer, "<style>\n%s</style>\n", theme.stylesheet())
+	buffer.WriteString("</head>\n<body>\n<article>\n<header>\n")
+	fmt.Fprintf(&buffer, "<h1>%s</h1>\n", html.EscapeString(article.Title))
+
+	if article.Byline != "" {
+		fmt.Fprintf(&buffer, "<p class=\"byline\">%s</p>\n", html.EscapeString(article.Byline))
+	}
+
+	buffer.WriteString("</header>\n")
+
+	if err := article.WriteContent(&buffer); err != nil {
+		return fmt.Errorf("failed to write content: %v", err)
+	}
+
+	buffer.WriteString("\n</article>\n</body>\n</html>\n")
+
+	if _, err := buffer.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write html: %v", err)
+	}
+
+	return nil
+}
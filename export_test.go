@@ -0,0 +1,40 @@
+package readability
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportHTML(t *testing.T) {
+	article := Article{
+		Title:   "Fish & Chips",
+		Byline:  "John Doe",
+		Content: "<div><p>lorem ipsum</p></div>",
+	}
+
+	theme := ThemeSepia
+	theme.Measure = "30em"
+	theme.CSS = "h1 { font-variant: small-caps; }"
+
+	var buffer bytes.Buffer
+
+	if err := ExportHTML(&buffer, article, theme); err != nil {
+		t.Fatalf("export failure: %s", err)
+	}
+
+	output := buffer.String()
+
+	for _, expected := range []string{
+		"<title>Fish &amp; Chips</title>",
+		"background-color: #f4ecd8;",
+		"max-width: 30em;",
+		"h1 { font-variant: small-caps; }",
+		"<p class=\"byline\">John Doe</p>",
+		"<div><p>lorem ipsum</p></div>",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("missing %q in the export:\n%s", expected, output)
+		}
+	}
+}
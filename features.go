@@ -0,0 +1,190 @@
+package readability
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cixtor/readability/heuristics"
+	"golang.org/x/net/html"
+)
+
+// featureAttr identifies the nodes of the document while the features are
+// collected, because the content grabber works on copies of the nodes.
+const featureAttr = "data-readability-node"
+
+// NodeFeatures is the feature vector of an element of the document, together
+// with the label assigned by the heuristics, meant to train other extractors.
+type NodeFeatures struct {
+	// Tag is the tag name of the element.
+	Tag string `json:"tag"`
+
+	// Depth is the number of ancestors of the element.
+	Depth int `json:"depth"`
+
+	// TextLength is the length of the text inside the element.
+	TextLength int `json:"text_length"`
+
+	// LinkDensity is the fraction of the text inside links.
+	LinkDensity float64 `json:"link_density"`
+
+	// Commas is the number of commas in the text.
+	Commas int `json:"commas"`
+
+	// ClassTokens are the words of the class name and ID of the element.
+	ClassTokens []string `json:"class_tokens"`
+
+	// ClassWeight is the weight given to the class name and ID.
+	ClassWeight int `json:"class_weight"`
+
+	// ChildCount is the number of child elements.
+	ChildCount int `json:"child_count"`
+
+	// SiblingIndex is the position of the element among its siblings.
+	SiblingIndex int `json:"sibling_index"`
+
+	// SiblingCount is the number of sibling elements, including itself.
+	SiblingCount int `json:"sibling_count"`
+
+	// Score is the content score of the element in the selected attempt, or
+	// zero if the element was not scored or is not part of the content.
+	Score float64 `json:"score"`
+
+	// Content is true if the element is part of the extracted content.
+	Content bool `json:"content"`
+}
+
+// markFeatureNodes collects the features of the elements with text inside the
+// body of the document and marks them, so they can be found in the content.
+func (r *Readability) markFeatureNodes(doc *html.Node) []NodeFeatures {
+	var features []NodeFeatures
+
+	bodies := getElementsByTagName(doc, "body")
+
+	if len(bodies) == 0 {
+		return nil
+	}
+
+	for _, node := range getElementsByTagName(bodies[0], "*") {
+		text := r.getInnerText(node, true)
+
+		if text == "" {
+			continue
+		}
+
+		depth := 0
+
+		for parent := node.Parent; parent != nil && parent.Type == html.ElementNode; parent = parent.Parent {
+			depth++
+		}
+
+		siblings := children(node.Parent)
+		matchString := r.matchString(node)
+
+		setAttribute(node, featureAttr, strconv.Itoa(len(features)))
+
+		features = append(features, NodeFeatures{
+			Tag:          tagName(node),
+			Depth:        depth,
+			TextLength:   len(text),
+			LinkDensity:  r.getLinkDensity(node),
+			Commas:       r.countCommas(text),
+			ClassTokens:  strings.Fields(matchString),
+			ClassWeight:  heuristics.ClassWeight(node, r.classWeightOptions()),
+			ChildCount:   len(children(node)),
+			SiblingIndex: indexOfNode(siblings, node),
+			SiblingCount: len(siblings),
+		})
+	}
+
+	return features
+}
+
+// indexOfNode returns the position of node in the list, or -1.
+func indexOfNode(list []*html.Node, node *html.Node) int {
+	for i, item := range list {
+		if item == node {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// labelFeatureNodes labels the features of the elements found in the article
+// content, and removes the marks set by markFeatureNodes from the content.
+func (r *Readability) labelFeatureNodes(articleContent *html.Node, features []NodeFeatures) {
+	if articleContent == nil {
+		return
+	}
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		index, err := strconv.Atoi(getAttribute(node, featureAttr))
+
+		if err != nil || index < 0 || index >= len(features) {
+			continue
+		}
+
+		removeAttribute(node, featureAttr)
+		features[index].Content = true
+
+		if r.hasContentScore(node) {
+			features[index].Score = r.getContentScore(node)
+		}
+	}
+}
+
+// WriteFeaturesJSON writes the features collected with ExportFeatures as a JSON
+// array with one object per element.
+func (res *Result) WriteFeaturesJSON(w io.Writer) error {
+	features := res.Features
+
+	if features == nil {
+		features = []NodeFeatures{}
+	}
+
+	if err := json.NewEncoder(w).Encode(features); err != nil {
+		return fmt.Errorf("failed to write features: %v", err)
+	}
+
+	return nil
+}
+
+// WriteFeaturesCSV writes the features collected with ExportFeatures as CSV,
+// with a header row and one row per element. The class tokens are separated
+// by spaces.
+func (res *Result) WriteFeaturesCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{{
+		"tag", "depth", "text_length", "link_density", "commas",
+		"class_tokens", "class_weight", "child_count", "sibling_index",
+		"sibling_count", "score", "content",
+	}}
+
+	for _, f := range res.Features {
+		records = append(records, []string{
+			f.Tag,
+			strconv.Itoa(f.Depth),
+			strconv.Itoa(f.TextLength),
+			strconv.FormatFloat(f.LinkDensity, 'f', 4, 64),
+			strconv.Itoa(f.Commas),
+			strings.Join(f.ClassTokens, "\x20"),
+			strconv.Itoa(f.ClassWeight),
+			strconv.Itoa(f.ChildCount),
+			strconv.Itoa(f.SiblingIndex),
+			strconv.Itoa(f.SiblingCount),
+			strconv.FormatFloat(f.Score, 'f', 4, 64),
+			strconv.FormatBool(f.Content),
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write features: %v", err)
+	}
+
+	return nil
+}
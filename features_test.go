@@ -0,0 +1,65 @@
+package readability
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportFeatures(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<nav class="menu"><a href="/">Home</a></nav>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+			</article>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.ExportFeatures = true
+	res, err := parser.Analyze(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	labels := map[string]bool{}
+
+	for _, f := range res.Features {
+		labels[f.Tag] = f.Content
+	}
+
+	if len(res.Features) != 5 || labels["nav"] || !labels["p"] {
+		t.Fatalf("unexpected features: %#v", res.Features)
+	}
+
+	if strings.Contains(res.HTML(), featureAttr) {
+		t.Fatalf("feature marks were not removed: %s", res.HTML())
+	}
+
+	var buffer bytes.Buffer
+	var decoded []NodeFeatures
+
+	if err := res.WriteFeaturesJSON(&buffer); err != nil {
+		t.Fatalf("json failure: %s", err)
+	}
+
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil || len(decoded) != 5 {
+		t.Fatalf("invalid json: %s", buffer.String())
+	}
+
+	buffer.Reset()
+
+	if err := res.WriteFeaturesCSV(&buffer); err != nil {
+		t.Fatalf("csv failure: %s", err)
+	}
+
+	if lines := strings.Split(strings.TrimSpace(buffer.String()), "\n"); len(lines) != 6 || !strings.HasPrefix(lines[2], "nav,") {
+		t.Fatalf("unexpected csv:\n%s", buffer.String())
+	}
+}
@@ -0,0 +1,173 @@
+package readability
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Fetcher sends HTTP requests on behalf of the parser. The interface matches
+// the Do method of *http.Client, so a client can be used directly. Wrap the
+// client to add caching, rate limiting or custom headers.
+type Fetcher interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// fetcher returns the configured Fetcher or the default HTTP client.
+func (r *Readability) fetcher() Fetcher {
+	if r.Fetcher != nil {
+		return r.Fetcher
+	}
+
+	return http.DefaultClient
+}
+
+// maxMetaRefreshes is the maximum number of meta refresh redirects followed by
+// ParseURL when FollowMetaRefresh is enabled.
+const maxMetaRefreshes = 5
+
+// ParseURL downloads the web page using the configured Fetcher and finds the
+// main readable content. The response body is decoded according to its
+// Content-Encoding header, including brotli which net/http does not support.
+//
+// If the page redirects to another page with a meta refresh, a RedirectError
+// is returned, unless FollowMetaRefresh is enabled, in which case the target
+// page is downloaded and parsed instead.
+func (r *Readability) ParseURL(pageURL string) (Article, error) {
+	requestedURL := pageURL
+
+	for redirects := 0; ; redirects++ {
+		result, err := r.fetchAndAnalyze(pageURL)
+
+		var redirect *RedirectError
+
+		if errors.As(err, &redirect) && r.FollowMetaRefresh && redirects < maxMetaRefreshes {
+			pageURL = redirect.URL
+			continue
+		}
+
+		if err != nil {
+			return Article{}, err
+		}
+
+		result.RequestedURL = requestedURL
+
+		return result.article(), nil
+	}
+}
+
+// fetchAndAnalyze downloads the web page and finds the main readable content.
+func (r *Readability) fetchAndAnalyze(pageURL string) (*Result, error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	// Setting the header explicitly disables the transparent decompression in
+	// net/http, which means every encoding is handled by decodeContent.
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+
+	res, err := r.fetcher().Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %v", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	body, err := decodeContent(res.Body, res.Header.Get("Content-Encoding"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The relative URLs of the page are resolved against the address of the
+	// page that was returned, after the redirects were followed.
+	finalURL := pageURL
+
+	if res.Request != nil && res.Request.URL != nil {
+		finalURL = res.Request.URL.String()
+	}
+
+	var robots robotsDirectives
+
+	for _, value := range res.Header.Values("X-Robots-Tag") {
+		robots.parse(value)
+	}
+
+	if r.RespectNoarchive && robots.noArchive {
+		return nil, ErrNoArchive
+	}
+
+	result, err := r.analyze(body, finalURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.NoIndex = result.NoIndex || robots.noIndex
+	result.NoArchive = result.NoArchive || robots.noArchive
+
+	return result, nil
+}
+
+// decodeContent wraps input with the decoders for the given content encoding.
+// The encoding uses the format of the Content-Encoding HTTP header, a comma
+// separated list of encodings in the order they were applied.
+func decodeContent(input io.Reader, contentEncoding string) (io.Reader, error) {
+	var err error
+
+	encodings := strings.Split(contentEncoding, ",")
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		switch encoding := strings.ToLower(strings.TrimSpace(encodings[i])); encoding {
+		case "", "identity":
+			continue
+		case "gzip", "x-gzip":
+			if input, err = gzip.NewReader(input); err != nil {
+				return nil, fmt.Errorf("failed to decode gzip content: %v", err)
+			}
+		case "deflate":
+			if input, err = newDeflateReader(input); err != nil {
+				return nil, fmt.Errorf("failed to decode deflate content: %v", err)
+			}
+		case "br":
+			input = brotli.NewReader(input)
+		default:
+			return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+		}
+	}
+
+	return input, nil
+}
+
+// newDeflateReader returns a reader for "deflate" content. The HTTP spec says
+// the content is wrapped in the zlib format, but some servers send raw deflate
+// data instead, so the zlib header is checked before choosing the decoder.
+func newDeflateReader(input io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(input)
+	header, err := buffered.Peek(2)
+
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
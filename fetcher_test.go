@@ -0,0 +1,130 @@
+package readability
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+const fetcherTestPage = `<html>
+	<head>
+		<title>hello world</title>
+	</head>
+	<body>
+		<p>lorem ipsum</p>
+	</body>
+	</html>`
+
+func TestParseURLContentEncoding(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(fetcherTestPage))
+	gz.Close()
+
+	var brotlied bytes.Buffer
+	br := brotli.NewWriter(&brotlied)
+	br.Write([]byte(fetcherTestPage))
+	br.Close()
+
+	bodies := map[string][]byte{
+		"gzip": gzipped.Bytes(),
+		"br":   brotlied.Bytes(),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		encoding := req.URL.Query().Get("encoding")
+		w.Header().Set("Content-Encoding", encoding)
+		w.Write(bodies[encoding])
+	}))
+	defer server.Close()
+
+	for encoding := range bodies {
+		a, err := New().ParseURL(server.URL + "/?encoding=" + encoding)
+
+		if err != nil {
+			t.Fatalf("%s: parser failure: %s", encoding, err)
+		}
+
+		if a.TextContent != "lorem ipsum" {
+			t.Fatalf("%s: content was not decoded: %q", encoding, a.TextContent)
+		}
+	}
+}
+
+func TestParseContentEncoding(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(fetcherTestPage))
+	gz.Close()
+
+	parser := New()
+	parser.ContentEncoding = "gzip"
+	a, err := parser.Parse(&gzipped, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.TextContent != "lorem ipsum" {
+		t.Fatalf("content was not decoded: %q", a.TextContent)
+	}
+}
+
+func TestParseReaderAtContentEncoding(t *testing.T) {
+	paragraph := "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua, ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>"
+	page := "<html><head><title>hello world</title></head><body><article>" + strings.Repeat(paragraph, 4) +
+		"<p>Duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p></article></body></html>"
+
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	gz.Write([]byte(page))
+	gz.Close()
+
+	parser := New()
+	parser.ContentEncoding = "gzip"
+	parser.ReadablePrefixSize = int64(len(page) - 100)
+	input := bytes.NewReader(gzipped.Bytes())
+	a, err := parser.ParseReaderAt(input, input.Size(), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.TextContent, "Duis aute irure") {
+		t.Fatalf("content was not decoded: %q", a.TextContent)
+	}
+}
+
+func TestParseURLRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, "/blog/post", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/blog/post", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`<html><head><title>hello world</title></head><body>
+			<p>Lorem ipsum dolor sit amet, <a href="next">consectetur</a> adipiscing elit.</p>
+			</body></html>`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	a, err := New().ParseURL(server.URL + "/old")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.URL != server.URL+"/blog/post" || a.RequestedURL != server.URL+"/old" {
+		t.Fatalf("unexpected URLs: %q, %q", a.URL, a.RequestedURL)
+	}
+
+	if !strings.Contains(a.Content, `href="`+server.URL+`/blog/next"`) {
+		t.Fatalf("the links were not resolved against the final URL: %s", a.Content)
+	}
+}
@@ -0,0 +1,146 @@
+package readability
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// simHashShingleSize is the number of consecutive words hashed together as a
+// feature of the SimHash. Single words make texts about the same topic look
+// alike, while longer shingles make small edits change too many features.
+const simHashShingleSize = 3
+
+// SimHashDistance returns the number of bits that differ between two SimHash
+// fingerprints. Articles with a distance of 3 or less out of 64 bits are very
+// likely near-duplicates, for example the same story with a different footer.
+func SimHashDistance(a uint64, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// fingerprintWriter receives the text of the article in chunks, counts its
+// bytes, and computes the fingerprints of its normalized form, which is the
+// text in lower case with every run of white space replaced by one space.
+type fingerprintWriter struct {
+	length  int
+	sha     hash.Hash
+	words   int
+	weights [64]int
+
+	// partial holds the bytes of a rune split between two chunks.
+	partial []byte
+
+	// word is the word being read, and shingle the last words read.
+	word    strings.Builder
+	shingle []string
+}
+
+func newFingerprintWriter() *fingerprintWriter {
+	return &fingerprintWriter{sha: sha256.New()}
+}
+
+// Write implements the io.Writer interface.
+func (fw *fingerprintWriter) Write(p []byte) (int, error) {
+	fw.length += len(p)
+
+	data := p
+
+	if len(fw.partial) > 0 {
+		data = append(fw.partial, p...)
+		fw.partial = nil
+	}
+
+	for len(data) > 0 {
+		if !utf8.FullRune(data) {
+			fw.partial = append([]byte(nil), data...)
+			break
+		}
+
+		char, size := utf8.DecodeRune(data)
+		data = data[size:]
+
+		if unicode.IsSpace(char) {
+			fw.endWord()
+			continue
+		}
+
+		fw.word.WriteRune(unicode.ToLower(char))
+	}
+
+	return len(p), nil
+}
+
+// endWord adds the word being read to the normalized text and to the shingle
+// of words used by the SimHash.
+func (fw *fingerprintWriter) endWord() {
+	if fw.word.Len() == 0 {
+		return
+	}
+
+	word := fw.word.String()
+	fw.word.Reset()
+
+	if fw.words > 0 {
+		fw.sha.Write([]byte{' '})
+	}
+
+	fw.sha.Write([]byte(word))
+	fw.words++
+
+	fw.shingle = append(fw.shingle, word)
+
+	if len(fw.shingle) > simHashShingleSize {
+		fw.shingle = fw.shingle[1:]
+	}
+
+	if len(fw.shingle) == simHashShingleSize {
+		fw.addFeature(strings.Join(fw.shingle, " "))
+	}
+}
+
+// addFeature adds the hash of the feature to the weights of the SimHash.
+func (fw *fingerprintWriter) addFeature(feature string) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	for i := range fw.weights {
+		if sum&(1<<uint(i)) != 0 {
+			fw.weights[i]++
+		} else {
+			fw.weights[i]--
+		}
+	}
+}
+
+// finish flushes the last word and returns the SHA-256 of the normalized text
+// in hexadecimal and its SimHash. Texts shorter than a shingle are hashed as a
+// single feature, and an empty text has an empty SimHash.
+func (fw *fingerprintWriter) finish() (string, uint64) {
+	if len(fw.partial) > 0 {
+		// A truncated rune at the end of the text is decoded as U+FFFD.
+		fw.word.WriteRune(utf8.RuneError)
+		fw.partial = nil
+	}
+
+	fw.endWord()
+
+	if fw.words > 0 && fw.words < simHashShingleSize {
+		fw.addFeature(strings.Join(fw.shingle, " "))
+	}
+
+	var simhash uint64
+
+	for i, weight := range fw.weights {
+		if weight > 0 {
+			simhash |= 1 << uint(i)
+		}
+	}
+
+	return hex.EncodeToString(fw.sha.Sum(nil)), simhash
+}
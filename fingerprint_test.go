@@ -0,0 +1,90 @@
+package readability
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+)
+
+func fingerprintText(chunks ...string) (int, string, uint64) {
+	fw := newFingerprintWriter()
+
+	for _, chunk := range chunks {
+		io.WriteString(fw, chunk)
+	}
+
+	hash, simhash := fw.finish()
+
+	return fw.length, hash, simhash
+}
+
+func TestFingerprintNormalization(t *testing.T) {
+	sum := sha256.Sum256([]byte("café au lait"))
+	expected := hex.EncodeToString(sum[:])
+
+	length, hash, _ := fingerprintText("  Café\n\tau   LAIT ")
+
+	if hash != expected {
+		t.Fatalf("unexpected hash: %s, expecting %s", hash, expected)
+	}
+
+	if length != len("  Café\n\tau   LAIT ") {
+		t.Fatalf("unexpected length: %d", length)
+	}
+
+	// A rune split between two chunks is decoded as a whole.
+	if _, split, _ := fingerprintText("  Caf\xc3", "\xa9\n\tau   LAIT "); split != expected {
+		t.Fatalf("unexpected hash of split text: %s, expecting %s", split, expected)
+	}
+
+	if _, _, simhash := fingerprintText(" \n "); simhash != 0 {
+		t.Fatalf("unexpected simhash of empty text: %x", simhash)
+	}
+}
+
+func TestSimHash(t *testing.T) {
+	base := strings.Repeat("The quick brown fox jumps over the lazy dog near the river bank. ", 3) +
+		"Scientists found that foxes in the region have adapted to living close to people, " +
+		"and they now hunt in parks and gardens at night while the city sleeps."
+
+	_, _, a := fingerprintText(base)
+	_, _, b := fingerprintText(base + " Read more stories.")
+	_, _, c := fingerprintText("Markets closed higher on Friday after the central bank kept interest " +
+		"rates unchanged, and investors bought shares of technology companies again.")
+
+	if near := SimHashDistance(a, b); near > 6 {
+		t.Fatalf("near-duplicate texts are %d bits apart", near)
+	}
+
+	if far := SimHashDistance(a, c); far < 16 {
+		t.Fatalf("different texts are only %d bits apart", far)
+	}
+}
+
+func TestArticleFingerprint(t *testing.T) {
+	a, err := New().Parse(strings.NewReader(contentTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	length, hash, simhash := fingerprintText(a.TextContent)
+
+	if a.Length != length || a.Hash != hash || a.SimHash != simhash {
+		t.Fatalf("unexpected fingerprint: %d %s %x", a.Length, a.Hash, a.SimHash)
+	}
+
+	parser := New()
+	parser.OmitContentStrings = true
+	b, err := parser.Parse(strings.NewReader(contentTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if b.Hash != a.Hash || b.SimHash != a.SimHash || b.Length != a.Length {
+		t.Fatalf("the streamed text has a different fingerprint: %s %x", b.Hash, b.SimHash)
+	}
+}
@@ -0,0 +1,19 @@
+package readability
+
+// FormPolicy is how the parser treats the <form> elements of the document.
+type FormPolicy int
+
+const (
+	// FormsClean removes the forms that look like boilerplate, like search
+	// boxes and comment forms, with the conditional cleaning.
+	FormsClean FormPolicy = iota
+
+	// FormsKeep skips the conditional cleaning of the forms. Their fields
+	// are still removed from the content.
+	FormsKeep
+
+	// FormsUnwrap replaces the forms with their children before the content
+	// is extracted, so the content inside them is scored like the rest of
+	// the document.
+	FormsUnwrap
+)
@@ -0,0 +1,51 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestForms(t *testing.T) {
+	input := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<div id="main">
+				<h1>Reference</h1>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua, ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi.</p>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua, ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi.</p>
+				<form method="post" action="/docs.aspx">
+					<input type="hidden" name="__VIEWSTATE" value="dDwtNTI0ODU5MDE1Ozs+">
+					<input type="hidden" name="__EVENTVALIDATION" value="dDwtMTA4MTQzO">
+					<input type="hidden" name="__EVENTTARGET" value="">
+					<table><tr><td>Parameter</td><td>The name of the parameter, and a longer description of it.</td></tr></table>
+					<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur, excepteur sint occaecat.</p>
+				</form>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua, ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi.</p>
+			</div>
+		</body>
+		</html>`
+
+	for _, policy := range []FormPolicy{FormsClean, FormsKeep, FormsUnwrap} {
+		parser := New()
+		parser.Forms = policy
+		a, err := parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+		if err != nil {
+			t.Fatalf("parser failure: %s", err)
+		}
+
+		if strings.Contains(a.Content, "Duis aute irure") != (policy != FormsClean) {
+			t.Fatalf("unexpected content with policy %d:\n%s", policy, a.Content)
+		}
+
+		if strings.Contains(a.Content, "<input") {
+			t.Fatalf("the form fields were not removed with policy %d:\n%s", policy, a.Content)
+		}
+
+		if strings.Contains(a.Content, "<form") != (policy == FormsKeep) {
+			t.Fatalf("unexpected form element with policy %d:\n%s", policy, a.Content)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxCaption matches the class names of the captions of the images that are
+// not written with a <figcaption> element, like "wp-caption-text".
+var rxCaption = regexp.MustCompile(`(?i)caption`)
+
+// galleryAttr marks the captioned images of a gallery while they are
+// processed. Its value is the caption, which is found before the class names
+// that identify it are removed.
+const galleryAttr = "data-readability-gallery"
+
+// galleryMinImages is the minimum number of captioned images of a gallery.
+const galleryMinImages = 4
+
+// galleryMaxDepth is the maximum number of ancestors of an image searched for
+// its caption.
+const galleryMaxDepth = 3
+
+// markGallery finds the images with a caption and, if there are enough of
+// them to consider the document a gallery or a photo essay, marks them to
+// protect them from conditional cleaning, which usually removes them because
+// they have little text. See DetectGalleries.
+func (r *Readability) markGallery(doc *html.Node) {
+	var items []*html.Node
+	var captions []string
+
+	for _, img := range getElementsByTagName(doc, "img") {
+		item, caption := galleryItem(img)
+
+		if item == nil || indexOfNode(items, item) != -1 {
+			continue
+		}
+
+		items = append(items, item)
+		captions = append(captions, caption)
+	}
+
+	if len(items) < galleryMinImages {
+		return
+	}
+
+	for i, item := range items {
+		setAttribute(item, galleryAttr, captions[i])
+	}
+}
+
+// galleryItem returns the closest ancestor of the image that contains its
+// caption, and the text of the caption, or nil if the image has no caption.
+// The search stops at the first ancestor with other images.
+func galleryItem(img *html.Node) (*html.Node, string) {
+	parent := img.Parent
+
+	for depth := 0; parent != nil && depth < galleryMaxDepth; depth++ {
+		if tagName(parent) == "body" || len(getElementsByTagName(parent, "img")) > 1 {
+			return nil, ""
+		}
+
+		for _, node := range getElementsByTagName(parent, "*") {
+			if tagName(node) != "figcaption" && !rxCaption.MatchString(className(node)) {
+				continue
+			}
+
+			if isDescendant(node, img) {
+				continue
+			}
+
+			if caption := strings.Join(strings.Fields(textContent(node)), "\x20"); caption != "" {
+				return parent, caption
+			}
+		}
+
+		parent = parent.Parent
+	}
+
+	return nil, ""
+}
+
+// isProtectedGalleryItem returns true if node is, contains or is inside one of
+// the captioned images marked by markGallery.
+func (r *Readability) isProtectedGalleryItem(node *html.Node) bool {
+	for parent := node; parent != nil; parent = parent.Parent {
+		if hasAttribute(parent, galleryAttr) {
+			return true
+		}
+	}
+
+	for _, elem := range getElementsByTagName(node, "*") {
+		if hasAttribute(elem, galleryAttr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isGallery returns true if the content has enough captioned images to be
+// accepted as a gallery, regardless of the length of its text.
+func isGallery(articleContent *html.Node) bool {
+	items := 0
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		if hasAttribute(node, galleryAttr) && len(getElementsByTagName(node, "img")) > 0 {
+			items++
+		}
+	}
+
+	return items >= galleryMinImages
+}
+
+// imageCaption returns the caption of the image, found by markGallery or in
+// the <figcaption> of the figure that contains the image.
+func imageCaption(img *html.Node) string {
+	for parent, depth := img.Parent, 0; parent != nil && depth < galleryMaxDepth; parent, depth = parent.Parent, depth+1 {
+		if hasAttribute(parent, galleryAttr) {
+			return getAttribute(parent, galleryAttr)
+		}
+
+		if tagName(parent) != "figure" {
+			continue
+		}
+
+		for _, caption := range getElementsByTagName(parent, "figcaption") {
+			if text := strings.Join(strings.Fields(textContent(caption)), "\x20"); text != "" {
+				return text
+			}
+		}
+
+		return ""
+	}
+
+	return ""
+}
+
+// extractGallery returns true if the content is a gallery, and removes the
+// marks set by markGallery.
+func extractGallery(articleContent *html.Node) bool {
+	gallery := isGallery(articleContent)
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		removeAttribute(node, galleryAttr)
+	}
+
+	return gallery
+}
@@ -0,0 +1,89 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const galleryTestPage = `<html>
+	<head><title>hello world</title></head>
+	<body>
+		<article>
+			<h1>Winter in the mountains</h1>
+			<figure><img src="/one.jpg" alt="Peaks"><figcaption>Snow on the peaks at dawn.</figcaption></figure>
+			<figure><img src="/two.jpg" alt="Lake"><figcaption>The frozen lake below the village.</figcaption></figure>
+			<div class="wp-caption"><img src="/three.jpg" alt="Cabin"><p class="wp-caption-text">A cabin at the end of the trail.</p></div>
+			<figure><img src="/four.jpg" alt="Bridge"><figcaption>The old bridge after the storm.</figcaption></figure>
+		</article>
+	</body>
+</html>`
+
+func TestGalleryItem(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<div id="a"><img id="x"><p class="caption">Hello <b>world</b></p></div>` +
+		`<div id="b"><img id="y"><img id="z"><figcaption>Both</figcaption></div>` +
+		`<figure id="c"><img id="w"></figure>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	tests := map[string]string{"x": "Hello world", "y": "", "z": "", "w": ""}
+
+	for _, img := range getElementsByTagName(doc, "img") {
+		_, caption := galleryItem(img)
+
+		if expected := tests[getAttribute(img, "id")]; caption != expected {
+			t.Fatalf("galleryItem(%s) = %q, expecting %q", getAttribute(img, "id"), caption, expected)
+		}
+	}
+}
+
+func TestDetectGalleries(t *testing.T) {
+	parser := New()
+	parser.DetectGalleries = true
+
+	a, err := parser.Parse(strings.NewReader(galleryTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !a.Gallery {
+		t.Fatalf("expecting the content to be a gallery")
+	}
+
+	expected := []string{
+		"Snow on the peaks at dawn.",
+		"The frozen lake below the village.",
+		"A cabin at the end of the trail.",
+		"The old bridge after the storm.",
+	}
+
+	if len(a.Images) != len(expected) {
+		t.Fatalf("expecting %d images; got %#v", len(expected), a.Images)
+	}
+
+	for i, image := range a.Images {
+		if image.Caption != expected[i] {
+			t.Fatalf("unexpected caption of image %d: %q", i, image.Caption)
+		}
+	}
+
+	if strings.Contains(a.Content, galleryAttr) {
+		t.Fatalf("the marks of the gallery were not removed:\n%s", a.Content)
+	}
+}
+
+func TestDetectGalleriesDisabled(t *testing.T) {
+	a, err := New().Parse(strings.NewReader(galleryTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Gallery {
+		t.Fatalf("galleries should only be detected when enabled")
+	}
+}
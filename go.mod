@@ -2,4 +2,8 @@ module github.com/cixtor/readability
 
 go 1.14
 
-require golang.org/x/net v0.8.0
+require (
+	github.com/PuerkitoBio/goquery v1.8.1
+	github.com/andybalholm/brotli v1.0.5
+	golang.org/x/net v0.8.0
+)
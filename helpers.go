@@ -5,61 +5,35 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/cixtor/readability/internal/dom"
 	"golang.org/x/net/html"
 )
 
+// The DOM helpers below are thin wrappers around the internal dom package, so
+// the code of the parser keeps the names used by Readability.js.
+
 // firstElementChild returns the object's first child Element, or nil if there
 // are no child elements.
 func firstElementChild(node *html.Node) *html.Node {
-	for child := node.FirstChild; child != nil; child = child.NextSibling {
-		if child.Type == html.ElementNode {
-			return child
-		}
-	}
-
-	return nil
+	return dom.FirstElementChild(node)
 }
 
 // nextElementSibling returns the Element immediately following the specified
 // one in its parent's children list, or nil if the specified Element is the
 // last one in the list.
 func nextElementSibling(node *html.Node) *html.Node {
-	for sibling := node.NextSibling; sibling != nil; sibling = sibling.NextSibling {
-		if sibling.Type == html.ElementNode {
-			return sibling
-		}
-	}
-
-	return nil
+	return dom.NextElementSibling(node)
 }
 
-// appendChild adds a node to the end of the list of children of a specified
-// parent node. If the given child is a reference to an existing node in the
-// document, appendChild moves it from its current position to the new position
-// (there is no requirement to remove the node from its parent node before
-// appending it to some other node).
-//
-// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/appendChild
+// appendChild moves child, or adds it if it is detached, to the end of the
+// list of children of node.
 func appendChild(node *html.Node, child *html.Node) {
-	if child.Parent != nil {
-		temp := cloneNode(child)
-		node.AppendChild(temp)
-		child.Parent.RemoveChild(child)
-		return
-	}
-
-	node.AppendChild(child)
+	dom.AppendChild(node, child)
 }
 
 // childNodes returns list of a node's direct children.
 func childNodes(node *html.Node) []*html.Node {
-	var list []*html.Node
-
-	for c := node.FirstChild; c != nil; c = c.NextSibling {
-		list = append(list, c)
-	}
-
-	return list
+	return dom.ChildNodes(node)
 }
 
 // includeNode determines if node is included inside nodeList.
@@ -74,149 +48,69 @@ func includeNode(nodeList []*html.Node, node *html.Node) bool {
 }
 
 // cloneNode returns a duplicate of the node on which this method was called.
-//
-// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/cloneNode
 func cloneNode(node *html.Node) *html.Node {
-	clone := &html.Node{
-		Type:     node.Type,
-		DataAtom: node.DataAtom,
-		Data:     node.Data,
-		Attr:     make([]html.Attribute, len(node.Attr)),
-	}
-
-	copy(clone.Attr, node.Attr)
+	return dom.CloneNode(node)
+}
 
-	for c := node.FirstChild; c != nil; c = c.NextSibling {
-		clone.AppendChild(cloneNode(c))
+// copyMarks copies the internal marks of the parser, the attributes starting
+// with "data-readability-", from an element to the one replacing it, unless
+// the replacement has its own.
+func copyMarks(from *html.Node, to *html.Node) {
+	for _, attr := range from.Attr {
+		if strings.HasPrefix(attr.Key, "data-readability-") && !hasAttribute(to, attr.Key) {
+			setAttribute(to, attr.Key, attr.Val)
+		}
 	}
-
-	return clone
 }
 
 // createElement creates the HTML element specified by tagName.
-//
-// See: https://developer.mozilla.org/en-US/docs/Web/API/Document/createElement
 func createElement(tagName string) *html.Node {
-	return &html.Node{Type: html.ElementNode, Data: tagName}
+	return dom.CreateElement(tagName)
 }
 
 // createTextNode creates a new Text node.
 func createTextNode(data string) *html.Node {
-	return &html.Node{Type: html.TextNode, Data: data}
+	return dom.CreateTextNode(data)
 }
 
 // getElementsByTagName returns a collection of HTML elements with the given
 // tag name. If tag name is an asterisk, a list of all the available HTML nodes
 // will be returned instead.
-//
-// See: https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementsByTagName
 func getElementsByTagName(node *html.Node, tag string) []*html.Node {
-	var lst []*html.Node
-	var fun func(*html.Node)
-
-	fun = func(n *html.Node) {
-		if n.Type == html.ElementNode && (tag == "*" || n.Data == tag) {
-			lst = append(lst, n)
-		}
-
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			fun(c)
-		}
-	}
-
-	fun(node)
-
-	return lst
+	return dom.GetElementsByTagName(node, tag)
 }
 
 // getAttribute returns the value of a specified attribute on the element. If
 // the given attribute does not exist, the function returns an empty string.
 func getAttribute(node *html.Node, attrName string) string {
-	for i := 0; i < len(node.Attr); i++ {
-		if node.Attr[i].Key == attrName {
-			return node.Attr[i].Val
-		}
-	}
-
-	return ""
+	return dom.GetAttribute(node, attrName)
 }
 
 // setAttribute sets attribute for node. If attribute already exists, it will
 // be replaced.
 func setAttribute(node *html.Node, attrName string, attrValue string) {
-	attrIdx := -1
-
-	for i := 0; i < len(node.Attr); i++ {
-		if node.Attr[i].Key == attrName {
-			attrIdx = i
-			break
-		}
-	}
-
-	if attrIdx >= 0 {
-		node.Attr[attrIdx].Val = attrValue
-		return
-	}
-
-	node.Attr = append(node.Attr, html.Attribute{
-		Key: attrName,
-		Val: attrValue,
-	})
+	dom.SetAttribute(node, attrName, attrValue)
 }
 
 // removeAttribute removes attribute with given name.
 func removeAttribute(node *html.Node, attrName string) {
-	attrIdx := -1
-
-	for i := 0; i < len(node.Attr); i++ {
-		if node.Attr[i].Key == attrName {
-			attrIdx = i
-			break
-		}
-	}
-
-	if attrIdx >= 0 {
-		a := node.Attr
-		a = append(a[:attrIdx], a[attrIdx+1:]...)
-		node.Attr = a
-	}
+	dom.RemoveAttribute(node, attrName)
 }
 
 // hasAttribute returns a Boolean value indicating whether the specified node
 // has the specified attribute or not.
 func hasAttribute(node *html.Node, attrName string) bool {
-	for i := 0; i < len(node.Attr); i++ {
-		if node.Attr[i].Key == attrName {
-			return true
-		}
-	}
-
-	return false
+	return dom.HasAttribute(node, attrName)
 }
 
 // outerHTML returns an HTML serialization of the element and its descendants.
 func outerHTML(node *html.Node) string {
-	var buffer bytes.Buffer
-
-	if err := html.Render(&buffer, node); err != nil {
-		return ""
-	}
-
-	return buffer.String()
+	return dom.OuterHTML(node)
 }
 
 // innerHTML returns the HTML content (inner HTML) of an element.
 func innerHTML(node *html.Node) string {
-	var err error
-	var buffer bytes.Buffer
-
-	for child := node.FirstChild; child != nil; child = child.NextSibling {
-		if err = html.Render(&buffer, child); err != nil {
-			return ""
-		}
-	}
-
-	return strings.TrimSpace(buffer.String())
+	return dom.InnerHTML(node)
 }
 
 // documentElement returns the root element of the document.
@@ -247,24 +141,7 @@ func id(node *html.Node) string {
 
 // children returns an HTMLCollection of the child elements of Node.
 func children(node *html.Node) []*html.Node {
-	var children []*html.Node
-
-	if node == nil {
-		return nil
-	}
-
-	for child := node.FirstChild; child != nil; child = child.NextSibling {
-		if child.Type == html.ElementNode {
-			children = append(children, child)
-		}
-	}
-
-	return children
-}
-
-// wordCount returns number of word in str.
-func wordCount(str string) int {
-	return len(strings.Fields(str))
+	return dom.Children(node)
 }
 
 // indexOf returns the first index at which a given element can be found in the
@@ -280,79 +157,124 @@ func indexOf(array []string, key string) int {
 }
 
 // replaceNode replaces a child node within the given (parent) node.
-//
-// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/replaceChild
 func replaceNode(oldNode *html.Node, newNode *html.Node) {
-	if oldNode.Parent == nil {
-		return
-	}
+	dom.ReplaceNode(oldNode, newNode)
+}
 
-	newNode.Parent = nil
-	newNode.PrevSibling = nil
-	newNode.NextSibling = nil
-	oldNode.Parent.InsertBefore(newNode, oldNode)
-	oldNode.Parent.RemoveChild(oldNode)
+// unwrapNode replaces node with its own children.
+func unwrapNode(node *html.Node) {
+	dom.UnwrapNode(node)
 }
 
 // tagName returns the tag name of the element on which it’s called.
-//
-// For example, if the element is an <img>, its tagName property is “IMG” (for
-// HTML documents; it may be cased differently for XML/XHTML documents).
-//
-// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/tagName
 func tagName(node *html.Node) string {
-	if node.Type != html.ElementNode {
-		return ""
-	}
-
-	return node.Data
+	return dom.TagName(node)
 }
 
 // textContent returns text content of a Node and its descendants.
-//
-// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/textContent
 func textContent(node *html.Node) string {
-	var buffer bytes.Buffer
-	var finder func(*html.Node)
+	return dom.TextContent(node)
+}
+
+// normalizedText returns the text content of the node without the leading
+// and trailing white space, and with every sequence of two or more white space
+// characters replaced by a single space. It is equivalent to trimming the text
+// and replacing rxNormalize, but it walks the tree and the text only once.
+func normalizedText(node *html.Node) string {
+	buffer := dom.AcquireBuffer()
+	defer dom.ReleaseBuffer(buffer)
 
-	finder = func(n *html.Node) {
-		if n.Type == html.TextNode {
-			buffer.WriteString(n.Data)
+	dom.WriteTextContent(buffer, node)
+
+	return collapseWhitespace(bytes.TrimSpace(buffer.Bytes()), 2)
+}
+
+// isCollapsibleSpace reports whether c is one of the characters matched by \s
+// in the regular expressions of the package.
+func isCollapsibleSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// collapseWhitespace replaces every sequence of at least minRun white space
+// characters in text with a single space.
+func collapseWhitespace(text []byte, minRun int) string {
+	var builder strings.Builder
+
+	start := 0
+
+	for i := 0; i < len(text); {
+		if !isCollapsibleSpace(text[i]) {
+			i++
+			continue
 		}
 
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			finder(c)
+		j := i + 1
+
+		for j < len(text) && isCollapsibleSpace(text[j]) {
+			j++
+		}
+
+		if j-i >= minRun && (j-i > 1 || text[i] != ' ') {
+			if builder.Len() == 0 {
+				builder.Grow(len(text))
+			}
+
+			builder.Write(text[start:i])
+			builder.WriteByte(' ')
+			start = j
 		}
+
+		i = j
+	}
+
+	if start == 0 {
+		return string(text)
 	}
 
-	finder(node)
+	builder.Write(text[start:])
 
-	return buffer.String()
+	return builder.String()
 }
 
-// toAbsoluteURI convert uri to absolute path based on base.
-// However, if uri is prefixed with hash (#), the uri won't be changed.
+// toAbsoluteURI converts uri to an absolute URL resolved against base. It
+// delegates to dom.ToAbsoluteURI, which keeps the fragments and the URLs with
+// a scheme, like "mailto:" or "data:", as they are.
 func toAbsoluteURI(uri string, base *url.URL) string {
-	if uri == "" || base == nil {
-		return ""
-	}
+	return dom.ToAbsoluteURI(uri, base)
+}
 
-	// If it is hash tag, return as it is
-	if uri[:1] == "#" {
-		return uri
+// closestAncestor returns the nearest ancestor of the node with the given tag
+// name, or nil if there is none.
+func closestAncestor(node *html.Node, tag string) *html.Node {
+	return dom.ClosestAncestor(node, tag)
+}
+
+// documentFromNode returns a copy of node wrapped in a document with the usual
+// <html>, <head> and <body> structure, so it can be parsed like a full page.
+func documentFromNode(node *html.Node) *html.Node {
+	if node.Type == html.DocumentNode {
+		return cloneNode(node)
 	}
 
-	// If it is already an absolute URL, return as it is
-	tmp, err := url.ParseRequestURI(uri)
-	if err == nil && tmp.Scheme != "" && tmp.Hostname() != "" {
-		return uri
+	doc := &html.Node{Type: html.DocumentNode}
+
+	if tagName(node) == "html" {
+		doc.AppendChild(cloneNode(node))
+		return doc
 	}
 
-	// Otherwise, resolve against base URI.
-	tmp, err = url.Parse(uri)
-	if err != nil {
-		return uri
+	root := createElement("html")
+	doc.AppendChild(root)
+	root.AppendChild(createElement("head"))
+
+	if tagName(node) == "body" {
+		root.AppendChild(cloneNode(node))
+		return doc
 	}
 
-	return base.ResolveReference(tmp).String()
+	body := createElement("body")
+	root.AppendChild(body)
+	body.AppendChild(cloneNode(node))
+
+	return doc
 }
@@ -0,0 +1,107 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestAppendChild(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<div id="source"><p>lorem</p><p>ipsum</p></div><div id="target"></div>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	divs := getElementsByTagName(doc, "div")
+	source, target := divs[0], divs[1]
+	paragraph := firstElementChild(source)
+
+	appendChild(target, paragraph)
+
+	if paragraph.Parent != target || target.FirstChild != paragraph {
+		t.Fatalf("the node was not moved: %s", outerHTML(target))
+	}
+
+	if len(children(source)) != 1 || textContent(source) != "ipsum" {
+		t.Fatalf("the node was not removed from its parent: %s", outerHTML(source))
+	}
+
+	detached := createElement("span")
+	appendChild(target, detached)
+
+	if detached.Parent != target || target.LastChild != detached {
+		t.Fatalf("the detached node was not appended: %s", outerHTML(target))
+	}
+}
+
+func TestGrabArticleKeepsNodes(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.
+		</body>
+		</html>`)
+
+	res, err := New().Analyze(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	// The body has no candidates, so its children are moved into a new node,
+	// which is still the same node once it is part of the content.
+	if res.Node == nil || res.Node.Parent != res.content {
+		t.Fatalf("the node is not part of the content: %#v", res.Node)
+	}
+
+	if getAttribute(res.Node, "id") != "readability-page-1" {
+		t.Fatalf("unexpected page node: %s", outerHTML(res.Node))
+	}
+}
+
+func TestNormalizedText(t *testing.T) {
+	tests := []string{
+		"",
+		"  lorem ipsum  ",
+		"lorem\nipsum",
+		"lorem \n\t ipsum   dolor",
+		" lorem    ipsum ",
+		"lorem  ipsum",
+	}
+
+	for _, text := range tests {
+		node := createElement("p")
+		node.AppendChild(createTextNode(text[:len(text)/2]))
+		node.AppendChild(createTextNode(text[len(text)/2:]))
+
+		expected := rxNormalize.ReplaceAllString(strings.TrimSpace(text), "\x20")
+
+		if normalized := normalizedText(node); normalized != expected {
+			t.Fatalf("%q: unexpected text: %q != %q", text, normalized, expected)
+		}
+	}
+}
+
+func BenchmarkGetInnerText(b *testing.B) {
+	doc, err := html.Parse(strings.NewReader(largePage(100)))
+
+	if err != nil {
+		b.Fatalf("cannot parse page: %s", err)
+	}
+
+	r := New()
+	nodes := getElementsByTagName(doc, "div")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, node := range nodes {
+			r.getInnerText(node, true)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxHeroCaption matches the class names of the captions and credits placed
+// next to hero images.
+var rxHeroCaption = regexp.MustCompile(`(?i)caption|credit`)
+
+// sameImageURL determines if both URLs point to the same image, ignoring the
+// query string and the fragment, which are often used to resize images.
+func sameImageURL(a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	trim := func(uri string) string {
+		if i := strings.IndexAny(uri, "?#"); i != -1 {
+			return uri[:i]
+		}
+
+		return uri
+	}
+
+	return trim(a) != "" && trim(a) == trim(b)
+}
+
+// isImageOf determines if the <img> element shows the image at imageURL, in
+// its src attribute or in any of its srcset candidates.
+func (r *Readability) isImageOf(img *html.Node, imageURL string) bool {
+	if src := getAttribute(img, "src"); src != "" && sameImageURL(toAbsoluteURI(src, r.documentURI), imageURL) {
+		return true
+	}
+
+	for _, candidate := range ParseSrcset(getAttribute(img, "srcset")) {
+		if sameImageURL(toAbsoluteURI(candidate.URL, r.documentURI), imageURL) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// heroCaption returns the caption of the hero image, which is either the
+// caption of the figure that contains the image or an element with a caption
+// class name right after the image or its parent.
+func heroCaption(img *html.Node) *html.Node {
+	if figure := closestAncestor(img, "figure"); figure != nil {
+		if captions := getElementsByTagName(figure, "figcaption"); len(captions) > 0 {
+			return captions[0]
+		}
+	}
+
+	for _, node := range []*html.Node{img, img.Parent} {
+		if sibling := nextElementSibling(node); sibling != nil && rxHeroCaption.MatchString(limitedClassName(sibling, DefaultMaxMatchStringLength)) {
+			return sibling
+		}
+	}
+
+	return nil
+}
+
+// insertHeroImage prepends a figure with the image of the article, as found
+// in the metadata, to the content if the image is shown in the document but
+// was left out of the content, which is common in pages with a hero image
+// above the title.
+func (r *Readability) insertHeroImage(articleContent *html.Node, imageURL string) {
+	page := firstElementChild(articleContent)
+
+	if imageURL == "" || page == nil {
+		return
+	}
+
+	for _, img := range getElementsByTagName(articleContent, "img") {
+		if r.isImageOf(img, imageURL) {
+			return
+		}
+	}
+
+	var hero *html.Node
+
+	for _, img := range getElementsByTagName(r.doc, "img") {
+		if r.isImageOf(img, imageURL) {
+			hero = img
+			break
+		}
+	}
+
+	if hero == nil {
+		return
+	}
+
+	figure := createElement("figure")
+	img := createElement("img")
+	copyAttributes(hero, img, "src", "srcset", "sizes", "alt", "width", "height")
+	figure.AppendChild(img)
+
+	if caption := heroCaption(hero); caption != nil && strings.TrimSpace(textContent(caption)) != "" {
+		figcaption := createElement("figcaption")
+
+		for child := caption.FirstChild; child != nil; child = child.NextSibling {
+			figcaption.AppendChild(cloneNode(child))
+		}
+
+		figure.AppendChild(figcaption)
+	}
+
+	page.InsertBefore(figure, page.FirstChild)
+}
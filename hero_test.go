@@ -0,0 +1,51 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIncludeHeroImage(t *testing.T) {
+	page := `<html>
+		<head>
+			<title>hello world</title>
+			<meta property="og:image" content="https://cixtor.com/images/hero.jpg?w=1200">
+		</head>
+		<body>
+			<div class="hero">
+				<img src="/images/hero.jpg" alt="The harbor at dawn">
+				<span class="hero-caption">Photo by Jane Doe</span>
+			</div>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+			</article>
+		</body>
+		</html>`
+
+	a, err := New().Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Contains(a.Content, "<img") {
+		t.Fatalf("unexpected hero image: %s", a.Content)
+	}
+
+	parser := New()
+	parser.IncludeHeroImage = true
+	a, err = parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := `<div id="readability-page-1" class="page"><figure>` +
+		`<img src="https://cixtor.com/images/hero.jpg" alt="The harbor at dawn"/>` +
+		`<figcaption>Photo by Jane Doe</figcaption></figure>`
+
+	if !strings.HasPrefix(a.Content, expected) {
+		t.Fatalf("the hero image was not included: %s", a.Content)
+	}
+}
@@ -0,0 +1,294 @@
+// Package heuristics exposes the primitives used by the readability parser to
+// score and filter the nodes of a document, so other tools can apply the same
+// rules in their own pre-processing and post-processing steps.
+package heuristics
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cixtor/readability/internal/dom"
+	"golang.org/x/net/html"
+)
+
+var rxNormalize = regexp.MustCompile(`(?i)\s{2,}`)
+
+// positiveClassTokens is a list of words that, found in the class name or ID
+// of an element, suggest that it is part of the content.
+var positiveClassTokens = []string{
+	"article", "body", "content", "entry", "hentry", "h-entry", "main",
+	"page", "pagination", "post", "text", "blog", "story",
+}
+
+// negativeClassTokens is a list of words that, found in the class name or ID
+// of an element, suggest that it is not part of the content.
+var negativeClassTokens = []string{
+	"hidden", "hid", "banner", "combx", "comment", "com-", "contact", "foot",
+	"footer", "footnote", "gdpr", "masthead", "media", "meta", "outbrain",
+	"promo", "related", "scroll", "share", "shoutbox", "sidebar", "skyscraper",
+	"sponsor", "shopping", "tags", "tool", "widget",
+}
+
+// DefaultClassWeight is the weight of each one of the built-in class tokens.
+const DefaultClassWeight = 25
+
+// defaultClassWeights is the map of class tokens used when Weights is nil.
+var defaultClassWeights = DefaultClassWeights()
+
+// ClassWeightOptions configures the words considered by ClassWeight.
+type ClassWeightOptions struct {
+	// Weights maps the words found in class names and IDs to the weight they
+	// add to the element. If nil, the built-in words are used.
+	Weights map[string]int
+
+	// PositiveTokens are additional words that suggest content. They count
+	// as DefaultClassWeight, unless a word in Weights is already higher.
+	PositiveTokens []string
+
+	// NegativeTokens are additional words that suggest boilerplate. They
+	// count as -DefaultClassWeight, unless a word in Weights is lower.
+	NegativeTokens []string
+
+	// MaxNameLength is the maximum number of bytes of the class names and of
+	// the ID considered, longer values are truncated before they are matched.
+	// Zero means no limit.
+	MaxNameLength int
+}
+
+// DefaultClassWeights returns a new map with the built-in class tokens and their
+// weights, each one with a weight of +25 or -25.
+func DefaultClassWeights() map[string]int {
+	weights := make(map[string]int, len(positiveClassTokens)+len(negativeClassTokens))
+
+	for _, token := range positiveClassTokens {
+		weights[token] = DefaultClassWeight
+	}
+
+	for _, token := range negativeClassTokens {
+		weights[token] = -DefaultClassWeight
+	}
+
+	return weights
+}
+
+// ClassWeight returns the weight of the class name and the ID of the element.
+// Positive weights suggest that the element is part of the content, negative
+// weights suggest boilerplate like sidebars and comments.
+func ClassWeight(node *html.Node, opts ClassWeightOptions) int {
+	weight := 0
+
+	className := strings.TrimSpace(TruncateName(dom.GetAttribute(node, "class"), opts.MaxNameLength))
+	className = rxNormalize.ReplaceAllString(className, "\x20")
+
+	if className != "" {
+		weight += nameWeight(className, opts)
+	}
+
+	if id := strings.TrimSpace(TruncateName(dom.GetAttribute(node, "id"), opts.MaxNameLength)); id != "" {
+		weight += nameWeight(id, opts)
+	}
+
+	return weight
+}
+
+// TruncateName returns the first limit bytes of a class name or ID, without
+// splitting a multibyte character. Zero or a negative limit means no limit.
+func TruncateName(name string, limit int) string {
+	if limit <= 0 || len(name) <= limit {
+		return name
+	}
+
+	for limit > 0 && !utf8.RuneStart(name[limit]) {
+		limit--
+	}
+
+	return name[:limit]
+}
+
+// nameWeight returns the weight of a class name or ID. Only the largest of the
+// positive weights and the lowest of the negative weights of the tokens found
+// in the name are added, so repeating similar words does not accumulate.
+func nameWeight(name string, opts ClassWeightOptions) int {
+	positive := 0
+	negative := 0
+	weights := opts.Weights
+
+	if weights == nil {
+		weights = defaultClassWeights
+	}
+
+	name = strings.ToLower(name)
+	words := strings.Fields(name)
+
+	for token, weight := range weights {
+		if !matchesClassToken(name, words, strings.ToLower(token)) {
+			continue
+		}
+
+		if weight > positive {
+			positive = weight
+		}
+
+		if weight < negative {
+			negative = weight
+		}
+	}
+
+	if positive < DefaultClassWeight && containsAnyToken(name, opts.PositiveTokens) {
+		positive = DefaultClassWeight
+	}
+
+	if negative > -DefaultClassWeight && containsAnyToken(name, opts.NegativeTokens) {
+		negative = -DefaultClassWeight
+	}
+
+	return positive + negative
+}
+
+// matchesClassToken returns true if the name contains the token. Tokens with
+// three characters or less, like "hid", must match one of the words of the
+// name, otherwise they would match too many unrelated names.
+func matchesClassToken(name string, words []string, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	if len(token) > 3 {
+		return strings.Contains(name, token)
+	}
+
+	for _, word := range words {
+		if word == token {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsAnyToken returns true if value contains one of the tokens, ignoring
+// the case.
+func containsAnyToken(value string, tokens []string) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+
+	value = strings.ToLower(value)
+
+	for _, token := range tokens {
+		if token != "" && strings.Contains(value, strings.ToLower(token)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InnerText returns the text of the node and its descendants, without leading
+// and trailing whitespace, and with every other run of whitespace collapsed.
+func InnerText(node *html.Node) string {
+	return rxNormalize.ReplaceAllString(strings.TrimSpace(dom.TextContent(node)), "\x20")
+}
+
+// TextLength returns the number of bytes of the normalized text of the node.
+func TextLength(node *html.Node) int {
+	return len(InnerText(node))
+}
+
+// LinkDensity returns the amount of text inside links divided by the total
+// text of the node, a number between 0 and 1. Navigation menus and lists of
+// related articles have a high link density.
+func LinkDensity(node *html.Node) float64 {
+	return LinkDensityFunc(node, InnerText)
+}
+
+// LinkDensityFunc is like LinkDensity, but measures the text of the node and
+// of its links with innerText, for callers that keep the texts in a cache.
+func LinkDensityFunc(node *html.Node, innerText func(*html.Node) string) float64 {
+	textLength := len(innerText(node))
+
+	if textLength == 0 {
+		return 0
+	}
+
+	linkLength := 0
+
+	for _, link := range dom.GetElementsByTagName(node, "a") {
+		linkLength += len(innerText(link))
+	}
+
+	return float64(linkLength) / float64(textLength)
+}
+
+// IsDataTable returns true if the table looks like it contains data, as opposed
+// to a table used to lay out the page, using the same rules as Readability.js.
+func IsDataTable(table *html.Node) bool {
+	if dom.GetAttribute(table, "role") == "presentation" {
+		return false
+	}
+
+	if dom.GetAttribute(table, "datatable") == "0" {
+		return false
+	}
+
+	if dom.HasAttribute(table, "summary") {
+		return true
+	}
+
+	if captions := dom.GetElementsByTagName(table, "caption"); len(captions) > 0 && captions[0].FirstChild != nil {
+		return true
+	}
+
+	for _, tag := range []string{"col", "colgroup", "tfoot", "thead", "th"} {
+		if len(dom.GetElementsByTagName(table, tag)) > 0 {
+			return true
+		}
+	}
+
+	// Nested tables indicate a layout table.
+	for c := table.FirstChild; c != nil; c = c.NextSibling {
+		if len(dom.GetElementsByTagName(c, "table")) > 0 {
+			return false
+		}
+	}
+
+	rows, columns := rowAndColumnCount(table)
+
+	return rows >= 10 || columns > 4 || rows*columns > 10
+}
+
+// rowAndColumnCount returns how many rows and columns the table has.
+func rowAndColumnCount(table *html.Node) (int, int) {
+	rows := 0
+	columns := 0
+
+	for _, tr := range dom.GetElementsByTagName(table, "tr") {
+		rowSpan, _ := strconv.Atoi(dom.GetAttribute(tr, "rowspan"))
+
+		if rowSpan == 0 {
+			rowSpan = 1
+		}
+
+		rows += rowSpan
+
+		columnsInThisRow := 0
+
+		for _, td := range dom.GetElementsByTagName(tr, "td") {
+			colSpan, _ := strconv.Atoi(dom.GetAttribute(td, "colspan"))
+
+			if colSpan == 0 {
+				colSpan = 1
+			}
+
+			columnsInThisRow += colSpan
+		}
+
+		if columnsInThisRow > columns {
+			columns = columnsInThisRow
+		}
+	}
+
+	return rows, columns
+}
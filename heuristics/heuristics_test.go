@@ -0,0 +1,80 @@
+package heuristics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cixtor/readability/internal/dom"
+	"golang.org/x/net/html"
+)
+
+func parseFragment(t *testing.T, input string) *html.Node {
+	doc, err := html.Parse(strings.NewReader("<html><body>" + input + "</body></html>"))
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	body := dom.GetElementsByTagName(doc, "body")[0]
+
+	return body.FirstChild
+}
+
+func TestLinkDensity(t *testing.T) {
+	node := parseFragment(t, `<div>  lorem   <a href="/a">ipsum</a></div>`)
+
+	if length := TextLength(node); length != 11 {
+		t.Fatalf("unexpected text length: %d", length)
+	}
+
+	if density := LinkDensity(node); density != 5.0/11.0 {
+		t.Fatalf("unexpected link density: %f", density)
+	}
+}
+
+func TestLinkDensityFunc(t *testing.T) {
+	node := parseFragment(t, `<div>lorem <a href="/a">ipsum</a> <a href="/b">dolor</a></div>`)
+	calls := 0
+
+	density := LinkDensityFunc(node, func(n *html.Node) string {
+		calls++
+		return InnerText(n)
+	})
+
+	if density != 10.0/17.0 || calls != 3 {
+		t.Fatalf("unexpected link density: %f after %d calls", density, calls)
+	}
+}
+
+func TestClassWeight(t *testing.T) {
+	node := parseFragment(t, `<div class="post-body" id="sidebar"></div>`)
+
+	if weight := ClassWeight(node, ClassWeightOptions{}); weight != 0 {
+		t.Fatalf("unexpected weight: %d", weight)
+	}
+
+	opts := ClassWeightOptions{Weights: map[string]int{"post": 40}, NegativeTokens: []string{"side"}}
+
+	if weight := ClassWeight(node, opts); weight != 15 {
+		t.Fatalf("unexpected weight with options: %d", weight)
+	}
+}
+
+func TestIsDataTable(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`<table><thead><tr><td>a</td></tr></thead></table>`, true},
+		{`<table role="presentation"><tr><th>a</th></tr></table>`, false},
+		{`<table><tr><td>a</td><td>b</td></tr></table>`, false},
+		{`<table><tr><td>1</td><td>2</td><td>3</td><td>4</td><td>5</td></tr></table>`, true},
+		{`<table><tr><td><table><tr><td>a</td></tr></table></td></tr></table>`, false},
+	}
+
+	for _, test := range tests {
+		if IsDataTable(parseFragment(t, test.input)) != test.expected {
+			t.Fatalf("expecting %t for %s", test.expected, test.input)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package readability
+
+import (
+	"golang.org/x/net/html"
+)
+
+// Hook is a function called at a stage of the extraction with the node being
+// processed, see BeforePrep, AfterGrab and AfterPostProcess. Hooks can modify
+// the node, which is useful to fix the markup of specific sites.
+type Hook func(node *html.Node)
+
+// runHook calls the hook with the node, if both are set.
+func runHook(hook Hook, node *html.Node) {
+	if hook != nil && node != nil {
+		hook(node)
+	}
+}
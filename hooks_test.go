@@ -0,0 +1,59 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestHooks(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<div class="site-specific-junk">Sponsored message from our partners</div>
+			</article>
+		</body>
+		</html>`)
+
+	var stages []string
+
+	parser := New()
+	parser.BeforePrep = func(doc *html.Node) {
+		stages = append(stages, "prep")
+
+		for _, div := range getElementsByTagName(doc, "div") {
+			if className(div) == "site-specific-junk" {
+				div.Parent.RemoveChild(div)
+			}
+		}
+	}
+	parser.AfterGrab = func(content *html.Node) {
+		stages = append(stages, "grab")
+	}
+	parser.AfterPostProcess = func(content *html.Node) {
+		stages = append(stages, "post")
+
+		for _, p := range getElementsByTagName(content, "p") {
+			setAttribute(p, "class", "lead")
+		}
+	}
+
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Join(stages, ",") != "prep,grab,post" {
+		t.Fatalf("unexpected stages: %v", stages)
+	}
+
+	if strings.Contains(a.TextContent, "Sponsored") || !strings.Contains(a.Content, `<p class="lead">`) {
+		t.Fatalf("the hooks did not modify the content: %s", a.Content)
+	}
+}
@@ -0,0 +1,71 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxEmojiClass matches the class names of the images used to render emoji,
+// like "emoji" or "wp-smiley".
+var rxEmojiClass = regexp.MustCompile(`(?i)(^|\s)(emoji|emojione|twemoji|wp-smiley|emoticon)(\s|$)`)
+
+// rxEmojiURL matches the URLs of the images served by the common emoji CDNs.
+var rxEmojiURL = regexp.MustCompile(`(?i)^(https?:)?//([^/]+\.)?(` +
+	`s\.w\.org/images/core/emoji/|twemoji\.maxcdn\.com/|cdn\.jsdelivr\.net/gh/(twitter|jdecked)/twemoji|` +
+	`cdnjs\.cloudflare\.com/ajax/libs/twemoji/|abs\.twimg\.com/emoji/|static\.xx\.fbcdn\.net/images/emoji|` +
+	`emoji\.slack-edge\.com/|github\.githubassets\.com/images/icons/emoji/|cdn\.discordapp\.com/emojis/)`)
+
+// rxIconClass matches the class names of the inline SVG icons.
+var rxIconClass = regexp.MustCompile(`(?i)(^|[\s_-])(icon|icons|glyph|emoji)([\s_-]|$)`)
+
+// iconMaxSize is the largest width and height, in pixels, of an inline SVG
+// icon with no other hint of being decorative.
+const iconMaxSize = 32
+
+// isEmojiImage returns true if the image renders an emoji, recognized by its
+// class name or by the URL of its source.
+func isEmojiImage(img *html.Node) bool {
+	return rxEmojiClass.MatchString(className(img)) || rxEmojiURL.MatchString(getAttribute(img, "src"))
+}
+
+// isIcon returns true if the inline SVG element is a decorative icon, either
+// because it is hidden from assistive technology, because of its class name,
+// or because it is declared to be as small as the text around it.
+func isIcon(svg *html.Node) bool {
+	if getAttribute(svg, "aria-hidden") == "true" || getAttribute(svg, "role") == "presentation" {
+		return true
+	}
+
+	if rxIconClass.MatchString(className(svg)) {
+		return true
+	}
+
+	width, widthOK := parseDimension(getAttribute(svg, "width"))
+	height, heightOK := parseDimension(getAttribute(svg, "height"))
+
+	return widthOK && heightOK && width <= iconMaxSize && height <= iconMaxSize
+}
+
+// stripIcons replaces the images of emoji with their alternative text, which
+// is usually the emoji itself, and removes the inline SVG icons, so they are
+// neither reported as images of the article nor read aloud. See StripIcons.
+func (r *Readability) stripIcons(articleContent *html.Node) {
+	r.forEachNode(getElementsByTagName(articleContent, "img"), func(img *html.Node, _ int) {
+		if img.Parent == nil || !isEmojiImage(img) {
+			return
+		}
+
+		r.invalidateText(img.Parent)
+
+		if alt := strings.TrimSpace(getAttribute(img, "alt")); alt != "" {
+			replaceNode(img, createTextNode(alt))
+			return
+		}
+
+		img.Parent.RemoveChild(img)
+	})
+
+	r.removeNodes(getElementsByTagName(articleContent, "svg"), r.reportingFilter(RemovalIcon, isIcon))
+}
@@ -0,0 +1,98 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestIsEmojiImage(t *testing.T) {
+	tests := map[string]bool{
+		`<img class="emoji" src="/smile.png" alt="😀">`:                                           true,
+		`<img class="wp-smiley" src="/smile.png" alt=":)">`:                                      true,
+		`<img src="https://s.w.org/images/core/emoji/14.0.0/72x72/1f600.png" alt="😀">`:           true,
+		`<img src="https://cdn.jsdelivr.net/gh/twitter/twemoji@14.0.2/assets/72x72/1f600.png">`:  true,
+		`<img class="emoji-free-photo" src="https://cixtor.com/photo.jpg" alt="A photo">`:        false,
+		`<img src="https://cixtor.com/images/emoji-history.jpg" alt="The history of the emoji">`: false,
+	}
+
+	for input, expected := range tests {
+		doc, err := html.Parse(strings.NewReader(input))
+
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
+		}
+
+		if result := isEmojiImage(getElementsByTagName(doc, "img")[0]); result != expected {
+			t.Fatalf("isEmojiImage(%s) = %t, expecting %t", input, result, expected)
+		}
+	}
+}
+
+func TestIsIcon(t *testing.T) {
+	tests := map[string]bool{
+		`<svg aria-hidden="true"></svg>`:                  true,
+		`<svg class="icon icon-twitter"></svg>`:           true,
+		`<svg class="social_icon"></svg>`:                 true,
+		`<svg width="16" height="16"></svg>`:              true,
+		`<svg width="640" height="480"></svg>`:            false,
+		`<svg class="chart" viewBox="0 0 640 480"></svg>`: false,
+		`<svg class="silicon-wafer" width="64"></svg>`:    false,
+	}
+
+	for input, expected := range tests {
+		doc, err := html.Parse(strings.NewReader(input))
+
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
+		}
+
+		if result := isIcon(getElementsByTagName(doc, "svg")[0]); result != expected {
+			t.Fatalf("isIcon(%s) = %t, expecting %t", input, result, expected)
+		}
+	}
+}
+
+func TestStripIcons(t *testing.T) {
+	input := `<html>
+		<head><title>hello world</title></head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet <img class="emoji" src="/smile.png" alt="😀"> consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore.</p>
+				<p><svg class="icon"><title>Warning</title><path d="M0 0h16v16H0z"/></svg> Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip.</p>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			</article>
+		</body>
+	</html>`
+
+	parser := New()
+	parser.StripIcons = true
+	parser.ReportRemovals = true
+
+	res, err := parser.Analyze(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if text := res.Text(); !strings.Contains(text, "amet 😀 consectetur") || strings.Contains(text, "Warning") {
+		t.Fatalf("unexpected text: %q", text)
+	}
+
+	if len(res.Images) != 0 {
+		t.Fatalf("the emoji should not be reported as images: %#v", res.Images)
+	}
+
+	icons := 0
+
+	for _, removal := range res.Removals {
+		if removal.Reason == RemovalIcon {
+			icons++
+		}
+	}
+
+	if icons != 1 {
+		t.Fatalf("expecting one icon to be removed: %#v", res.Removals)
+	}
+}
@@ -0,0 +1,278 @@
+package readability
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ImageMeta describes an image found in the content of the article.
+type ImageMeta struct {
+	// URL is the absolute URL of the image.
+	URL string
+
+	// Alt is the alternative text of the image.
+	Alt string
+
+	// Width is the width of the image in pixels, or 0 if it is unknown. It
+	// is taken from the width attributes or from the largest width in the
+	// srcset attribute, the image itself is never downloaded.
+	Width int
+
+	// Height is the height of the image in pixels, or 0 if it is unknown.
+	Height int
+
+	// Caption is the text of the <figcaption> of the figure that contains
+	// the image or, for galleries, of the element found as its caption.
+	Caption string
+}
+
+// rxTrackingPixel matches the URLs of the images used by analytics services to
+// track the visitors of a page: the hosts of known trackers, and the common
+// file names of pixels, like spacer.gif. Other hosts are not matched by name
+// alone, those images must be declared invisibly small, see isTrackingPixel.
+var rxTrackingPixel = regexp.MustCompile(`(?i)^(https?:)?//([^/]+\.)?(` +
+	`facebook\.com/tr|google-analytics\.com/|googleadservices\.com/|doubleclick\.net/|` +
+	`pixel\.wp\.com/|stats\.wp\.com/|scorecardresearch\.com/|pixel\.quantserve\.com/|` +
+	`bat\.bing\.com/|analytics\.twitter\.com/|ct\.pinterest\.com/|px\.ads\.linkedin\.com/|` +
+	`pixel\.mathtag\.com/|pixel\.rubiconproject\.com/|beacon\.krxd\.net/)` +
+	`|/(pixel|beacon|tracker|tracking|spacer|blank)\.(gif|png)(\?|$)`)
+
+// imageMetaNames is a list of the metadata names used to declare the image
+// that represents the content of the document.
+var imageMetaNames = []string{
+	"og:image",
+	"og:image:url",
+	"og:image:secure_url",
+	"image",
+	"twitter:image",
+	"twitter:image:src",
+}
+
+// probeImages requests every candidate image and returns the URL of the
+// largest one that loads. Candidates without a known size are considered
+// smaller than any candidate with a known size.
+func (r *Readability) probeImages(candidates []string) string {
+	image := ""
+	imageSize := int64(-2)
+
+	for _, candidate := range candidates {
+		candidate = toAbsoluteURI(candidate, r.documentURI)
+
+		if candidate == "" {
+			continue
+		}
+
+		if size, ok := r.probeImage(candidate); ok && size > imageSize {
+			image = candidate
+			imageSize = size
+		}
+	}
+
+	return image
+}
+
+// probeImage sends a HEAD request for the image and returns its size, or -1
+// if the size is unknown, and whether the image loads or not.
+func (r *Readability) probeImage(imageURL string) (int64, bool) {
+	res, err := r.probe(http.MethodHead, imageURL)
+
+	// Some servers do not implement HEAD requests, try again with GET.
+	if err == nil && res.StatusCode == http.StatusMethodNotAllowed {
+		res, err = r.probe(http.MethodGet, imageURL)
+	}
+
+	if err != nil {
+		return 0, false
+	}
+
+	contentType := res.Header.Get("Content-Type")
+
+	if res.StatusCode < 200 || res.StatusCode > 299 || strings.HasPrefix(contentType, "text/") {
+		return 0, false
+	}
+
+	return res.ContentLength, true
+}
+
+// probe sends a request without reading the body of the response.
+func (r *Readability) probe(method string, resourceURL string) (*http.Response, error) {
+	req, err := http.NewRequest(method, resourceURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.fetcher().Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body.Close()
+
+	return res, nil
+}
+
+// getArticleImages returns the metadata of the images in the article content.
+func (r *Readability) getArticleImages(articleContent *html.Node) []ImageMeta {
+	var images []ImageMeta
+
+	r.forEachNode(getElementsByTagName(articleContent, "img"), func(img *html.Node, _ int) {
+		src := getAttribute(img, "src")
+
+		if src == "" {
+			return
+		}
+
+		width, height := imageDimensions(img)
+
+		images = append(images, ImageMeta{
+			URL:     src,
+			Alt:     strings.TrimSpace(getAttribute(img, "alt")),
+			Width:   width,
+			Height:  height,
+			Caption: imageCaption(img),
+		})
+	})
+
+	return images
+}
+
+// imageDimensions returns the size of the image as declared in its attributes.
+// The width and height attributes are preferred, then the data attributes used
+// by lazy loading scripts, and finally the largest width in the srcset.
+func imageDimensions(img *html.Node) (int, int) {
+	width := firstDimension(img, "width", "data-width")
+	height := firstDimension(img, "height", "data-height")
+
+	if width == 0 {
+		width = srcsetMaxWidth(getAttribute(img, "srcset"))
+	}
+
+	return width, height
+}
+
+// firstDimension returns the first valid dimension among the attributes.
+func firstDimension(node *html.Node, attrNames ...string) int {
+	for _, attrName := range attrNames {
+		if size, ok := parseDimension(getAttribute(node, attrName)); ok && size > 0 {
+			return size
+		}
+	}
+
+	return 0
+}
+
+// parseDimension parses a size in pixels like "300" or "300px". Relative sizes
+// like "50%" or "10em" cannot be resolved and are reported as not valid.
+func parseDimension(value string) (int, bool) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	value = strings.TrimSuffix(value, "px")
+
+	if dot := strings.Index(value, "."); dot != -1 {
+		value = value[:dot]
+	}
+
+	size, err := strconv.Atoi(strings.TrimSpace(value))
+
+	if err != nil || size < 0 {
+		return 0, false
+	}
+
+	return size, true
+}
+
+// isTrackingPixel determines if the image is a tracking pixel, either because
+// it is declared to be invisibly small or because its URL looks like the URL
+// of a known beacon.
+func isTrackingPixel(img *html.Node) bool {
+	for _, attrName := range []string{"width", "height"} {
+		if size, ok := parseDimension(getAttribute(img, attrName)); ok && size <= 2 {
+			return true
+		}
+	}
+
+	return rxTrackingPixel.MatchString(getAttribute(img, "src"))
+}
+
+// removeTrackingPixels removes the tracking pixels from the article content.
+func (r *Readability) removeTrackingPixels(articleContent *html.Node) {
+	r.removeNodes(getElementsByTagName(articleContent, "img"), r.reportingFilter(RemovalTrackingPixel, isTrackingPixel))
+}
+
+// srcsetMaxWidth returns the largest width descriptor in a srcset attribute.
+func srcsetMaxWidth(srcset string) int {
+	maxWidth := 0
+
+	for _, candidate := range ParseSrcset(srcset) {
+		if candidate.Width > maxWidth {
+			maxWidth = candidate.Width
+		}
+	}
+
+	return maxWidth
+}
+
+// limitInlineImages strips the data URIs larger than MaxInlineImageBytes from
+// the images in the article content. The <img> element is kept without its
+// src attribute, so the alternative text is rendered as a placeholder.
+func (r *Readability) limitInlineImages(articleContent *html.Node) {
+	r.forEachNode(r.getAllNodesWithTag(articleContent, "img", "source"), func(node *html.Node, _ int) {
+		if src := getAttribute(node, "src"); dataURISize(src) > r.MaxInlineImageBytes {
+			removeAttribute(node, "src")
+		}
+
+		srcset := getAttribute(node, "srcset")
+
+		if !strings.Contains(srcset, "data:") {
+			return
+		}
+
+		var candidates []ImageCandidate
+
+		for _, candidate := range ParseSrcset(srcset) {
+			if dataURISize(candidate.URL) <= r.MaxInlineImageBytes {
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		if len(candidates) == 0 {
+			removeAttribute(node, "srcset")
+			return
+		}
+
+		setAttribute(node, "srcset", FormatSrcset(candidates))
+	})
+}
+
+// dataURISize returns the size in bytes of the data encoded in a data URI, or
+// zero if the URI is not a data URI.
+func dataURISize(uri string) int {
+	if !strings.HasPrefix(strings.ToLower(uri), "data:") {
+		return 0
+	}
+
+	comma := strings.Index(uri, ",")
+
+	if comma == -1 {
+		return 0
+	}
+
+	fields := strings.Fields(uri[comma+1:])
+
+	if len(fields) == 0 {
+		return 0
+	}
+
+	payload := fields[0]
+
+	if strings.HasSuffix(strings.ToLower(uri[:comma]), ";base64") {
+		return len(payload) * 3 / 4
+	}
+
+	return len(payload)
+}
@@ -0,0 +1,203 @@
+package readability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestProbeImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/small.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("Content-Length", "100")
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("Content-Length", "5000")
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+			<meta property="og:image" content="/small.png">
+			<meta property="og:image" content="/missing.png">
+			<meta property="og:image" content="/large.png">
+			<meta name="twitter:image" content="/small.png">
+			<link rel="icon" type="image/png" href="/favicon-32x32.png">
+		</head>
+		<body>
+			<p>lorem ipsum</p>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.ProbeImages = true
+	a, err := parser.Parse(input, server.URL+"/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Image != server.URL+"/large.png" {
+		t.Fatalf("expecting the largest image: %s", a.Image)
+	}
+
+	if a.Favicon != "" {
+		t.Fatalf("expecting the missing favicon to be dropped: %s", a.Favicon)
+	}
+}
+
+func TestImageDimensions(t *testing.T) {
+	tests := []struct {
+		img    string
+		width  int
+		height int
+	}{
+		{`<img src="a.png">`, 0, 0},
+		{`<img src="a.png" width="300" height="200">`, 300, 200},
+		{`<img src="a.png" width="300px" height="50%">`, 300, 0},
+		{`<img src="a.png" data-width="640" data-height="480">`, 640, 480},
+		{`<img src="a.png" srcset="a-320.png 320w, a-1024.png 1024w, a-640.png 640w">`, 1024, 0},
+	}
+
+	for _, test := range tests {
+		doc, _ := html.Parse(strings.NewReader(test.img))
+		width, height := imageDimensions(getElementsByTagName(doc, "img")[0])
+
+		if width != test.width || height != test.height {
+			t.Errorf("%s: want %dx%d got %dx%d", test.img, test.width, test.height, width, height)
+		}
+	}
+}
+
+func TestIsTrackingPixel(t *testing.T) {
+	tests := []struct {
+		img      string
+		expected bool
+	}{
+		{`<img src="https://cixtor.com/photo.jpg" width="640" height="480">`, false},
+		{`<img src="https://cixtor.com/photo.jpg">`, false},
+		{`<img src="https://cixtor.com/p.gif" width="1" height="1">`, true},
+		{`<img src="https://cixtor.com/p.gif" width="0" height="0">`, true},
+		{`<img src="https://www.facebook.com/tr?id=1&ev=PageView">`, true},
+		{`<img src="https://pixel.wp.com/g.gif?blog=1">`, true},
+		{`<img src="//sb.scorecardresearch.com/p?c1=2">`, true},
+		{`<img src="https://cixtor.com/images/spacer.gif">`, true},
+		{`<img src="https://cixtor.com/images/pixel-art.png">`, false},
+		{`<img src="https://pixel.photography/gallery/cat.jpg">`, false},
+		{`<img src="https://beacon.cixtor.com/photos/lead.jpg" width="640">`, false},
+		{`<img src="https://pixel.mathtag.com/event/img?mt_id=1">`, true},
+	}
+
+	for _, test := range tests {
+		doc, _ := html.Parse(strings.NewReader(test.img))
+
+		if result := isTrackingPixel(getElementsByTagName(doc, "img")[0]); result != test.expected {
+			t.Errorf("%s: want %v got %v", test.img, test.expected, result)
+		}
+	}
+}
+
+func TestMaxInlineImageBytes(t *testing.T) {
+	small := "data:image/png;base64," + strings.Repeat("A", 40)
+	large := "data:image/png;base64," + strings.Repeat("A", 4000)
+
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<p>lorem ipsum</p>
+			<p><img src="` + small + `" alt="small"></p>
+			<p><img src="` + large + `" alt="large" srcset="` + large + ` 2x, ` + small + ` 1x"></p>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.MaxInlineImageBytes = 1000
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Contains(a.Content, large) {
+		t.Fatalf("the large data URI was not stripped")
+	}
+
+	if !strings.Contains(a.Content, `alt="large" srcset="`+small+` 1x"`) {
+		t.Fatalf("expecting placeholder and small srcset candidate: %s", a.Content)
+	}
+}
+
+func TestDataURISize(t *testing.T) {
+	tests := map[string]int{
+		"data:image/gif;base64,":        0,
+		"data:image/gif;base64,   ":     0,
+		"data:image/gif;base64,AAAA":    3,
+		"data:image/gif;base64,AAAA 2x": 3,
+		"data:text/plain,hello":         5,
+		"data:image/gif":                0,
+		"https://cixtor.com/photo.gif":  0,
+	}
+
+	for uri, expected := range tests {
+		if size := dataURISize(uri); size != expected {
+			t.Fatalf("dataURISize(%q) = %d, expecting %d", uri, size, expected)
+		}
+	}
+
+	parser := New()
+	parser.MaxInlineImageBytes = 1000
+	input := `<html><head><title>hello world</title></head><body>` +
+		`<p>lorem ipsum <img src="data:image/gif;base64," srcset="data:image/gif;base64, 1x" alt="empty"></p></body></html>`
+
+	if _, err := parser.Parse(strings.NewReader(input), "https://cixtor.com/blog"); err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+}
+
+func TestMediaURIs(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<video poster="poster.jpg" controls>
+					<source src="/media/clip.webm" type="video/webm">
+					<track src="captions.vtt" kind="captions" srclang="en">
+				</video>
+				<audio src="//cdn.cixtor.com/podcast.mp3" controls></audio>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog/post")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{
+		`poster="https://cixtor.com/blog/poster.jpg"`,
+		`src="https://cixtor.com/media/clip.webm"`,
+		`src="https://cixtor.com/blog/captions.vtt"`,
+		`src="https://cdn.cixtor.com/podcast.mp3"`,
+	}
+
+	for _, attr := range expected {
+		if !strings.Contains(a.Content, attr) {
+			t.Fatalf("missing %s in %s", attr, a.Content)
+		}
+	}
+}
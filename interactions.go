@@ -0,0 +1,213 @@
+package readability
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// commentCountMetaNames is a list of meta tag names used to declare the number
+// of comments of the article.
+var commentCountMetaNames = []string{
+	"comment-count",
+	"comment_count",
+	"comments-count",
+	"comments_count",
+	"commentcount",
+	"disqus:comment_count",
+}
+
+// interactionStats holds the counters found in the metadata of the document.
+type interactionStats struct {
+	commentCount int
+	counts       map[string]int
+}
+
+// getInteractionStats extracts the number of comments and the counters of user
+// interactions, like shares and likes, from the schema.org interactionStatistic
+// declared with JSON-LD or microdata, and from the common meta tags. It must be
+// called before the scripts are removed from the document.
+func (r *Readability) getInteractionStats(doc *html.Node) interactionStats {
+	stats := interactionStats{commentCount: -1, counts: map[string]int{}}
+
+	for _, script := range getElementsByTagName(doc, "script") {
+		if !strings.EqualFold(strings.TrimSpace(getAttribute(script, "type")), "application/ld+json") {
+			continue
+		}
+
+		var data interface{}
+
+		if err := json.Unmarshal([]byte(textContent(script)), &data); err != nil {
+			continue
+		}
+
+		stats.addJSONLD(data)
+	}
+
+	for _, node := range getElementsByTagName(doc, "*") {
+		switch getAttribute(node, "itemprop") {
+		case "commentCount":
+			if count, ok := parseCount(microdataValue(node)); ok && stats.commentCount < 0 {
+				stats.commentCount = count
+			}
+		case "interactionStatistic":
+			stats.addMicrodata(node)
+		}
+	}
+
+	for _, meta := range getElementsByTagName(doc, "meta") {
+		name := strings.ToLower(getAttribute(meta, "name") + getAttribute(meta, "property"))
+
+		if indexOf(commentCountMetaNames, name) == -1 {
+			continue
+		}
+
+		if count, ok := parseCount(getAttribute(meta, "content")); ok && stats.commentCount < 0 {
+			stats.commentCount = count
+		}
+	}
+
+	if count, ok := stats.counts["CommentAction"]; ok && stats.commentCount < 0 {
+		stats.commentCount = count
+	}
+
+	if stats.commentCount < 0 {
+		stats.commentCount = 0
+	}
+
+	return stats
+}
+
+// addJSONLD walks the JSON-LD data looking for commentCount properties and
+// InteractionCounter objects. The properties of the objects are visited in the
+// order of their names, so the first value found is the same on every run.
+func (s *interactionStats) addJSONLD(data interface{}) {
+	switch value := data.(type) {
+	case []interface{}:
+		for _, item := range value {
+			s.addJSONLD(item)
+		}
+	case map[string]interface{}:
+		if count, ok := jsonCount(value["commentCount"]); ok && s.commentCount < 0 {
+			s.commentCount = count
+		}
+
+		if action := interactionType(value["interactionType"]); action != "" {
+			if count, ok := jsonCount(value["userInteractionCount"]); ok {
+				s.add(action, count)
+			}
+		}
+
+		keys := make([]string, 0, len(value))
+
+		for key := range value {
+			if key != "interactionType" {
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s.addJSONLD(value[key])
+		}
+	}
+}
+
+// addMicrodata reads an InteractionCounter declared with microdata.
+func (s *interactionStats) addMicrodata(node *html.Node) {
+	var action string
+	var count int
+	var found bool
+
+	for _, elem := range getElementsByTagName(node, "*") {
+		switch getAttribute(elem, "itemprop") {
+		case "interactionType":
+			action = interactionType(microdataValue(elem))
+		case "userInteractionCount":
+			count, found = parseCount(microdataValue(elem))
+		}
+	}
+
+	if action != "" && found {
+		s.add(action, count)
+	}
+}
+
+// add sets the counter of the action, keeping the first value found.
+func (s *interactionStats) add(action string, count int) {
+	if _, ok := s.counts[action]; !ok {
+		s.counts[action] = count
+	}
+}
+
+// interactionType returns the name of the schema.org action without the URL
+// of the vocabulary, for example "LikeAction" for "https://schema.org/LikeAction".
+func interactionType(value interface{}) string {
+	var name string
+
+	switch v := value.(type) {
+	case string:
+		name = v
+	case map[string]interface{}:
+		name, _ = v["@type"].(string)
+	}
+
+	name = strings.TrimSpace(name)
+
+	if idx := strings.LastIndexAny(name, "/:"); idx != -1 {
+		name = name[idx+1:]
+	}
+
+	return name
+}
+
+// microdataValue returns the value of a microdata property, which is found in
+// different attributes depending on the element.
+func microdataValue(node *html.Node) string {
+	switch tagName(node) {
+	case "meta":
+		return getAttribute(node, "content")
+	case "link", "a":
+		return getAttribute(node, "href")
+	}
+
+	if content := getAttribute(node, "content"); content != "" {
+		return content
+	}
+
+	return textContent(node)
+}
+
+// jsonCount converts a JSON number, or a string with a number, into a count.
+func jsonCount(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case float64:
+		if v < 0 {
+			return 0, false
+		}
+
+		return int(v), true
+	case string:
+		return parseCount(v)
+	}
+
+	return 0, false
+}
+
+// parseCount converts a counter, like "1,234", into a number.
+func parseCount(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	value = strings.NewReplacer(",", "", ".", "", "\x20", "", " ", "").Replace(value)
+
+	count, err := strconv.Atoi(value)
+
+	if err != nil || count < 0 {
+		return 0, false
+	}
+
+	return count, true
+}
@@ -0,0 +1,72 @@
+package readability
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInteractionCounts(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+			<script type="application/ld+json">
+			{
+				"@context": "https://schema.org",
+				"@type": "NewsArticle",
+				"headline": "hello world",
+				"interactionStatistic": [
+					{"@type": "InteractionCounter", "interactionType": "https://schema.org/ShareAction", "userInteractionCount": 1204},
+					{"@type": "InteractionCounter", "interactionType": {"@type": "LikeAction"}, "userInteractionCount": "87"}
+				]
+			}
+			</script>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>
+				<div itemprop="interactionStatistic" itemscope itemtype="https://schema.org/InteractionCounter">
+					<link itemprop="interactionType" href="https://schema.org/CommentAction">
+					<span itemprop="userInteractionCount">1,532</span> comments
+				</div>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.CommentCount != 1532 {
+		t.Fatalf("unexpected comment count: %d", a.CommentCount)
+	}
+
+	if a.InteractionCounts["ShareAction"] != 1204 || a.InteractionCounts["LikeAction"] != 87 {
+		t.Fatalf("unexpected interaction counts: %#v", a.InteractionCounts)
+	}
+}
+
+func TestInteractionCountsOrder(t *testing.T) {
+	data := `{
+		"@type": "NewsArticle",
+		"video": {"@type": "VideoObject", "commentCount": 9, "interactionStatistic": {"interactionType": "LikeAction", "userInteractionCount": 3}},
+		"discussion": {"@type": "DiscussionForumPosting", "commentCount": 5, "interactionStatistic": {"interactionType": "LikeAction", "userInteractionCount": 7}}
+	}`
+
+	for i := 0; i < 20; i++ {
+		var value interface{}
+
+		if err := json.Unmarshal([]byte(data), &value); err != nil {
+			t.Fatalf("cannot decode JSON-LD: %s", err)
+		}
+
+		stats := interactionStats{commentCount: -1, counts: map[string]int{}}
+		stats.addJSONLD(value)
+
+		if stats.commentCount != 5 || stats.counts["LikeAction"] != 7 {
+			t.Fatalf("unexpected counters: %d comments, %v", stats.commentCount, stats.counts)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+// Package dom implements the subset of the DOM API used by the parser, over
+// the nodes of golang.org/x/net/html. The functions mirror their JavaScript
+// counterparts, which makes it easier to follow the original Readability.js
+// code, and accept nil or detached nodes without panicking.
+package dom
+
+import (
+	"bytes"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// maxPooledBufferSize is the capacity above which a buffer is not returned to
+// the pool, so a single huge document does not pin its memory forever.
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool holds the buffers used to serialize the nodes, which are needed
+// at a very high frequency while the document is scored.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// AcquireBuffer returns an empty buffer from the pool.
+func AcquireBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// ReleaseBuffer returns the buffer to the pool. The buffer must not be used
+// after it was released.
+func ReleaseBuffer(buffer *bytes.Buffer) {
+	if buffer.Cap() > maxPooledBufferSize {
+		return
+	}
+
+	buffer.Reset()
+	bufferPool.Put(buffer)
+}
+
+// FirstElementChild returns the object's first child Element, or nil if there
+// are no child elements.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/firstElementChild
+func FirstElementChild(node *html.Node) *html.Node {
+	if node == nil {
+		return nil
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// NextElementSibling returns the Element immediately following the specified
+// one in its parent's children list, or nil if the specified Element is the
+// last one in the list.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/nextElementSibling
+func NextElementSibling(node *html.Node) *html.Node {
+	if node == nil {
+		return nil
+	}
+
+	for sibling := node.NextSibling; sibling != nil; sibling = sibling.NextSibling {
+		if sibling.Type == html.ElementNode {
+			return sibling
+		}
+	}
+
+	return nil
+}
+
+// AppendChild adds a node to the end of the list of children of a specified
+// parent node. If the given child is a reference to an existing node in the
+// document, AppendChild moves it from its current position to the new position
+// (there is no requirement to remove the node from its parent node before
+// appending it to some other node).
+//
+// Appending a node to itself or to one of its descendants would create a cycle,
+// and is ignored.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/appendChild
+func AppendChild(node *html.Node, child *html.Node) {
+	if node == nil || child == nil || Contains(child, node) {
+		return
+	}
+
+	if child.Parent != nil {
+		child.Parent.RemoveChild(child)
+	}
+
+	node.AppendChild(child)
+}
+
+// ChildNodes returns list of a node's direct children.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/childNodes
+func ChildNodes(node *html.Node) []*html.Node {
+	var list []*html.Node
+
+	if node == nil {
+		return nil
+	}
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		list = append(list, c)
+	}
+
+	return list
+}
+
+// Children returns the child elements of node.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/children
+func Children(node *html.Node) []*html.Node {
+	var list []*html.Node
+
+	if node == nil {
+		return nil
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			list = append(list, child)
+		}
+	}
+
+	return list
+}
+
+// CloneNode returns a duplicate of the node on which this method was called.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/cloneNode
+func CloneNode(node *html.Node) *html.Node {
+	clone := &html.Node{
+		Type:     node.Type,
+		DataAtom: node.DataAtom,
+		Data:     node.Data,
+		Attr:     make([]html.Attribute, len(node.Attr)),
+	}
+
+	copy(clone.Attr, node.Attr)
+
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		clone.AppendChild(CloneNode(c))
+	}
+
+	return clone
+}
+
+// CreateElement creates the HTML element specified by tagName.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Document/createElement
+func CreateElement(tagName string) *html.Node {
+	return &html.Node{Type: html.ElementNode, Data: tagName}
+}
+
+// CreateTextNode creates a new Text node.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Document/createTextNode
+func CreateTextNode(data string) *html.Node {
+	return &html.Node{Type: html.TextNode, Data: data}
+}
+
+// GetElementsByTagName returns a collection of HTML elements with the given
+// tag name, including the node itself. If tag name is an asterisk, a list of
+// all the available HTML elements will be returned instead.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementsByTagName
+func GetElementsByTagName(node *html.Node, tag string) []*html.Node {
+	if node == nil {
+		return nil
+	}
+
+	var lst []*html.Node
+	var fun func(*html.Node)
+
+	fun = func(n *html.Node) {
+		if n.Type == html.ElementNode && (tag == "*" || n.Data == tag) {
+			lst = append(lst, n)
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			fun(c)
+		}
+	}
+
+	fun(node)
+
+	return lst
+}
+
+// Attribute returns the value of a specified attribute on the element, and
+// whether the element has the attribute.
+func Attribute(node *html.Node, attrName string) (string, bool) {
+	if node == nil {
+		return "", false
+	}
+
+	for i := 0; i < len(node.Attr); i++ {
+		if node.Attr[i].Key == attrName {
+			return node.Attr[i].Val, true
+		}
+	}
+
+	return "", false
+}
+
+// GetAttribute returns the value of a specified attribute on the element. If
+// the given attribute does not exist, the function returns an empty string.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/getAttribute
+func GetAttribute(node *html.Node, attrName string) string {
+	value, _ := Attribute(node, attrName)
+	return value
+}
+
+// SetAttribute sets attribute for node. If attribute already exists, it will
+// be replaced.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/setAttribute
+func SetAttribute(node *html.Node, attrName string, attrValue string) {
+	for i := 0; i < len(node.Attr); i++ {
+		if node.Attr[i].Key == attrName {
+			node.Attr[i].Val = attrValue
+			return
+		}
+	}
+
+	node.Attr = append(node.Attr, html.Attribute{
+		Key: attrName,
+		Val: attrValue,
+	})
+}
+
+// RemoveAttribute removes attribute with given name.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/removeAttribute
+func RemoveAttribute(node *html.Node, attrName string) {
+	if node == nil {
+		return
+	}
+
+	for i := 0; i < len(node.Attr); i++ {
+		if node.Attr[i].Key == attrName {
+			node.Attr = append(node.Attr[:i], node.Attr[i+1:]...)
+			return
+		}
+	}
+}
+
+// HasAttribute returns a Boolean value indicating whether the specified node
+// has the specified attribute or not.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/hasAttribute
+func HasAttribute(node *html.Node, attrName string) bool {
+	_, ok := Attribute(node, attrName)
+	return ok
+}
+
+// OuterHTML returns an HTML serialization of the element and its descendants.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/outerHTML
+func OuterHTML(node *html.Node) string {
+	buffer := AcquireBuffer()
+	defer ReleaseBuffer(buffer)
+
+	if err := html.Render(buffer, node); err != nil {
+		return ""
+	}
+
+	return buffer.String()
+}
+
+// InnerHTML returns the HTML content (inner HTML) of an element.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/innerHTML
+func InnerHTML(node *html.Node) string {
+	buffer := AcquireBuffer()
+	defer ReleaseBuffer(buffer)
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if err := html.Render(buffer, child); err != nil {
+			return ""
+		}
+	}
+
+	return string(bytes.TrimSpace(buffer.Bytes()))
+}
+
+// ReplaceNode replaces a child node within the given (parent) node.
+//
+// The new node is moved from its current position, which can be inside of the
+// old node. Replacing a node with one of its ancestors would create a cycle,
+// and is ignored, as well as replacing a detached node.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/replaceChild
+func ReplaceNode(oldNode *html.Node, newNode *html.Node) {
+	if oldNode == nil || newNode == nil || oldNode.Parent == nil || Contains(newNode, oldNode) {
+		return
+	}
+
+	if newNode.Parent != nil {
+		newNode.Parent.RemoveChild(newNode)
+	}
+
+	parent := oldNode.Parent
+	parent.InsertBefore(newNode, oldNode)
+	parent.RemoveChild(oldNode)
+}
+
+// Contains returns true if other is node or one of its descendants.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/contains
+func Contains(node *html.Node, other *html.Node) bool {
+	if node == nil {
+		return false
+	}
+
+	for ; other != nil; other = other.Parent {
+		if other == node {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnwrapNode replaces node with its own children.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/replaceWith
+func UnwrapNode(node *html.Node) {
+	if node.Parent == nil {
+		return
+	}
+
+	for node.FirstChild != nil {
+		child := node.FirstChild
+		node.RemoveChild(child)
+		node.Parent.InsertBefore(child, node)
+	}
+
+	node.Parent.RemoveChild(node)
+}
+
+// TagName returns the tag name of the element on which it’s called.
+//
+// For example, if the element is an <img>, its tagName property is “IMG” (for
+// HTML documents; it may be cased differently for XML/XHTML documents).
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/tagName
+func TagName(node *html.Node) string {
+	if node == nil || node.Type != html.ElementNode {
+		return ""
+	}
+
+	return node.Data
+}
+
+// TextContent returns text content of a Node and its descendants.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Node/textContent
+func TextContent(node *html.Node) string {
+	if node == nil {
+		return ""
+	}
+
+	// Most elements scored by the parser contain a single text node, its
+	// text can be returned without copying it.
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+
+	if child := node.FirstChild; child != nil && child.NextSibling == nil && child.Type == html.TextNode {
+		return child.Data
+	}
+
+	buffer := AcquireBuffer()
+	defer ReleaseBuffer(buffer)
+
+	WriteTextContent(buffer, node)
+
+	return buffer.String()
+}
+
+// WriteTextContent writes the text content of a Node and its descendants to
+// the buffer, in a single pass over the tree.
+func WriteTextContent(buffer *bytes.Buffer, node *html.Node) {
+	if node == nil {
+		return
+	}
+
+	if node.Type == html.TextNode {
+		buffer.WriteString(node.Data)
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		WriteTextContent(buffer, child)
+	}
+}
+
+// ClosestAncestor returns the nearest ancestor of the node with the given tag
+// name, or nil if there is none.
+//
+// See: https://developer.mozilla.org/en-US/docs/Web/API/Element/closest
+func ClosestAncestor(node *html.Node, tag string) *html.Node {
+	if node == nil {
+		return nil
+	}
+
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if TagName(parent) == tag {
+			return parent
+		}
+	}
+
+	return nil
+}
+
+// rxScheme matches the scheme of an absolute URL, like "https:", "mailto:",
+// "tel:" or "data:".
+var rxScheme = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.\-]*:`)
+
+// uriCleaner removes the tabs and newlines that browsers ignore in URLs.
+var uriCleaner = strings.NewReplacer("\t", "", "\n", "", "\r", "")
+
+// ToAbsoluteURI converts uri to an absolute URL resolved against base:
+//
+//   - Fragments, like "#top", are returned as is, so they keep pointing to
+//     the current document.
+//   - URLs with a scheme, like "https:", "mailto:", "tel:" or "data:", are
+//     returned as is, they are already absolute and some of them would be
+//     mangled by a round trip through the URL parser.
+//   - Everything else, including protocol-relative URLs like "//cdn.com/x",
+//     and query-only URLs like "?page=2", is resolved against base.
+//
+// The surrounding whitespace, and the tabs and newlines inside the URL, are
+// ignored like browsers do. Invalid URLs are returned as is, and an empty
+// string is returned if uri is empty or there is no base.
+func ToAbsoluteURI(uri string, base *url.URL) string {
+	uri = strings.TrimSpace(uriCleaner.Replace(uri))
+
+	if uri == "" || base == nil {
+		return ""
+	}
+
+	if strings.HasPrefix(uri, "#") || rxScheme.MatchString(uri) {
+		return uri
+	}
+
+	ref, err := url.Parse(uri)
+
+	if err != nil {
+		return uri
+	}
+
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,279 @@
+package dom
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func parse(t *testing.T, input string) *html.Node {
+	doc, err := html.Parse(strings.NewReader(input))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	return doc
+}
+
+func body(t *testing.T, input string) *html.Node {
+	return GetElementsByTagName(parse(t, input), "body")[0]
+}
+
+func TestTraversal(t *testing.T) {
+	node := body(t, `text<p id="a">lorem</p> <!-- note --> <p id="b">ipsum</p>`)
+
+	first := FirstElementChild(node)
+	second := NextElementSibling(first)
+
+	if GetAttribute(first, "id") != "a" || GetAttribute(second, "id") != "b" {
+		t.Fatalf("unexpected elements: %s, %s", OuterHTML(first), OuterHTML(second))
+	}
+
+	if NextElementSibling(second) != nil || FirstElementChild(second.FirstChild) != nil {
+		t.Fatalf("expecting no more elements")
+	}
+
+	if len(ChildNodes(node)) != 6 || len(Children(node)) != 2 {
+		t.Fatalf("unexpected children: %d nodes, %d elements", len(ChildNodes(node)), len(Children(node)))
+	}
+
+	if ClosestAncestor(first.FirstChild, "body") != node || ClosestAncestor(first, "table") != nil {
+		t.Fatalf("unexpected ancestors")
+	}
+
+	if len(GetElementsByTagName(node, "p")) != 2 || len(GetElementsByTagName(node, "*")) != 3 {
+		t.Fatalf("unexpected elements by tag name")
+	}
+}
+
+func TestNilNodes(t *testing.T) {
+	if FirstElementChild(nil) != nil || NextElementSibling(nil) != nil ||
+		ChildNodes(nil) != nil || Children(nil) != nil ||
+		GetElementsByTagName(nil, "*") != nil || ClosestAncestor(nil, "p") != nil {
+		t.Fatalf("expecting no nodes")
+	}
+
+	if GetAttribute(nil, "id") != "" || HasAttribute(nil, "id") || TagName(nil) != "" || TextContent(nil) != "" {
+		t.Fatalf("expecting empty values")
+	}
+
+	RemoveAttribute(nil, "id")
+}
+
+func TestAttributes(t *testing.T) {
+	node := CreateElement("div")
+
+	SetAttribute(node, "id", "a")
+	SetAttribute(node, "class", "b")
+	SetAttribute(node, "id", "c")
+
+	if value, ok := Attribute(node, "id"); !ok || value != "c" || len(node.Attr) != 2 {
+		t.Fatalf("unexpected attributes: %#v", node.Attr)
+	}
+
+	if _, ok := Attribute(node, "title"); ok || HasAttribute(node, "title") || !HasAttribute(node, "class") {
+		t.Fatalf("unexpected attribute lookup: %#v", node.Attr)
+	}
+
+	RemoveAttribute(node, "id")
+	RemoveAttribute(node, "title")
+
+	if HasAttribute(node, "id") || GetAttribute(node, "class") != "b" {
+		t.Fatalf("unexpected attributes after removal: %#v", node.Attr)
+	}
+}
+
+func TestMutations(t *testing.T) {
+	node := body(t, `<div><p>lorem <b>ipsum</b></p></div><span>dolor</span>`)
+	div, span := Children(node)[0], Children(node)[1]
+	p := FirstElementChild(div)
+
+	clone := CloneNode(div)
+
+	if OuterHTML(clone) != OuterHTML(div) || FirstElementChild(clone) == p {
+		t.Fatalf("unexpected clone: %s", OuterHTML(clone))
+	}
+
+	AppendChild(span, p)
+
+	if p.Parent != span || FirstElementChild(div) != nil {
+		t.Fatalf("the node was not moved: %s", OuterHTML(node))
+	}
+
+	text := CreateTextNode("sit")
+	AppendChild(div, text)
+
+	if TextContent(div) != "sit" || InnerHTML(span) != "dolor<p>lorem <b>ipsum</b></p>" {
+		t.Fatalf("unexpected content: %s", OuterHTML(node))
+	}
+
+	UnwrapNode(p)
+
+	if InnerHTML(span) != "dolorlorem <b>ipsum</b>" {
+		t.Fatalf("the node was not unwrapped: %s", OuterHTML(span))
+	}
+
+	em := CreateElement("em")
+	ReplaceNode(span, em)
+
+	if span.Parent != nil || TagName(Children(node)[1]) != "em" {
+		t.Fatalf("the node was not replaced: %s", OuterHTML(node))
+	}
+
+	// Detached nodes are left alone.
+	ReplaceNode(span, CreateElement("i"))
+	UnwrapNode(span)
+
+	if TagName(span) != "span" || TagName(text) != "" {
+		t.Fatalf("unexpected tag names")
+	}
+}
+
+func TestMutationCycles(t *testing.T) {
+	node := body(t, `<div> <p>lorem <b>ipsum</b></p> </div>`)
+	div := FirstElementChild(node)
+	p := FirstElementChild(div)
+	b := FirstElementChild(p)
+
+	// Moving a node into itself or into one of its descendants is ignored.
+	AppendChild(b, p)
+	AppendChild(p, p)
+	ReplaceNode(b, div)
+
+	if err := Validate(node); err != nil {
+		t.Fatalf("invalid tree: %s", err)
+	}
+
+	if p.Parent != div || b.Parent != p {
+		t.Fatalf("the nodes should not be moved: %s", OuterHTML(node))
+	}
+
+	// The new node is detached from the old node before replacing it.
+	ReplaceNode(div, p)
+
+	if err := Validate(node); err != nil {
+		t.Fatalf("invalid tree: %s", err)
+	}
+
+	if err := Validate(div); err != nil {
+		t.Fatalf("invalid detached tree: %s", err)
+	}
+
+	if InnerHTML(node) != "<p>lorem <b>ipsum</b></p>" || InnerHTML(div) != "" {
+		t.Fatalf("the node was not replaced: %s", OuterHTML(node))
+	}
+
+	if !Contains(node, b) || Contains(b, node) || !Contains(p, p) || Contains(nil, p) {
+		t.Fatalf("unexpected containment")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	node := body(t, `<p>lorem</p><p>ipsum</p><p>dolor</p>`)
+	first, second := Children(node)[0], Children(node)[1]
+
+	if err := Validate(node); err != nil {
+		t.Fatalf("invalid tree: %s", err)
+	}
+
+	second.Parent = first
+
+	if err := Validate(node); err == nil || !strings.Contains(err.Error(), "points to <p>") {
+		t.Fatalf("expecting a wrong parent: %v", err)
+	}
+
+	second.Parent = node
+	second.NextSibling = first
+
+	if err := Validate(node); err == nil || !strings.Contains(err.Error(), "reachable twice") {
+		t.Fatalf("expecting a cycle: %v", err)
+	}
+}
+
+func TestToAbsoluteURI(t *testing.T) {
+	base, _ := url.Parse("https://cixtor.com/blog/post")
+
+	tests := []struct {
+		uri      string
+		base     *url.URL
+		expected string
+	}{
+		{"", base, ""},
+		{"  ", base, ""},
+		{"/about", nil, ""},
+		{"#top", base, "#top"},
+		{"#", base, "#"},
+		{" #top ", base, "#top"},
+		{"https://example.com/a", base, "https://example.com/a"},
+		{"HTTP://EXAMPLE.COM/A", base, "HTTP://EXAMPLE.COM/A"},
+		{"/about", base, "https://cixtor.com/about"},
+		{"next", base, "https://cixtor.com/blog/next"},
+		{"../up", base, "https://cixtor.com/up"},
+		{"//cdn.example.com/x.png", base, "https://cdn.example.com/x.png"},
+		{"?page=2", base, "https://cixtor.com/blog/post?page=2"},
+		{"\n  /ab\tout\n", base, "https://cixtor.com/about"},
+		{"＃top", base, "https://cixtor.com/blog/%EF%BC%83top"},
+		{"mailto:hello@cixtor.com?subject=Hi there", base, "mailto:hello@cixtor.com?subject=Hi there"},
+		{"tel:+1-555-0100", base, "tel:+1-555-0100"},
+		{"data:image/png;base64,iVBORw0KGgo=", base, "data:image/png;base64,iVBORw0KGgo="},
+		{"data:text/html,<p>a b</p>", base, "data:text/html,<p>a b</p>"},
+		{"%zz", base, "%zz"},
+	}
+
+	for _, test := range tests {
+		if uri := ToAbsoluteURI(test.uri, test.base); uri != test.expected {
+			t.Fatalf("ToAbsoluteURI(%q) = %q, expecting %q", test.uri, uri, test.expected)
+		}
+	}
+}
+
+func TestBufferPool(t *testing.T) {
+	buffer := AcquireBuffer()
+	buffer.WriteString("lorem ipsum")
+	ReleaseBuffer(buffer)
+
+	if buffer = AcquireBuffer(); buffer.Len() != 0 {
+		t.Fatalf("the buffer was not reset: %q", buffer.String())
+	}
+
+	ReleaseBuffer(buffer)
+
+	node := body(t, `<p>lorem <b>ipsum</b></p><p>dolor</p>`)
+
+	if text := TextContent(node); text != "lorem ipsumdolor" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+
+	if text := TextContent(node.FirstChild.FirstChild); text != "lorem " {
+		t.Fatalf("unexpected text of a text node: %q", text)
+	}
+}
+
+func BenchmarkSerialization(b *testing.B) {
+	var page strings.Builder
+
+	for i := 0; i < 100; i++ {
+		page.WriteString(`<div class="post"><p>Lorem <b>ipsum</b> dolor sit amet.</p><p>Consectetur <a href="#">adipiscing</a> elit.</p></div>`)
+	}
+
+	doc, err := html.Parse(strings.NewReader(page.String()))
+
+	if err != nil {
+		b.Fatalf("cannot parse document: %s", err)
+	}
+
+	nodes := GetElementsByTagName(doc, "div")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, node := range nodes {
+			TextContent(node)
+			InnerHTML(node)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package dom
+
+import (
+	"fmt"
+
+	"golang.org/x/net/html"
+)
+
+// Validate checks the links between the nodes of the tree rooted at node, and
+// returns an error describing the first inconsistency found: a node that is
+// reachable twice, which is a cycle or a node with two parents, a child that
+// points to another parent, or siblings that do not point to each other.
+// These states are not produced by the HTML parser, only by the mutations of
+// the tree, and make the traversals skip nodes or never end.
+func Validate(node *html.Node) error {
+	if node == nil {
+		return nil
+	}
+
+	visited := map[*html.Node]bool{node: true}
+	stack := []*html.Node{node}
+
+	for len(stack) > 0 {
+		parent := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		var prev *html.Node
+
+		for child := parent.FirstChild; child != nil; child = child.NextSibling {
+			if visited[child] {
+				return fmt.Errorf("%s is reachable twice, from %s", describe(child), describe(parent))
+			}
+
+			visited[child] = true
+
+			if child.Parent != parent {
+				return fmt.Errorf("%s is a child of %s but points to %s", describe(child), describe(parent), describe(child.Parent))
+			}
+
+			if child.PrevSibling != prev {
+				return fmt.Errorf("%s does not point to its previous sibling %s", describe(child), describe(prev))
+			}
+
+			prev = child
+			stack = append(stack, child)
+		}
+
+		if parent.LastChild != prev {
+			return fmt.Errorf("%s does not point to its last child %s", describe(parent), describe(prev))
+		}
+	}
+
+	return nil
+}
+
+// describe returns a short description of node for the error messages.
+func describe(node *html.Node) string {
+	if node == nil {
+		return "nothing"
+	}
+
+	switch node.Type {
+	case html.ElementNode:
+		return "<" + node.Data + ">"
+	case html.TextNode:
+		return "text node"
+	case html.DocumentNode:
+		return "document"
+	case html.CommentNode:
+		return "comment"
+	}
+
+	return "node"
+}
@@ -0,0 +1,52 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cixtor/readability/internal/dom"
+	"golang.org/x/net/html"
+)
+
+// ErrInvalidTree is returned when CheckInvariants is enabled and one of the
+// stages of the parser left the tree of the document in an invalid state.
+var ErrInvalidTree = errors.New("invalid document tree")
+
+// InvariantError is returned when CheckInvariants is enabled and the tree of
+// the document is invalid after one of the stages of the parser. It wraps
+// ErrInvalidTree.
+type InvariantError struct {
+	// Stage is the stage of the parser after which the tree was checked,
+	// like "prepare", "grab" or "post-process", as in Timings.
+	Stage string
+
+	// Reason describes the first inconsistency found in the tree.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("%s after %s: %s", ErrInvalidTree, e.Stage, e.Reason)
+}
+
+// Unwrap returns ErrInvalidTree, so errors.Is can be used to check the error.
+func (e *InvariantError) Unwrap() error {
+	return ErrInvalidTree
+}
+
+// checkInvariants returns an InvariantError if the links between the nodes
+// of any of the trees are inconsistent, which would make the traversals skip
+// nodes or never end. It does nothing unless CheckInvariants is enabled.
+func (r *Readability) checkInvariants(stage string, nodes ...*html.Node) error {
+	if !r.CheckInvariants {
+		return nil
+	}
+
+	for _, node := range nodes {
+		if err := dom.Validate(node); err != nil {
+			return &InvariantError{Stage: stage, Reason: err.Error()}
+		}
+	}
+
+	return nil
+}
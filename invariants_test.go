@@ -0,0 +1,45 @@
+package readability
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	input := `<html>
+		<head><title>hello world</title></head>
+		<body>
+			<div id="main">
+				<div> <p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore.</p> </div>
+				<div>Ut enim ad minim veniam<br><br>quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo.</div>
+				<div> <p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla.</p> </div>
+			</div>
+		</body>
+	</html>`
+
+	parser := New()
+	parser.CheckInvariants = true
+
+	res, err := parser.Analyze(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(res.Text(), "Duis aute irure") {
+		t.Fatalf("unexpected text: %q", res.Text())
+	}
+}
+
+func TestInvariantError(t *testing.T) {
+	err := error(&InvariantError{Stage: "grab", Reason: "<p> is reachable twice, from <div>"})
+
+	if !errors.Is(err, ErrInvalidTree) {
+		t.Fatalf("expecting the error to wrap ErrInvalidTree")
+	}
+
+	if err.Error() != "invalid document tree after grab: <p> is reachable twice, from <div>" {
+		t.Fatalf("unexpected message: %s", err)
+	}
+}
@@ -0,0 +1,252 @@
+package readability
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// LanguagePack holds the words and phrases used to clean the content of the
+// articles written in a language. Register new packs, or replace the built-in
+// ones, with RegisterLanguagePack.
+type LanguagePack struct {
+	// BylinePrefixes are the words that introduce the name of the author in
+	// a byline, like "By" in English or "Geschrieben von" in German. Leave
+	// out the particles that also start surnames, like "von" or "di".
+	BylinePrefixes []string
+
+	// BylineTokens are the words found in the class names and ids of the
+	// elements containing the byline, and in the names of the meta tags
+	// with the author, like "autor" in German or Spanish.
+	BylineTokens []string
+
+	// ReadMorePrefixes are the phrases that introduce links to other articles
+	// inside the content, like "Read more" in English.
+	ReadMorePrefixes []string
+
+	// NewsletterPatterns are the phrases used to promote newsletters, like
+	// "Sign up for our newsletter" in English.
+	NewsletterPatterns []string
+}
+
+// defaultLanguages is the list of languages whose phrases are used by default,
+// even when the Languages option is empty.
+var defaultLanguages = []string{"en", "es", "fr", "de", "pt", "it", "nl"}
+
+var languagePacksMu sync.RWMutex
+
+// languagePacks is the registry of language packs, by primary language subtag.
+var languagePacks = map[string]LanguagePack{
+	"en": {
+		BylinePrefixes: []string{"written by", "posted by", "by"},
+		ReadMorePrefixes: []string{
+			"read more", "read also", "also read", "read next", "related",
+			"see also", "more on this", "more from", "recommended",
+			"you may also like",
+		},
+		NewsletterPatterns: []string{
+			"sign up for our newsletter", "subscribe to our newsletter",
+			"sign up for the newsletter", "subscribe to the newsletter",
+			"get our newsletter", "join our newsletter", "join our mailing list",
+			"delivered to your inbox", "straight to your inbox",
+			"sign up to receive", "enter your email",
+		},
+	},
+	"es": {
+		BylinePrefixes: []string{"escrito por", "por"},
+		BylineTokens:   []string{"autor", "firma"},
+		ReadMorePrefixes: []string{
+			"lee también", "leer más", "lea también", "te puede interesar",
+			"relacionado", "relacionada", "noticias relacionadas", "ver también",
+		},
+		NewsletterPatterns: []string{
+			"suscríbete a nuestro boletín", "suscríbete a nuestra newsletter",
+			"recibe nuestro boletín",
+		},
+	},
+	"fr": {
+		BylinePrefixes: []string{"écrit par", "par"},
+		BylineTokens:   []string{"auteur", "signature"},
+		ReadMorePrefixes: []string{
+			"lire aussi", "à lire aussi", "voir aussi", "sur le même sujet",
+			"lire la suite",
+		},
+		NewsletterPatterns: []string{
+			"abonnez-vous à notre newsletter", "inscrivez-vous à notre newsletter",
+			"recevez notre newsletter",
+		},
+	},
+	"de": {
+		BylinePrefixes: []string{"geschrieben von"},
+		BylineTokens:   []string{"autor", "verfasser"},
+		ReadMorePrefixes: []string{
+			"lesen sie auch", "lesen sie mehr", "mehr zum thema", "siehe auch",
+			"auch interessant",
+		},
+		NewsletterPatterns: []string{
+			"newsletter abonnieren", "melden sie sich für unseren newsletter an",
+			"unseren newsletter",
+		},
+	},
+	"pt": {
+		BylinePrefixes:   []string{"escrito por", "por"},
+		BylineTokens:     []string{"autor", "assinatura"},
+		ReadMorePrefixes: []string{"leia também", "leia mais", "veja também", "saiba mais"},
+		NewsletterPatterns: []string{
+			"assine nossa newsletter", "inscreva-se na nossa newsletter",
+		},
+	},
+	"it": {
+		BylinePrefixes:   []string{"scritto da", "a cura di"},
+		BylineTokens:     []string{"autore", "firma"},
+		ReadMorePrefixes: []string{"leggi anche", "vedi anche", "potrebbe interessarti"},
+		NewsletterPatterns: []string{
+			"iscriviti alla newsletter", "iscriviti alla nostra newsletter",
+		},
+	},
+	"nl": {
+		BylinePrefixes:   []string{"geschreven door", "door"},
+		BylineTokens:     []string{"auteur", "schrijver"},
+		ReadMorePrefixes: []string{"lees ook", "lees meer", "zie ook"},
+		NewsletterPatterns: []string{
+			"schrijf je in voor onze nieuwsbrief", "meld je aan voor onze nieuwsbrief",
+		},
+	},
+	"ru": {
+		BylinePrefixes:   []string{"автор", "текст"},
+		BylineTokens:     []string{"avtor", "автор"},
+		ReadMorePrefixes: []string{"читайте также", "читать также", "смотрите также", "по теме"},
+		NewsletterPatterns: []string{
+			"подпишитесь на нашу рассылку", "подписаться на рассылку",
+		},
+	},
+	"ja": {
+		BylinePrefixes:   []string{"文", "著", "執筆"},
+		BylineTokens:     []string{"chosha", "shippitsu", "著者", "筆者", "執筆者"},
+		ReadMorePrefixes: []string{"関連記事", "あわせて読みたい", "こちらもおすすめ"},
+		NewsletterPatterns: []string{
+			"メールマガジンに登録", "ニュースレターに登録", "メルマガ登録",
+		},
+	},
+}
+
+// RegisterLanguagePack adds the language pack for the given language, as a BCP
+// 47 tag like "sv" or "pt-BR", replacing any pack registered before. Only the
+// primary language subtag is used. It is safe for concurrent use.
+func RegisterLanguagePack(lang string, pack LanguagePack) {
+	languagePacksMu.Lock()
+	defer languagePacksMu.Unlock()
+
+	languagePacks[primaryLanguage(lang)] = pack
+}
+
+// primaryLanguage returns the primary subtag of a language tag, for example
+// "pt" for "pt-BR".
+func primaryLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+
+	if idx := strings.IndexAny(lang, "-_"); idx != -1 {
+		lang = lang[:idx]
+	}
+
+	return lang
+}
+
+// languagePhrases returns the phrases selected by field from the language
+// packs of the given languages, without duplicates.
+func languagePhrases(langs []string, field func(LanguagePack) []string) []string {
+	var phrases []string
+
+	languagePacksMu.RLock()
+	defer languagePacksMu.RUnlock()
+
+	for _, lang := range langs {
+		pack, ok := languagePacks[primaryLanguage(lang)]
+
+		if !ok {
+			continue
+		}
+
+		for _, phrase := range field(pack) {
+			if indexOf(phrases, phrase) == -1 {
+				phrases = append(phrases, phrase)
+			}
+		}
+	}
+
+	return phrases
+}
+
+// languagePhrases returns the configured phrases extended with the phrases of
+// the language packs of the expected Languages.
+func (r *Readability) languagePhrases(configured []string, field func(LanguagePack) []string) []string {
+	if len(r.Languages) == 0 {
+		return configured
+	}
+
+	phrases := append([]string{}, configured...)
+
+	for _, phrase := range languagePhrases(r.Languages, field) {
+		if indexOf(phrases, phrase) == -1 {
+			phrases = append(phrases, phrase)
+		}
+	}
+
+	return phrases
+}
+
+// trimBylinePrefix removes the words that introduce the name of the author,
+// like "By" or "Von", using the language packs of the expected Languages.
+func (r *Readability) trimBylinePrefix(byline string) string {
+	lower := strings.ToLower(byline)
+
+	for _, prefix := range languagePhrases(r.Languages, func(pack LanguagePack) []string {
+		return pack.BylinePrefixes
+	}) {
+		if !strings.HasPrefix(lower, prefix) {
+			continue
+		}
+
+		rest := byline[len(prefix):]
+		trimmed := strings.TrimLeft(rest, ":：\x20")
+
+		// The prefix must be a separate word.
+		if trimmed != "" && len(trimmed) < len(rest) {
+			return trimmed
+		}
+	}
+
+	return byline
+}
+
+// bylineTokens returns the byline tokens of the expected Languages. Unlike
+// the other phrases, the tokens of the default languages are not used unless
+// they are expected, short words like "firma" are too common in class names.
+func (r *Readability) bylineTokens() []string {
+	return languagePhrases(r.Languages, func(pack LanguagePack) []string {
+		return pack.BylineTokens
+	})
+}
+
+// isLocalizedByline returns true if matchString, the class names and id of an
+// element or the name of a meta tag, contains one of the byline tokens as a
+// whole word. Words are split by the characters that are neither letters nor
+// digits, so "article-autor" contains "autor" but "confirmation" does not
+// contain "firma".
+func (r *Readability) isLocalizedByline(matchString string) bool {
+	if len(r.localizedBylineTokens) == 0 {
+		return false
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(matchString), func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+	})
+
+	for _, word := range words {
+		if indexOf(r.localizedBylineTokens, word) != -1 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLanguages(t *testing.T) {
+	page := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p class="byline">Автор: Иван Петров</p>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+				<p>Читайте также: <a href="/other">Другая статья</a></p>
+				<p>Duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore.</p>
+			</article>
+		</body>
+		</html>`
+
+	a, err := New().Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Byline != "Автор: Иван Петров" || !strings.Contains(a.Content, "Другая статья") {
+		t.Fatalf("russian phrases should be ignored by default: %q %s", a.Byline, a.Content)
+	}
+
+	parser := New()
+	parser.Languages = []string{"ru-RU"}
+	a, err = parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Byline != "Иван Петров" || strings.Contains(a.Content, "Другая статья") {
+		t.Fatalf("russian phrases should be used: %q %s", a.Byline, a.Content)
+	}
+}
+
+func TestLocalizedBylines(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<div class="artikel-verfasser">Hans Müller</div>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+			</article>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.Languages = []string{"de"}
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Byline != "Hans Müller" {
+		t.Fatalf("german byline was not found: %q", a.Byline)
+	}
+
+	input = strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+			<meta name="著者" content="山田太郎">
+		</head>
+		<body>
+			<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+		</body>
+		</html>`)
+
+	parser = New()
+	parser.Languages = []string{"ja"}
+	a, err = parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Byline != "山田太郎" {
+		t.Fatalf("japanese byline was not found: %q", a.Byline)
+	}
+}
+
+func TestLocalizedBylineWords(t *testing.T) {
+	parser := New()
+
+	if parser.localizedBylineTokens = parser.bylineTokens(); parser.isLocalizedByline("byline-firma") {
+		t.Fatalf("byline tokens should only be used for the expected languages")
+	}
+
+	parser.Languages = []string{"es"}
+	parser.localizedBylineTokens = parser.bylineTokens()
+
+	tests := map[string]bool{
+		"byline-firma":         true,
+		"article_autor main":   true,
+		"confirmation":         false,
+		"autores-relacionados": false,
+	}
+
+	for input, expected := range tests {
+		if result := parser.isLocalizedByline(input); result != expected {
+			t.Fatalf("isLocalizedByline(%q) = %t, expecting %t", input, result, expected)
+		}
+	}
+
+	input := `<html><head><title>hello world</title></head><body><article>` +
+		`<p class="confirmation">Gracias, su suscripción fue confirmada.</p>` +
+		`<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>` +
+		`</article></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Byline != "" || !strings.Contains(a.TextContent, "Gracias") {
+		t.Fatalf("the confirmation was taken as the byline: %q", a.Byline)
+	}
+}
+
+func TestTrimBylinePrefix(t *testing.T) {
+	tests := map[string]string{
+		"By Jane Doe":                 "Jane Doe",
+		"Geschrieben von Hans Müller": "Hans Müller",
+		"Scritto da Mario Rossi":      "Mario Rossi",
+		"A cura di Mario Rossi":       "Mario Rossi",
+		"Di Maio Luigi":               "Di Maio Luigi",
+		"Von der Leyen Ursula":        "Von der Leyen Ursula",
+		"Byrne Jane":                  "Byrne Jane",
+	}
+
+	parser := New()
+	parser.Languages = []string{"en", "de", "it"}
+
+	for byline, expected := range tests {
+		if result := parser.trimBylinePrefix(byline); result != expected {
+			t.Fatalf("trimBylinePrefix(%q) = %q, expecting %q", byline, result, expected)
+		}
+	}
+}
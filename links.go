@@ -0,0 +1,91 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// relLinkTypes are the link types exposed in the RelLinks of the article.
+var relLinkTypes = []string{"prev", "next", "alternate"}
+
+// RelLink is a <link> element of the document pointing to a related page, like
+// the previous or the next page of a paginated article, a translation, or a
+// feed.
+type RelLink struct {
+	// Rel is the link type: "prev", "next" or "alternate".
+	Rel string
+
+	// URL is the absolute address of the related page.
+	URL string
+
+	// Type is the media type of the related page, if declared, like
+	// "application/rss+xml" for feeds.
+	Type string
+
+	// HrefLang is the language of the related page, if declared, like "de"
+	// for the German translation of the article.
+	HrefLang string
+
+	// Title is the title of the related page, if declared.
+	Title string
+}
+
+// getRelLinks returns the links of the document pointing to the previous and
+// the next pages, to the translations, and to the alternate versions of the
+// page, in the same order found in the document.
+func (r *Readability) getRelLinks(doc *html.Node) []RelLink {
+	var links []RelLink
+
+	for _, link := range getElementsByTagName(doc, "link") {
+		href := toAbsoluteURI(getAttribute(link, "href"), r.documentURI)
+
+		if href == "" {
+			continue
+		}
+
+		for _, rel := range strings.Fields(strings.ToLower(getAttribute(link, "rel"))) {
+			if rel == "previous" {
+				rel = "prev"
+			}
+
+			if indexOf(relLinkTypes, rel) == -1 {
+				continue
+			}
+
+			links = append(links, RelLink{
+				Rel:      rel,
+				URL:      href,
+				Type:     strings.TrimSpace(getAttribute(link, "type")),
+				HrefLang: strings.TrimSpace(getAttribute(link, "hreflang")),
+				Title:    strings.TrimSpace(getAttribute(link, "title")),
+			})
+		}
+	}
+
+	return links
+}
+
+// translations returns the URLs of the translations of the page, declared by
+// the alternate links with a hreflang attribute, by language. The page used
+// when no translation matches the language of the reader is under the
+// "x-default" key. It returns nil if the page declares no translations.
+func translations(links []RelLink) map[string]string {
+	var urls map[string]string
+
+	for _, link := range links {
+		if link.Rel != "alternate" || link.HrefLang == "" {
+			continue
+		}
+
+		if urls == nil {
+			urls = map[string]string{}
+		}
+
+		if _, ok := urls[link.HrefLang]; !ok {
+			urls[link.HrefLang] = link.URL
+		}
+	}
+
+	return urls
+}
@@ -0,0 +1,64 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRelLinks(t *testing.T) {
+	input := `<html><head>
+		<title>Page 2</title>
+		<link rel="stylesheet" href="/style.css">
+		<link rel="previous" href="/article?page=1">
+		<link rel="next" href="/article?page=3">
+		<link rel="alternate" hreflang="de" href="https://cixtor.de/artikel?page=2">
+		<link rel="alternate" type="application/rss+xml" title="Feed" href="/feed.xml">
+		<link rel="alternate" href="">
+	</head><body><p>lorem ipsum</p></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/article?page=2")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []RelLink{
+		{Rel: "prev", URL: "https://cixtor.com/article?page=1"},
+		{Rel: "next", URL: "https://cixtor.com/article?page=3"},
+		{Rel: "alternate", URL: "https://cixtor.de/artikel?page=2", HrefLang: "de"},
+		{Rel: "alternate", URL: "https://cixtor.com/feed.xml", Type: "application/rss+xml", Title: "Feed"},
+	}
+
+	if !reflect.DeepEqual(a.RelLinks, expected) {
+		t.Fatalf("unexpected links:\n%#v\n%#v", a.RelLinks, expected)
+	}
+
+	if !reflect.DeepEqual(a.Translations, map[string]string{"de": "https://cixtor.de/artikel?page=2"}) {
+		t.Fatalf("unexpected translations: %#v", a.Translations)
+	}
+}
+
+func TestTranslations(t *testing.T) {
+	links := []RelLink{
+		{Rel: "alternate", URL: "https://cixtor.com/en", HrefLang: "en"},
+		{Rel: "alternate", URL: "https://cixtor.com/es", HrefLang: "es"},
+		{Rel: "alternate", URL: "https://cixtor.com/es-duplicate", HrefLang: "es"},
+		{Rel: "alternate", URL: "https://cixtor.com/", HrefLang: "x-default"},
+		{Rel: "next", URL: "https://cixtor.com/en?page=2", HrefLang: "en"},
+	}
+
+	expected := map[string]string{
+		"en":        "https://cixtor.com/en",
+		"es":        "https://cixtor.com/es",
+		"x-default": "https://cixtor.com/",
+	}
+
+	if urls := translations(links); !reflect.DeepEqual(urls, expected) {
+		t.Fatalf("unexpected translations: %#v", urls)
+	}
+
+	if urls := translations(nil); urls != nil {
+		t.Fatalf("expecting no translations: %#v", urls)
+	}
+}
@@ -0,0 +1,110 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxBullet matches the bullet at the beginning of the paragraphs used as the
+// items of a list. Hyphens and asterisks must be followed by a space.
+var rxBullet = regexp.MustCompile(`^\s*(?:[•·▪▫◦‣⁃●○■□►▸]\s*|[-*–]\s+)`)
+
+// listElems are the elements that can contain <li> elements.
+var listElems = []string{"ul", "ol", "menu"}
+
+// normalizeLists wraps the <li> elements found outside of a list in an <ul>
+// element and, if ConvertBulletParagraphs is enabled, converts the runs of
+// paragraphs starting with a bullet into lists.
+func (r *Readability) normalizeLists(articleContent *html.Node) {
+	for _, item := range getElementsByTagName(articleContent, "li") {
+		if item.Parent == nil || indexOf(listElems, tagName(item.Parent)) != -1 {
+			continue
+		}
+
+		wrapSiblings(item, "ul", func(node *html.Node) bool {
+			return tagName(node) == "li"
+		})
+	}
+
+	if !r.ConvertBulletParagraphs {
+		return
+	}
+
+	for _, p := range getElementsByTagName(articleContent, "p") {
+		if p.Parent == nil || tagName(p.Parent) == "li" || !isBulletParagraph(p) {
+			continue
+		}
+
+		if next := nextElementSibling(p); next == nil || !isBulletParagraph(next) {
+			continue
+		}
+
+		list := wrapSiblings(p, "ul", isBulletParagraph)
+
+		for _, item := range children(list) {
+			removeBullet(item)
+			r.setNodeTag(item, "li")
+		}
+	}
+}
+
+// wrapSiblings moves node, and the siblings that follow it and match the given
+// filter, into a new element inserted at the position of node. Whitespace
+// between the siblings is moved too.
+func wrapSiblings(node *html.Node, tag string, filter func(*html.Node) bool) *html.Node {
+	wrapper := createElement(tag)
+	node.Parent.InsertBefore(wrapper, node)
+
+	for next := node; next != nil; {
+		if next.Type == html.TextNode && strings.TrimSpace(next.Data) == "" {
+			next = next.NextSibling
+			continue
+		}
+
+		if next.Type != html.ElementNode || !filter(next) {
+			break
+		}
+
+		// Keep the whitespace between the items.
+		for wrapper.NextSibling != next {
+			sibling := wrapper.NextSibling
+			sibling.Parent.RemoveChild(sibling)
+			wrapper.AppendChild(sibling)
+		}
+
+		following := next.NextSibling
+		next.Parent.RemoveChild(next)
+		wrapper.AppendChild(next)
+		next = following
+	}
+
+	return wrapper
+}
+
+// isBulletParagraph returns true if node is a paragraph starting with a bullet.
+func isBulletParagraph(node *html.Node) bool {
+	return tagName(node) == "p" && rxBullet.MatchString(textContent(node))
+}
+
+// removeBullet removes the bullet from the first text node with content.
+func removeBullet(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			if strings.TrimSpace(textContent(child)) == "" {
+				continue
+			}
+
+			removeBullet(child)
+			return
+		}
+
+		if child.Type != html.TextNode || strings.TrimSpace(child.Data) == "" {
+			continue
+		}
+
+		child.Data = rxBullet.ReplaceAllString(child.Data, "")
+		return
+	}
+}
@@ -0,0 +1,52 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLists(t *testing.T) {
+	input := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<div><li>first orphan</li><li>second orphan</li></div>
+				<p>• first <b>bullet</b></p>
+				<p>• second bullet</p>
+				<p>- third bullet</p>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+			</article>
+		</body>
+		</html>`
+
+	parser := New()
+	a, err := parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, "<ul><li>first orphan</li><li>second orphan</li></ul>") {
+		t.Fatalf("orphan list items were not wrapped:\n%s", a.Content)
+	}
+
+	if strings.Contains(a.Content, "<li>first <b>bullet</b></li>") {
+		t.Fatalf("bullet paragraphs converted without the option:\n%s", a.Content)
+	}
+
+	parser.ConvertBulletParagraphs = true
+	a, err = parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, "<li>first <b>bullet</b></li>") ||
+		!strings.Contains(a.Content, "<li>second bullet</li>") ||
+		!strings.Contains(a.Content, "<li>third bullet</li>") {
+		t.Fatalf("bullet paragraphs were not converted:\n%s", a.Content)
+	}
+}
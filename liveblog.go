@@ -0,0 +1,148 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var rxLiveUpdate = regexp.MustCompile(`(?i)live-?blog|live-?(update|post|entry|item|event)|timeline-?(item|entry|event)|update-?(item|entry|block)`)
+var rxTimestamp = regexp.MustCompile(`(?i)(^|[\s_-])(time|timestamp|date|datetime|dateline|pubdate|published)([\s_-]|$)`)
+
+// liveUpdateAttr marks the updates of a live blog while they are processed. Its
+// value is the timestamp of the update, which is found before the class names
+// that identify it are removed.
+const liveUpdateAttr = "data-readability-update"
+
+// LiveUpdate is one of the timestamped entries of a live blog.
+type LiveUpdate struct {
+	// Time is the timestamp of the update, as written in the document. The
+	// machine-readable datetime attribute of the <time> element is preferred.
+	Time string
+
+	// HTML is the content of the update with HTML tags.
+	HTML string
+}
+
+// markLiveUpdates finds the updates of a live blog and marks them to protect
+// them from conditional cleaning, which usually removes most of them because
+// each update is short and contains links, embeds and images. The document is
+// considered a live blog only if it contains two or more updates.
+func (r *Readability) markLiveUpdates(doc *html.Node) {
+	var candidates []*html.Node
+
+	for _, node := range getElementsByTagName(doc, "*") {
+		if isLiveUpdate(node) {
+			candidates = append(candidates, node)
+		}
+	}
+
+	// The container of the updates often matches the same class names, keep
+	// only the innermost candidates.
+	var updates []*html.Node
+
+	for _, candidate := range candidates {
+		innermost := true
+
+		for _, other := range candidates {
+			if other != candidate && isDescendant(other, candidate) {
+				innermost = false
+				break
+			}
+		}
+
+		if innermost {
+			updates = append(updates, candidate)
+		}
+	}
+
+	if len(updates) < 2 {
+		return
+	}
+
+	for _, update := range updates {
+		setAttribute(update, liveUpdateAttr, liveUpdateTime(update))
+	}
+}
+
+// isLiveUpdate returns true if node looks like a timestamped live blog update.
+func isLiveUpdate(node *html.Node) bool {
+	if getAttribute(node, "itemprop") == "liveBlogUpdate" {
+		return true
+	}
+
+	if !rxLiveUpdate.MatchString(limitedMatchString(node, DefaultMaxMatchStringLength)) {
+		return false
+	}
+
+	return liveUpdateTimestamp(node) != nil
+}
+
+// liveUpdateTimestamp returns the element with the timestamp of the update.
+func liveUpdateTimestamp(update *html.Node) *html.Node {
+	if nodes := getElementsByTagName(update, "time"); len(nodes) > 0 {
+		return nodes[0]
+	}
+
+	for _, node := range getElementsByTagName(update, "*") {
+		if node != update && rxTimestamp.MatchString(limitedClassName(node, DefaultMaxMatchStringLength)) {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// liveUpdateTime returns the timestamp of the update. The machine-readable
+// datetime attribute of the <time> element is preferred over its text.
+func liveUpdateTime(update *html.Node) string {
+	elem := liveUpdateTimestamp(update)
+
+	if elem == nil {
+		return ""
+	}
+
+	if datetime := getAttribute(elem, "datetime"); datetime != "" {
+		return datetime
+	}
+
+	return strings.Join(strings.Fields(textContent(elem)), "\x20")
+}
+
+// isProtectedLiveUpdate returns true if node is, contains or is inside one of
+// the live blog updates marked by markLiveUpdates.
+func (r *Readability) isProtectedLiveUpdate(node *html.Node) bool {
+	for parent := node; parent != nil; parent = parent.Parent {
+		if hasAttribute(parent, liveUpdateAttr) {
+			return true
+		}
+	}
+
+	for _, elem := range getElementsByTagName(node, "*") {
+		if hasAttribute(elem, liveUpdateAttr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractLiveUpdates returns the updates of the live blog found in the article
+// content, in document order, and removes the marks set by markLiveUpdates.
+func (r *Readability) extractLiveUpdates(articleContent *html.Node) []LiveUpdate {
+	var updates []LiveUpdate
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		if !hasAttribute(node, liveUpdateAttr) {
+			continue
+		}
+
+		timestamp := getAttribute(node, liveUpdateAttr)
+		removeAttribute(node, liveUpdateAttr)
+
+		updates = append(updates, LiveUpdate{Time: timestamp, HTML: innerHTML(node)})
+	}
+
+	return updates
+}
@@ -0,0 +1,74 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLiveUpdates(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>Election night live</title>
+		</head>
+		<body>
+			<div class="live-updates">
+				<div class="live-update">
+					<time datetime="2024-11-05T22:10:00Z">22:10</time>
+					<p>Polls closed in <a href="/ohio">Ohio</a>.</p>
+					<img src="/ohio.jpg"><img src="/ohio-2.jpg">
+				</div>
+				<div class="live-update">
+					<span class="timestamp">21:45</span>
+					<p>Turnout was <a href="/turnout">high</a>.</p>
+					<img src="/queue.jpg"><img src="/queue-2.jpg">
+				</div>
+				<div class="live-update">
+					<time datetime="2024-11-05T21:30:00Z">21:30</time>
+					<p>First results are <a href="/results">coming</a>.</p>
+					<img src="/map.jpg"><img src="/map-2.jpg">
+				</div>
+			</div>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/live")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(a.Updates) != 3 {
+		t.Fatalf("expecting three updates: %#v", a.Updates)
+	}
+
+	if a.Updates[0].Time != "2024-11-05T22:10:00Z" || a.Updates[1].Time != "21:45" {
+		t.Fatalf("unexpected timestamps: %q, %q", a.Updates[0].Time, a.Updates[1].Time)
+	}
+
+	if !strings.Contains(a.Updates[2].HTML, "First results are") {
+		t.Fatalf("unexpected update content: %s", a.Updates[2].HTML)
+	}
+
+	if strings.Contains(a.Content, liveUpdateAttr) {
+		t.Fatalf("update marks were not removed: %s", a.Content)
+	}
+}
+
+func TestTimestampClassWords(t *testing.T) {
+	tests := map[string]bool{
+		"timestamp":         true,
+		"entry-date":        true,
+		"post_published at": true,
+		"update-time":       true,
+		"update":            false,
+		"validated":         false,
+		"runtime-stats":     false,
+		"sometimes":         false,
+	}
+
+	for input, expected := range tests {
+		if result := rxTimestamp.MatchString(input); result != expected {
+			t.Fatalf("rxTimestamp(%q) = %t, expecting %t", input, result, expected)
+		}
+	}
+}
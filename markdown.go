@@ -0,0 +1,365 @@
+package readability
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var rxMarkdownBlankLines = regexp.MustCompile(`\n{3,}`)
+
+// rxMarkdownBlockMarker matches the text that would be parsed as a heading, a
+// blockquote, or a list item at the start of a Markdown block.
+var rxMarkdownBlockMarker = regexp.MustCompile(`^\s*(?:([#>+-])|\d{1,9}([.)])(?:\s|$))`)
+
+// markdownEscaper escapes the characters with a special meaning in Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+)
+
+// markdownURLEscaper percent-encodes the characters that end or break the
+// destination of a Markdown link.
+var markdownURLEscaper = strings.NewReplacer(
+	" ", "%20",
+	"\t", "%09",
+	"\n", "%0A",
+	"\r", "%0D",
+	"(", "%28",
+	")", "%29",
+	"<", "%3C",
+	">", "%3E",
+)
+
+// renderMarkdown converts the children of node into Markdown.
+func renderMarkdown(node *html.Node) string {
+	var markdown strings.Builder
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		markdown.WriteString(markdownNode(child))
+	}
+
+	return strings.TrimSpace(rxMarkdownBlankLines.ReplaceAllString(markdown.String(), "\n\n"))
+}
+
+// markdownChildren converts the children of node into Markdown.
+func markdownChildren(node *html.Node) string {
+	var markdown strings.Builder
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		markdown.WriteString(markdownNode(child))
+	}
+
+	return markdown.String()
+}
+
+// markdownBlock formats text as a standalone Markdown block.
+func markdownBlock(text string) string {
+	lines := strings.Split(text, "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	text = strings.TrimSpace(strings.Join(lines, "\n"))
+	text = rxMarkdownBlankLines.ReplaceAllString(text, "\n\n")
+
+	if text == "" {
+		return ""
+	}
+
+	return "\n\n" + text + "\n\n"
+}
+
+// markdownInline wraps text with the given delimiter, leaving the surrounding
+// whitespace outside of the delimiters.
+func markdownInline(text string, delimiter string) string {
+	trimmed := strings.TrimSpace(text)
+
+	if trimmed == "" {
+		return text
+	}
+
+	prefix := text[:strings.Index(text, trimmed)]
+	suffix := text[len(prefix)+len(trimmed):]
+
+	return prefix + delimiter + trimmed + delimiter + suffix
+}
+
+// markdownPrefixLines adds prefix to every line of text, using indent for all
+// the lines after the first one when indent is not empty.
+func markdownPrefixLines(text string, prefix string, indent string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	for i, line := range lines {
+		switch {
+		case i == 0:
+			lines[i] = prefix + line
+		case indent != "" && line == "":
+			lines[i] = ""
+		case indent != "":
+			lines[i] = indent + line
+		default:
+			lines[i] = strings.TrimRight(prefix+line, " ")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// markdownNode converts a single node, and its descendants, into Markdown.
+func markdownNode(node *html.Node) string {
+	if node.Type == html.TextNode {
+		text := markdownEscaper.Replace(collapseWhitespace([]byte(node.Data), 1))
+		if markdownLineStart(node) {
+			text = markdownEscapeBlockMarker(text)
+		}
+		return text
+	}
+
+	if node.Type != html.ElementNode {
+		return ""
+	}
+
+	switch tag := tagName(node); tag {
+	case "script", "style", "noscript", "template":
+		return ""
+
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(tag[1:])
+		heading := strings.Join(strings.Fields(markdownChildren(node)), " ")
+		if heading == "" {
+			return ""
+		}
+		return "\n\n" + strings.Repeat("#", level) + " " + heading + "\n\n"
+
+	case "br":
+		return "\\\n"
+
+	case "hr":
+		return "\n\n---\n\n"
+
+	case "em", "i", "cite", "dfn":
+		return markdownInline(markdownChildren(node), "_")
+
+	case "strong", "b":
+		return markdownInline(markdownChildren(node), "**")
+
+	case "del", "s", "strike":
+		return markdownInline(markdownChildren(node), "~~")
+
+	case "code", "kbd", "samp":
+		if text := textContent(node); strings.TrimSpace(text) != "" {
+			return markdownCode(strings.Join(strings.Fields(text), " "))
+		}
+		return ""
+
+	case "pre":
+		code := strings.Trim(textContent(node), "\n")
+		if strings.TrimSpace(code) == "" {
+			return ""
+		}
+		fence := "```"
+		if run := markdownBacktickRun(code); run >= len(fence) {
+			fence = strings.Repeat("`", run+1)
+		}
+		return "\n\n" + fence + "\n" + code + "\n" + fence + "\n\n"
+
+	case "a":
+		text := strings.TrimSpace(markdownChildren(node))
+		href := getAttribute(node, "href")
+		if href == "" || text == "" {
+			return text
+		}
+		return "[" + text + "](" + markdownURLEscaper.Replace(href) + ")"
+
+	case "img":
+		src := getAttribute(node, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + markdownEscaper.Replace(getAttribute(node, "alt")) + "](" + markdownURLEscaper.Replace(src) + ")"
+
+	case "ul", "ol":
+		return markdownList(node, tag == "ol")
+
+	case "blockquote":
+		quote := strings.TrimSpace(renderMarkdown(node))
+		if quote == "" {
+			return ""
+		}
+		return "\n\n" + markdownPrefixLines(quote, "> ", "") + "\n\n"
+
+	case "table":
+		return markdownTable(node)
+
+	case "p", "div", "section", "article", "main", "header", "footer",
+		"figure", "figcaption", "details", "summary", "dl", "dt", "dd",
+		"address", "aside", "li", "tr", "td", "th", "caption":
+		return markdownBlock(markdownChildren(node))
+	}
+
+	return markdownChildren(node)
+}
+
+// markdownLineStart reports whether the text node is the first content of a
+// line, where a leading block marker would change the meaning of the text.
+func markdownLineStart(node *html.Node) bool {
+	for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+		switch {
+		case sibling.Type == html.TextNode && strings.TrimSpace(sibling.Data) == "":
+			continue
+		case sibling.Type == html.CommentNode:
+			continue
+		}
+
+		return sibling.Type == html.ElementNode && tagName(sibling) == "br"
+	}
+
+	return true
+}
+
+// markdownEscapeBlockMarker escapes the character that would turn text into a
+// heading, a blockquote, or a list item.
+func markdownEscapeBlockMarker(text string) string {
+	match := rxMarkdownBlockMarker.FindStringSubmatchIndex(text)
+
+	if match == nil {
+		return text
+	}
+
+	position := match[2]
+
+	if position < 0 {
+		position = match[4]
+	}
+
+	return text[:position] + `\` + text[position:]
+}
+
+// markdownBacktickRun returns the length of the longest sequence of backticks
+// in text.
+func markdownBacktickRun(text string) int {
+	longest, current := 0, 0
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '`' {
+			current = 0
+			continue
+		}
+
+		current++
+
+		if current > longest {
+			longest = current
+		}
+	}
+
+	return longest
+}
+
+// markdownCode wraps text in a code span delimited by more backticks than the
+// text contains in a row.
+func markdownCode(text string) string {
+	fence := strings.Repeat("`", markdownBacktickRun(text)+1)
+
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		text = " " + text + " "
+	}
+
+	return fence + text + fence
+}
+
+// markdownList converts an <ul> or <ol> element into a Markdown list.
+func markdownList(list *html.Node, ordered bool) string {
+	var items []string
+
+	number := 1
+
+	if start, err := strconv.Atoi(getAttribute(list, "start")); err == nil && ordered {
+		number = start
+	}
+
+	for _, item := range children(list) {
+		text := strings.TrimSpace(rxMarkdownBlankLines.ReplaceAllString(markdownChildren(item), "\n\n"))
+
+		if tagName(item) != "li" {
+			if text != "" {
+				items = append(items, text)
+			}
+			continue
+		}
+
+		marker := "- "
+
+		if ordered {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		items = append(items, markdownPrefixLines(text, marker, strings.Repeat(" ", len(marker))))
+	}
+
+	if len(items) == 0 {
+		return ""
+	}
+
+	return "\n\n" + strings.Join(items, "\n") + "\n\n"
+}
+
+// markdownTable converts a <table> element into a Markdown table. The first
+// row of the table is used as the header.
+func markdownTable(table *html.Node) string {
+	var rows [][]string
+
+	columns := 0
+
+	for _, tr := range getElementsByTagName(table, "tr") {
+		var row []string
+
+		for _, cell := range children(tr) {
+			if tag := tagName(cell); tag != "td" && tag != "th" {
+				continue
+			}
+
+			text := strings.Join(strings.Fields(markdownChildren(cell)), " ")
+			row = append(row, strings.Replace(text, "|", `\|`, -1))
+		}
+
+		if len(row) > columns {
+			columns = len(row)
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 || columns == 0 {
+		return ""
+	}
+
+	var markdown strings.Builder
+
+	markdown.WriteString("\n\n")
+
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+
+		markdown.WriteString("| " + strings.Join(row, " | ") + " |\n")
+
+		if i == 0 {
+			markdown.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+		}
+	}
+
+	markdown.WriteString("\n")
+
+	return markdown.String()
+}
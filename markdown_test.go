@@ -0,0 +1,106 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "link",
+			input:    `<p>Read <a href="/wiki/Go_(language)">the <em>wiki</em></a> now.</p>`,
+			expected: "Read [the _wiki_](/wiki/Go_%28language%29) now.",
+		},
+		{
+			name:     "link with spaces",
+			input:    `<p><a href="/my file.html">file</a></p>`,
+			expected: "[file](/my%20file.html)",
+		},
+		{
+			name:     "image",
+			input:    `<p><img src="/a b (1).png" alt="A [chart]"></p>`,
+			expected: "![A \\[chart\\]](/a%20b%20%281%29.png)",
+		},
+		{
+			name:     "code",
+			input:    `<p>Use <code>fmt.Println</code> here.</p>`,
+			expected: "Use `fmt.Println` here.",
+		},
+		{
+			name:     "code with backticks",
+			input:    "<p><code>a `b` c</code> and <code>``x</code></p>",
+			expected: "``a `b` c`` and ``` ``x ```",
+		},
+		{
+			name:     "preformatted with fences",
+			input:    "<pre>```go\nfmt.Println()\n```</pre>",
+			expected: "````\n```go\nfmt.Println()\n```\n````",
+		},
+		{
+			name:     "nested lists",
+			input:    `<ol start="3"><li>one<ul><li>two</li><li>three</li></ul></li><li>four</li></ol>`,
+			expected: "3. one\n\n   - two\n   - three\n4. four",
+		},
+		{
+			name:     "table",
+			input:    `<table><tr><th>Name</th><th>Value</th></tr><tr><td>a|b</td><td>1</td></tr></table>`,
+			expected: "| Name | Value |\n| --- | --- |\n| a\\|b | 1 |",
+		},
+		{
+			name:     "blockquote",
+			input:    `<blockquote><p>First</p><p>Second</p></blockquote>`,
+			expected: "> First\n>\n> Second",
+		},
+		{
+			name:     "heading marker",
+			input:    `<p># not a heading</p>`,
+			expected: `\# not a heading`,
+		},
+		{
+			name:     "blockquote marker",
+			input:    `<p>> not a quote</p>`,
+			expected: `\> not a quote`,
+		},
+		{
+			name:     "list markers",
+			input:    `<p>- minus</p><p>+ plus</p><p>1. ordered</p><p>2) ordered</p>`,
+			expected: "\\- minus\n\n\\+ plus\n\n1\\. ordered\n\n2\\) ordered",
+		},
+		{
+			name:     "marker after a line break",
+			input:    `<p>first<br>- second</p>`,
+			expected: "first\\\n\\- second",
+		},
+		{
+			name:     "markers inside the text",
+			input:    `<p>The year 1984. A - B + C > D #1</p>`,
+			expected: "The year 1984. A - B + C > D #1",
+		},
+		{
+			name:     "number without a marker",
+			input:    `<p>1984 was a novel</p>`,
+			expected: "1984 was a novel",
+		},
+	}
+
+	for _, test := range tests {
+		doc, err := html.Parse(strings.NewReader(test.input))
+
+		if err != nil {
+			t.Fatalf("%s: cannot parse document: %s", test.name, err)
+		}
+
+		body := getElementsByTagName(doc, "body")[0]
+
+		if result := renderMarkdown(body); result != test.expected {
+			t.Fatalf("%s: unexpected markdown\n%s\nexpecting\n%s", test.name, result, test.expected)
+		}
+	}
+}
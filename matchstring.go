@@ -0,0 +1,43 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/cixtor/readability/heuristics"
+	"golang.org/x/net/html"
+)
+
+// DefaultMaxMatchStringLength is the default of MaxMatchStringLength. Class
+// names and IDs of real pages are rarely longer than a few hundred characters,
+// obfuscated pages generate attributes of hundreds of kilobytes, which made a
+// single parse take seconds before they were truncated.
+const DefaultMaxMatchStringLength = 1024
+
+// maxMatchStringLength returns the maximum length of the class names and IDs
+// considered by the heuristics, see MaxMatchStringLength.
+func (r *Readability) maxMatchStringLength() int {
+	if r.MaxMatchStringLength <= 0 {
+		return DefaultMaxMatchStringLength
+	}
+
+	return r.MaxMatchStringLength
+}
+
+// matchString returns the class names and the ID of the element, as matched by
+// the regular expressions of the heuristics, truncated to MaxMatchStringLength.
+func (r *Readability) matchString(node *html.Node) string {
+	return limitedMatchString(node, r.maxMatchStringLength())
+}
+
+// limitedMatchString returns the class names and the ID of the element, each
+// one truncated to limit bytes before they are normalized.
+func limitedMatchString(node *html.Node, limit int) string {
+	return limitedClassName(node, limit) + "\x20" + strings.TrimSpace(heuristics.TruncateName(getAttribute(node, "id"), limit))
+}
+
+// limitedClassName returns the class names of the element, truncated to limit
+// bytes before they are normalized.
+func limitedClassName(node *html.Node, limit int) string {
+	className := strings.TrimSpace(heuristics.TruncateName(getAttribute(node, "class"), limit))
+	return rxNormalize.ReplaceAllString(className, "\x20")
+}
@@ -0,0 +1,85 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cixtor/readability/heuristics"
+)
+
+// obfuscatedPage returns a page whose elements have class names of size bytes.
+func obfuscatedPage(size int) string {
+	class := strings.Repeat("x9 ", size/3)
+
+	var page strings.Builder
+
+	page.WriteString(`<html><head><title>Obfuscated</title></head><body>`)
+
+	for i := 0; i < 50; i++ {
+		page.WriteString(`<div class="` + class + `"><p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p></div>`)
+	}
+
+	page.WriteString(`</body></html>`)
+
+	return page.String()
+}
+
+func TestTruncateName(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		expected string
+	}{
+		{"content", 0, "content"},
+		{"content", 100, "content"},
+		{"content main", 7, "content"},
+		{"añb", 2, "a"},
+	}
+
+	for _, tt := range tests {
+		if name := heuristics.TruncateName(tt.name, tt.limit); name != tt.expected {
+			t.Fatalf("%q: unexpected name: %q", tt.name, name)
+		}
+	}
+}
+
+func TestMatchStringLimit(t *testing.T) {
+	doc := createElement("div")
+	setAttribute(doc, "class", strings.Repeat("a", 5000)+" comment")
+	setAttribute(doc, "id", strings.Repeat("b", 5000))
+
+	r := New()
+	r.MaxMatchStringLength = 100
+
+	if matchString := r.matchString(doc); len(matchString) != 201 {
+		t.Fatalf("unexpected length of the match string: %d", len(matchString))
+	}
+
+	// The negative class name beyond the limit is ignored.
+	if weight := r.getClassWeight(doc); weight != 0 {
+		t.Fatalf("unexpected class weight: %d", weight)
+	}
+}
+
+func BenchmarkLongAttributes(b *testing.B) {
+	page := obfuscatedPage(64 << 10)
+
+	for _, limit := range []int{DefaultMaxMatchStringLength, 1 << 30} {
+		name := "default"
+
+		if limit != DefaultMaxMatchStringLength {
+			name = "unlimited"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				parser := New()
+				parser.MaxMatchStringLength = limit
+
+				if _, err := parser.Parse(strings.NewReader(page), "https://cixtor.com/blog"); err != nil {
+					b.Fatalf("parser failure: %s", err)
+				}
+			}
+		})
+	}
+}
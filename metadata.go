@@ -0,0 +1,157 @@
+package readability
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+
+	"golang.org/x/net/html"
+)
+
+// Metadata describes a document as declared in its <head>: the meta tags, the
+// JSON-LD data, the title and the icons. It is returned by ExtractMetadata,
+// which does not look for the content of the article.
+type Metadata struct {
+	// Title is the title of the article, see Article.Title.
+	Title string
+
+	// Byline is the name of the author, as found in the meta tags.
+	Byline string
+
+	// Excerpt is the description of the article, as found in the meta tags.
+	// Unlike the Excerpt of the Article, it is never taken from the content.
+	Excerpt string
+
+	// SiteName is the name of the original publisher website.
+	SiteName string
+
+	// Image is an image URL which represents the article’s content.
+	Image string
+
+	// Favicon is the URL of the icon of the website.
+	Favicon string
+
+	// PublishedTime is the date when the article was published, as written
+	// in the metadata of the document.
+	PublishedTime string
+
+	// CommentCount is the number of comments of the article, as declared in
+	// the schema.org metadata or in the meta tags of the document.
+	CommentCount int
+
+	// InteractionCounts maps the schema.org actions to the number of times
+	// users performed them, see Article.InteractionCounts.
+	InteractionCounts map[string]int
+
+	// NoIndex is true if the document asks search engines not to index it.
+	NoIndex bool
+
+	// NoArchive is true if the document asks not to be stored.
+	NoArchive bool
+
+	// URL is the address of the document.
+	URL string
+
+	// RelLinks are the links of the document to the previous and the next
+	// pages, to the translations and to the alternate versions of the page.
+	RelLinks []RelLink
+
+	// Translations maps the languages to the URLs of the translations of the
+	// page declared with hreflang links.
+	Translations map[string]string
+
+	// OEmbedURLs are the oEmbed endpoints declared by the document.
+	OEmbedURLs []string
+
+	// OEmbed is the response of the first oEmbed endpoint, only fetched when
+	// FetchOEmbed is enabled.
+	OEmbed *OEmbed
+
+	// CanonicalURL is the preferred address of the document, declared with
+	// the og:url property or with a canonical link, or URL if there is none.
+	CanonicalURL string
+
+	// ThemeColor is the color suggested by the document to decorate the user
+	// interface around it, as declared with the theme-color meta tag.
+	ThemeColor string
+
+	// TileColor is the background color of the tile of the website in the
+	// Windows start screen, declared with the msapplication-TileColor tag.
+	TileColor string
+
+	// Logo is the absolute URL of the logo of the publisher.
+	Logo string
+}
+
+// ExtractMetadata reads the metadata of the document without looking for the
+// content of the article, which is much faster than Parse. It is meant for
+// services that only need the title, the description and the image of a page,
+// like link previews.
+func (r *Readability) ExtractMetadata(input io.Reader, pageURL string) (Metadata, error) {
+	doc, err := html.Parse(input)
+
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	return r.extractMetadata(doc, pageURL)
+}
+
+// extractMetadata reads the metadata of a parsed document. The scripts of the
+// document are removed.
+func (r *Readability) extractMetadata(doc *html.Node, pageURL string) (Metadata, error) {
+	var err error
+
+	if r.documentURI, err = url.ParseRequestURI(pageURL); err != nil {
+		return Metadata{}, fmt.Errorf("failed to parse URL: %v", err)
+	}
+
+	r.doc = doc
+	r.articleTitle = ""
+	r.localizedBylineTokens = r.bylineTokens()
+
+	// The JSON-LD metadata is removed together with the scripts.
+	stats := r.getInteractionStats(r.doc)
+	robots := getRobotsDirectives(r.doc)
+	logo := r.getPublisherLogo(r.doc)
+
+	r.removeScripts(r.doc)
+
+	article := r.getArticleMetadata()
+
+	metadata := Metadata{
+		Title:         article.Title,
+		Byline:        article.Byline,
+		Excerpt:       article.Excerpt,
+		SiteName:      article.SiteName,
+		Image:         article.Image,
+		Favicon:       article.Favicon,
+		PublishedTime: article.PublishedTime,
+		CommentCount:  stats.commentCount,
+		NoIndex:       robots.noIndex,
+		NoArchive:     robots.noArchive,
+		URL:           pageURL,
+		CanonicalURL:  r.canonicalURL(r.doc),
+		ThemeColor:    article.ThemeColor,
+		TileColor:     article.TileColor,
+		Logo:          logo,
+		RelLinks:      article.RelLinks,
+		Translations:  article.Translations,
+		OEmbedURLs:    article.OEmbedURLs,
+		OEmbed:        article.OEmbed,
+	}
+
+	if author, date := splitBylineDate(metadata.Byline); date != "" {
+		metadata.Byline = author
+
+		if metadata.PublishedTime == "" {
+			metadata.PublishedTime = date
+		}
+	}
+
+	if len(stats.counts) > 0 {
+		metadata.InteractionCounts = stats.counts
+	}
+
+	return metadata, nil
+}
@@ -0,0 +1,50 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractMetadata(t *testing.T) {
+	input := `<html><head>
+		<title>Parsing HTML in Go | Cixtor</title>
+		<meta property="og:title" content="Parsing HTML in Go">
+		<meta name="description" content="How to parse HTML documents.">
+		<meta property="og:site_name" content="Cixtor">
+		<meta property="og:image" content="/images/cover.png">
+		<meta name="author" content="Jane Doe | March 3, 2024">
+		<meta name="robots" content="noarchive">
+		<link rel="icon" type="image/png" sizes="32x32" href="/favicon.png">
+		<script type="application/ld+json">{"@type": "Article", "commentCount": 7}</script>
+	</head><body><p>The content is ignored.</p></body></html>`
+
+	m, err := New().ExtractMetadata(strings.NewReader(input), "https://cixtor.com/blog/go")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := Metadata{
+		Title:         "Parsing HTML in Go",
+		Byline:        "Jane Doe",
+		Excerpt:       "How to parse HTML documents.",
+		SiteName:      "Cixtor",
+		Image:         "https://cixtor.com/images/cover.png",
+		Favicon:       "https://cixtor.com/favicon.png",
+		PublishedTime: "March 3, 2024",
+		CommentCount:  7,
+		NoArchive:     true,
+		URL:           "https://cixtor.com/blog/go",
+	}
+
+	if m.Title != expected.Title || m.Byline != expected.Byline || m.Excerpt != expected.Excerpt ||
+		m.SiteName != expected.SiteName || m.Image != expected.Image || m.Favicon != expected.Favicon ||
+		m.PublishedTime != expected.PublishedTime || m.CommentCount != expected.CommentCount ||
+		m.NoIndex != expected.NoIndex || m.NoArchive != expected.NoArchive || m.URL != expected.URL {
+		t.Fatalf("unexpected metadata:\n%#v\n%#v", m, expected)
+	}
+
+	if _, err := New().ExtractMetadata(strings.NewReader(input), "not a url"); err == nil {
+		t.Fatalf("expecting an error for an invalid URL")
+	}
+}
@@ -0,0 +1,106 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxNewsletter matches the class names and ids of newsletter forms as whole
+// words, so the "subscriber-only" containers of paywalled articles are kept.
+var rxNewsletter = regexp.MustCompile(`(?i)(^|[\s_-])(newsletters?|subscribe|subscription|sign-?up|mailing-?list|opt-?in|email-?capture)([\s_-]|$)`)
+
+// newsletterMaxLength is the maximum length of the text of a block removed for
+// containing one of the NewsletterPatterns, which avoids removing paragraphs of
+// articles that talk about newsletters.
+const newsletterMaxLength = 300
+
+// defaultNewsletterPatterns is the list of phrases used by websites to promote
+// their newsletters, in the default languages.
+var defaultNewsletterPatterns = languagePhrases(defaultLanguages, func(pack LanguagePack) []string {
+	return pack.NewsletterPatterns
+})
+
+// newsletterElems is a list of HTML tag names that can hold a newsletter form.
+var newsletterElems = []string{"aside", "div", "form", "li", "p", "section", "h2", "h3", "h4", "h5", "h6"}
+
+// removeNewsletterBlocks removes the forms and blurbs inviting the reader to
+// subscribe to a newsletter. These blocks are recognized by their class names
+// and by the NewsletterPatterns found in their text. They often contain full
+// sentences, which makes them look like regular paragraphs to cleanConditionally,
+// so they are removed from the document before the content is scored.
+func (r *Readability) removeNewsletterBlocks(root *html.Node) {
+	var blocks []*html.Node
+
+	patterns := r.languagePhrases(r.NewsletterPatterns, func(pack LanguagePack) []string {
+		return pack.NewsletterPatterns
+	})
+
+	for _, node := range getElementsByTagName(root, "*") {
+		if node == root || indexOf(newsletterElems, tagName(node)) == -1 {
+			continue
+		}
+
+		if len(blocks) > 0 && r.hasAncestorIn(node, blocks) {
+			continue
+		}
+
+		if r.isNewsletterContainer(node) || r.isNewsletterBlurb(node, patterns) {
+			blocks = append(blocks, r.newsletterBlock(root, node))
+		}
+	}
+
+	r.removeNodes(blocks, r.reportingFilter(RemovalNewsletter, nil))
+}
+
+// isNewsletterContainer returns true if the class or id of node indicate that
+// it is a newsletter form and its text is too short to be part of the article.
+func (r *Readability) isNewsletterContainer(node *html.Node) bool {
+	if !rxNewsletter.MatchString(r.matchString(node)) {
+		return false
+	}
+
+	return len(r.getInnerText(node, true)) < r.CharThresholds
+}
+
+// isNewsletterBlurb returns true if node is a short block of text containing
+// one of the patterns or an input field for an email address.
+func (r *Readability) isNewsletterBlurb(node *html.Node, patterns []string) bool {
+	text := r.getInnerText(node, true)
+
+	if len(text) > newsletterMaxLength {
+		return false
+	}
+
+	for _, input := range getElementsByTagName(node, "input") {
+		if strings.EqualFold(getAttribute(input, "type"), "email") {
+			return true
+		}
+	}
+
+	text = strings.ToLower(text)
+
+	for _, pattern := range patterns {
+		if pattern != "" && strings.Contains(text, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newsletterBlock returns the largest ancestor of node that is still short
+// enough to be the container of the newsletter form, so the headings, inputs
+// and buttons next to the text are removed as well.
+func (r *Readability) newsletterBlock(root *html.Node, node *html.Node) *html.Node {
+	for parent := node.Parent; parent != nil && parent != root; parent = parent.Parent {
+		if len(r.getInnerText(parent, true)) > newsletterMaxLength {
+			break
+		}
+
+		node = parent
+	}
+
+	return node
+}
@@ -0,0 +1,101 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveNewsletterBlocks(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+				<div>
+					<h3>Stay in the loop</h3>
+					<p>Sign up for our newsletter, and get the best stories of the week, every week.</p>
+					<button>Subscribe</button>
+				</div>
+				<p>Duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore.</p>
+				<div class="inline-signup"><p>Never miss a story, we send one email per day.</p></div>
+				<p>Many readers subscribe to our newsletter, sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium, totam rem aperiam, eaque ipsa quae ab illo inventore veritatis et quasi architecto beatae vitae dicta sunt explicabo. Nemo enim ipsam voluptatem quia voluptas sit aspernatur aut odit aut fugit, sed quia consequuntur magni dolores eos qui ratione voluptatem sequi nesciunt.</p>
+			</article>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.RemoveNewsletters = true
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, removed := range []string{"Stay in the loop", "Sign up for our newsletter", "Never miss a story"} {
+		if strings.Contains(a.Content, removed) {
+			t.Fatalf("newsletter block was not removed: %s", a.Content)
+		}
+	}
+
+	for _, kept := range []string{"Lorem ipsum", "Duis aute", "Many readers"} {
+		if !strings.Contains(a.Content, kept) {
+			t.Fatalf("paragraph was removed: %s", a.Content)
+		}
+	}
+}
+
+func TestNewsletterClassWords(t *testing.T) {
+	tests := map[string]bool{
+		"newsletter-signup":       true,
+		"inline-signup":           true,
+		"footer_subscribe":        true,
+		"mailing-list widget":     true,
+		"subscriber-only":         false,
+		"subscribers-content":     false,
+		"unsubscribed premium":    false,
+		"newslettersarchive-list": false,
+	}
+
+	for input, expected := range tests {
+		if result := rxNewsletter.MatchString(input); result != expected {
+			t.Fatalf("rxNewsletter(%q) = %t, expecting %t", input, result, expected)
+		}
+	}
+}
+
+func TestRemoveNewslettersOption(t *testing.T) {
+	input := `<html><head><title>hello world</title></head><body><article>
+		<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+		<div class="subscriber-only"><p>Paywalled reporting, sed ut perspiciatis unde omnis.</p></div>
+		<div class="newsletter"><p>Never miss a story, we send one email per day.</p></div>
+		<p>Duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore eu fugiat nulla pariatur, excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum, nemo enim ipsam voluptatem quia voluptas sit aspernatur aut odit aut fugit.</p>
+		</article></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, "Never miss a story") {
+		t.Fatalf("newsletter block was removed by default: %s", a.Content)
+	}
+
+	parser := New()
+	parser.RemoveNewsletters = true
+	a, err = parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.Content, "Paywalled reporting") {
+		t.Fatalf("subscriber-only content was removed: %s", a.Content)
+	}
+
+	if strings.Contains(a.Content, "Never miss a story") {
+		t.Fatalf("newsletter block was not removed: %s", a.Content)
+	}
+}
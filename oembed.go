@@ -0,0 +1,172 @@
+package readability
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// oembedTypes are the media types of the oEmbed discovery links.
+var oembedTypes = []string{
+	"application/json+oembed",
+	"text/xml+oembed",
+	"application/xml+oembed",
+}
+
+// oembedMaxBytes is the maximum size of the response of an oEmbed endpoint,
+// the documented responses are a few kilobytes at most.
+const oembedMaxBytes = 1 << 20
+
+// OEmbed is the response of an oEmbed endpoint, which describes how to embed
+// the page in another one. See https://oembed.com for the meaning of each
+// property.
+type OEmbed struct {
+	Type            string
+	Version         string
+	Title           string
+	AuthorName      string
+	AuthorURL       string
+	ProviderName    string
+	ProviderURL     string
+	ThumbnailURL    string
+	ThumbnailWidth  int
+	ThumbnailHeight int
+	URL             string
+	HTML            string
+	Width           int
+	Height          int
+}
+
+// getOEmbedURLs returns the absolute URLs of the oEmbed endpoints declared by
+// the document with <link rel="alternate"> elements, the JSON ones first.
+func (r *Readability) getOEmbedURLs(doc *html.Node) []string {
+	var urls []string
+	var xmlURLs []string
+
+	for _, link := range getElementsByTagName(doc, "link") {
+		linkType := strings.ToLower(strings.TrimSpace(getAttribute(link, "type")))
+
+		if indexOf(oembedTypes, linkType) == -1 || !hasRel(link, "alternate") {
+			continue
+		}
+
+		href := toAbsoluteURI(getAttribute(link, "href"), r.documentURI)
+
+		if href == "" {
+			continue
+		}
+
+		if linkType == oembedTypes[0] {
+			urls = append(urls, href)
+		} else {
+			xmlURLs = append(xmlURLs, href)
+		}
+	}
+
+	return append(urls, xmlURLs...)
+}
+
+// hasRel determines if the rel attribute of the link contains the given type.
+func hasRel(link *html.Node, rel string) bool {
+	for _, value := range strings.Fields(getAttribute(link, "rel")) {
+		if strings.EqualFold(value, rel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fetchOEmbed downloads the response of the oEmbed endpoint with the Fetcher.
+// Only JSON responses are supported. The endpoint comes from the document, so
+// only HTTP and HTTPS URLs are fetched, and at most oembedMaxBytes are read.
+func (r *Readability) fetchOEmbed(endpoint string) (*OEmbed, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported oEmbed scheme: %s", req.URL.Scheme)
+	}
+
+	res, err := r.fetcher().Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch oEmbed: %v", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	var data map[string]interface{}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, oembedMaxBytes)).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode oEmbed: %v", err)
+	}
+
+	return &OEmbed{
+		Type:            oembedString(data["type"]),
+		Version:         oembedString(data["version"]),
+		Title:           oembedString(data["title"]),
+		AuthorName:      oembedString(data["author_name"]),
+		AuthorURL:       oembedString(data["author_url"]),
+		ProviderName:    oembedString(data["provider_name"]),
+		ProviderURL:     oembedString(data["provider_url"]),
+		ThumbnailURL:    oembedString(data["thumbnail_url"]),
+		ThumbnailWidth:  oembedInt(data["thumbnail_width"]),
+		ThumbnailHeight: oembedInt(data["thumbnail_height"]),
+		URL:             oembedString(data["url"]),
+		HTML:            oembedString(data["html"]),
+		Width:           oembedInt(data["width"]),
+		Height:          oembedInt(data["height"]),
+	}, nil
+}
+
+// getOEmbed fetches the first oEmbed endpoint that responds with JSON, or
+// returns nil if there is none.
+func (r *Readability) getOEmbed(endpoints []string) *OEmbed {
+	for _, endpoint := range endpoints {
+		if oembed, err := r.fetchOEmbed(endpoint); err == nil {
+			return oembed
+		}
+	}
+
+	return nil
+}
+
+// oembedString returns the value of a property of the oEmbed response as text.
+func oembedString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return ""
+}
+
+// oembedInt returns the value of a dimension of the oEmbed response. Some
+// providers send the dimensions as strings.
+func oembedInt(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return n
+		}
+	}
+
+	return 0
+}
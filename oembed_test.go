@@ -0,0 +1,70 @@
+package readability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"type": "video", "version": "1.0", "title": "Cats", "provider_name": "Tube", "width": 640, "height": "360", "html": "<iframe></iframe>"}`))
+	}))
+	defer server.Close()
+
+	input := `<html><head>
+		<title>Cats</title>
+		<link rel="alternate" type="text/xml+oembed" href="/oembed.xml">
+		<link rel="alternate" type="application/json+oembed" href="` + server.URL + `/oembed.json">
+		<link rel="alternate" type="application/rss+xml" href="/feed">
+	</head><body><p>lorem ipsum</p></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/videos/cats")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{server.URL + "/oembed.json", "https://cixtor.com/oembed.xml"}
+
+	if !reflect.DeepEqual(a.OEmbedURLs, expected) {
+		t.Fatalf("unexpected oEmbed URLs: %q", a.OEmbedURLs)
+	}
+
+	if a.OEmbed != nil {
+		t.Fatalf("the oEmbed endpoint was fetched without FetchOEmbed")
+	}
+
+	parser := New()
+	parser.FetchOEmbed = true
+	m, err := parser.ExtractMetadata(strings.NewReader(input), "https://cixtor.com/videos/cats")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if m.OEmbed == nil || m.OEmbed.Type != "video" || m.OEmbed.Width != 640 || m.OEmbed.Height != 360 || m.OEmbed.ProviderName != "Tube" {
+		t.Fatalf("unexpected oEmbed: %#v", m.OEmbed)
+	}
+}
+
+func TestFetchOEmbedLimits(t *testing.T) {
+	body := &countingBody{prefix: `{"title": "`}
+	parser := New()
+	parser.Fetcher = bodyFetcher{body: body}
+
+	if _, err := parser.fetchOEmbed("file:///etc/passwd"); err == nil || body.read != 0 {
+		t.Fatalf("the endpoint with an unsupported scheme was fetched")
+	}
+
+	if _, err := parser.fetchOEmbed("https://cixtor.com/oembed.json"); err == nil {
+		t.Fatalf("the endless response should not be decoded")
+	}
+
+	if body.read > oembedMaxBytes+64*1024 {
+		t.Fatalf("the response was read past the limit: %d bytes", body.read)
+	}
+}
@@ -0,0 +1,134 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// outlineContainers are the elements that are flattened while building the
+// outline when they contain headings, so the headings nested inside wrappers
+// still start their own sections.
+var outlineContainers = []string{"div", "section", "article", "main", "header", "hgroup"}
+
+// Section is a part of the article introduced by a heading. The first section
+// of an outline has no heading and contains the blocks found before the first
+// heading of the article.
+type Section struct {
+	// Heading is the text of the heading that introduces the section.
+	Heading string
+
+	// Level is the level of the heading, 1 for <h1> through 6 for <h6>, or 0
+	// for the root of the outline.
+	Level int
+
+	// ID is the id attribute of the heading, if any, which can be used to link
+	// to the section.
+	ID string
+
+	// Blocks is the HTML of each one of the blocks found after the heading and
+	// before the next heading, in the same order found in the content.
+	Blocks []string
+
+	// Sections are the sections introduced by headings of a deeper level.
+	Sections []Section
+}
+
+// Outline returns the tree of sections of the article, built from the headings
+// of the content. Blocks that follow a heading belong to its section until the
+// next heading of the same or a higher level.
+func (a Article) Outline() Section {
+	if a.content != nil {
+		return buildOutline(a.content)
+	}
+
+	doc, err := html.Parse(strings.NewReader(a.Content))
+
+	if err != nil {
+		return Section{}
+	}
+
+	if body := getElementsByTagName(doc, "body"); len(body) > 0 {
+		return buildOutline(body[0])
+	}
+
+	return Section{}
+}
+
+// Outline returns the tree of sections of the article. See Article.Outline.
+func (res *Result) Outline() Section {
+	if res.content == nil {
+		return Section{}
+	}
+
+	return buildOutline(res.content)
+}
+
+// buildOutline returns the tree of sections of the children of root.
+func buildOutline(root *html.Node) Section {
+	outline := &Section{}
+	stack := []*Section{outline}
+
+	var walk func(*html.Node)
+
+	walk = func(parent *html.Node) {
+		for child := parent.FirstChild; child != nil; child = child.NextSibling {
+			if level := headingLevel(child); level > 0 {
+				for len(stack) > 1 && stack[len(stack)-1].Level >= level {
+					stack = stack[:len(stack)-1]
+				}
+
+				current := stack[len(stack)-1]
+				current.Sections = append(current.Sections, Section{
+					Heading: strings.Join(strings.Fields(textContent(child)), " "),
+					Level:   level,
+					ID:      id(child),
+				})
+				stack = append(stack, &current.Sections[len(current.Sections)-1])
+				continue
+			}
+
+			if child.Type == html.TextNode && strings.TrimSpace(child.Data) == "" {
+				continue
+			}
+
+			if child.Type == html.CommentNode {
+				continue
+			}
+
+			if indexOf(outlineContainers, tagName(child)) != -1 && hasHeading(child) {
+				walk(child)
+				continue
+			}
+
+			current := stack[len(stack)-1]
+			current.Blocks = append(current.Blocks, strings.TrimSpace(outerHTML(child)))
+		}
+	}
+
+	walk(root)
+
+	return *outline
+}
+
+// headingLevel returns the level of a heading element, or 0 for other nodes.
+func headingLevel(node *html.Node) int {
+	tag := tagName(node)
+
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+
+	return 0
+}
+
+// hasHeading returns true if the node contains a heading element.
+func hasHeading(node *html.Node) bool {
+	for _, tag := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
+		if len(getElementsByTagName(node, tag)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
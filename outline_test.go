@@ -0,0 +1,40 @@
+package readability
+
+import (
+	"testing"
+)
+
+func TestOutline(t *testing.T) {
+	article := Article{Content: `<div id="readability-page-1" class="page">
+		<p>Intro</p>
+		<h2 id="one">One</h2>
+		<p>First</p>
+		<h3>One point one</h3>
+		<p>Nested</p>
+		<section><h2>Two</h2><p>Second</p></section>
+	</div>`}
+
+	outline := article.Outline()
+
+	if len(outline.Blocks) != 1 || outline.Blocks[0] != "<p>Intro</p>" {
+		t.Fatalf("unexpected root blocks: %#v", outline.Blocks)
+	}
+
+	if len(outline.Sections) != 2 {
+		t.Fatalf("expecting two top sections: %#v", outline.Sections)
+	}
+
+	one := outline.Sections[0]
+
+	if one.Heading != "One" || one.Level != 2 || one.ID != "one" || len(one.Blocks) != 1 {
+		t.Fatalf("unexpected first section: %#v", one)
+	}
+
+	if len(one.Sections) != 1 || one.Sections[0].Heading != "One point one" || one.Sections[0].Blocks[0] != "<p>Nested</p>" {
+		t.Fatalf("unexpected nested section: %#v", one.Sections)
+	}
+
+	if two := outline.Sections[1]; two.Heading != "Two" || len(two.Blocks) != 1 || two.Blocks[0] != "<p>Second</p>" {
+		t.Fatalf("unexpected second section: %#v", two)
+	}
+}
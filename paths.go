@@ -0,0 +1,72 @@
+package readability
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxPathID matches the IDs that can be written in a path without escaping.
+var rxPathID = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// pathSegment returns the segment of the path of node, which is the element
+// at the given index among total siblings with the same tag name. Segments are
+// separated by ">" to build a path, like "html>body>div#main>article". Each one
+// is the tag name of an element, followed by its ID if it has one, or by its
+// position among the siblings with the same tag name, like "p:nth-of-type(2)",
+// if there is more than one.
+func pathSegment(node *html.Node, index int, total int) string {
+	if id := getAttribute(node, "id"); rxPathID.MatchString(id) {
+		return node.Data + "#" + id
+	}
+
+	if total > 1 {
+		return node.Data + ":nth-of-type(" + strconv.Itoa(index+1) + ")"
+	}
+
+	return node.Data
+}
+
+// domPath returns the path of node from the root of its document, see
+// pathSegment.
+func domPath(node *html.Node) string {
+	var segments []string
+
+	for ; node != nil && node.Type == html.ElementNode; node = node.Parent {
+		index, total := 0, 0
+
+		if node.Parent != nil {
+			for sibling := firstElementChild(node.Parent); sibling != nil; sibling = nextElementSibling(sibling) {
+				if sibling == node {
+					index = total
+				}
+
+				if sibling.Data == node.Data {
+					total++
+				}
+			}
+		}
+
+		segments = append(segments, pathSegment(node, index, total))
+	}
+
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+
+	return strings.Join(segments, ">")
+}
+
+// reportPath returns the path of node reported in the diagnostics. When the
+// source positions are recorded, it is the path of the element in the original
+// document, otherwise it is the path in the document as prepared for scoring,
+// where scripts, styles and other elements were already removed.
+func (r *Readability) reportPath(node *html.Node) string {
+	if index, err := strconv.Atoi(getAttribute(node, sourceAttr)); err == nil && index >= 0 && index < len(r.sourceNodes) {
+		return r.sourcePosition(index).Path
+	}
+
+	return domPath(node)
+}
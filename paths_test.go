@@ -0,0 +1,80 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestDOMPath(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<div id="nav"></div><div><p>a</p><p>b</p><span></span><p class="x y">c</p></div><div id="a b"></div>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	paragraphs := getElementsByTagName(doc, "p")
+	divs := getElementsByTagName(doc, "div")
+
+	tests := []struct {
+		node     *html.Node
+		expected string
+	}{
+		{divs[0], "html>body>div#nav"},
+		{paragraphs[0], "html>body>div:nth-of-type(2)>p:nth-of-type(1)"},
+		{paragraphs[2], "html>body>div:nth-of-type(2)>p:nth-of-type(3)"},
+		{getElementsByTagName(doc, "span")[0], "html>body>div:nth-of-type(2)>span"},
+		{divs[2], "html>body>div:nth-of-type(3)"},
+	}
+
+	for _, test := range tests {
+		if path := domPath(test.node); path != test.expected {
+			t.Fatalf("unexpected path: %q, expecting %q", path, test.expected)
+		}
+	}
+}
+
+func TestTopCandidatePath(t *testing.T) {
+	input := `<html>
+		<head><title>hello world</title></head>
+		<body>
+			<div class="sidebar"><a href="/">home</a> <a href="/about">about</a></div>
+			<div id="main">
+				<article>
+					<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+					<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+					<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+				</article>
+			</div>
+		</body>
+		</html>`
+
+	for _, record := range []bool{false, true} {
+		parser := New()
+		parser.ReportRemovals = true
+		parser.RecordSourcePositions = record
+
+		result, err := parser.Analyze(strings.NewReader(input), "https://cixtor.com/blog")
+
+		if err != nil {
+			t.Fatalf("parser failure: %s", err)
+		}
+
+		if result.TopCandidatePath != "html>body>div#main" {
+			t.Fatalf("unexpected top candidate path: %q", result.TopCandidatePath)
+		}
+
+		found := false
+
+		for _, removal := range result.Removals {
+			if removal.Class == "sidebar" {
+				found = removal.Path == "html>body>div:nth-of-type(1)"
+			}
+		}
+
+		if !found {
+			t.Fatalf("unexpected removals: %#v", result.Removals)
+		}
+	}
+}
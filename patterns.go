@@ -0,0 +1,106 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+)
+
+// ErrUnsafePattern is returned by CompilePattern when a pattern could make the
+// parser slow or remove every element of the document.
+var ErrUnsafePattern = errors.New("unsafe pattern")
+
+// maxPatternLength is the maximum length of a pattern given as a string.
+const maxPatternLength = 1024
+
+// maxPatternRepeat is the maximum count of a counted repetition, like {2,5}.
+const maxPatternRepeat = 100
+
+// maxPatternInstructions is the maximum size of the compiled program of a
+// pattern. Nested counted repetitions, like ((a{50}){50}){50}, compile to huge
+// programs that are slow to run, even if the time is linear in the input.
+const maxPatternInstructions = 5000
+
+// CompilePattern compiles a regular expression supplied by users of the parser
+// as a string, like the patterns read from configuration files.
+//
+// The regular expressions of Go run in linear time, so the backtracking that
+// makes some patterns catastrophic in other engines is not possible, and
+// patterns with backreferences or lookarounds are rejected by the compiler.
+// On top of that, CompilePattern returns ErrUnsafePattern for patterns that
+// are too long, that expand to too many instructions, or that match the empty
+// string, which would match every element of the document.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxPatternLength {
+		return nil, fmt.Errorf("%w: longer than %d bytes", ErrUnsafePattern, maxPatternLength)
+	}
+
+	re, err := syntax.Parse(pattern, syntax.Perl)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pattern: %v", err)
+	}
+
+	if hasLargeRepeat(re) {
+		return nil, fmt.Errorf("%w: repetition count larger than %d", ErrUnsafePattern, maxPatternRepeat)
+	}
+
+	prog, err := syntax.Compile(re.Simplify())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile pattern: %v", err)
+	}
+
+	if len(prog.Inst) > maxPatternInstructions {
+		return nil, fmt.Errorf("%w: too complex", ErrUnsafePattern)
+	}
+
+	rx, err := regexp.Compile(pattern)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile pattern: %v", err)
+	}
+
+	if rx.MatchString("") {
+		return nil, fmt.Errorf("%w: matches the empty string", ErrUnsafePattern)
+	}
+
+	return rx, nil
+}
+
+// hasLargeRepeat determines if the pattern has a counted repetition larger than
+// maxPatternRepeat.
+func hasLargeRepeat(re *syntax.Regexp) bool {
+	if re.Op == syntax.OpRepeat && (re.Min > maxPatternRepeat || re.Max > maxPatternRepeat) {
+		return true
+	}
+
+	for _, sub := range re.Sub {
+		if hasLargeRepeat(sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewAdPattern returns an AdPattern for the given attribute, with the value
+// compiled by CompilePattern. An empty value matches any value.
+func NewAdPattern(attribute string, value string) (AdPattern, error) {
+	pattern := AdPattern{Attribute: attribute}
+
+	if value == "" {
+		return pattern, nil
+	}
+
+	rx, err := CompilePattern(value)
+
+	if err != nil {
+		return AdPattern{}, err
+	}
+
+	pattern.Value = rx
+
+	return pattern, nil
+}
@@ -0,0 +1,126 @@
+package readability
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompilePattern(t *testing.T) {
+	valid := []string{
+		`^(div-gpt-ad|google_ads_iframe)`,
+		`(?i)sponsor(ed)?`,
+		`[a-z]{2,10}-ad`,
+	}
+
+	for _, pattern := range valid {
+		if _, err := CompilePattern(pattern); err != nil {
+			t.Fatalf("%q: unexpected error: %s", pattern, err)
+		}
+	}
+
+	unsafe := []string{
+		`a{1000}`,
+		`((abcdefghij|klmnopqrst){30}){30}`,
+		`.*`,
+		`(ad)?`,
+		strings.Repeat("a", maxPatternLength+1),
+	}
+
+	for _, pattern := range unsafe {
+		if _, err := CompilePattern(pattern); !errors.Is(err, ErrUnsafePattern) {
+			t.Fatalf("%q: expecting ErrUnsafePattern: %v", pattern, err)
+		}
+	}
+
+	invalid := []string{`(a)\1`, `(?=ad)`, `[`}
+
+	for _, pattern := range invalid {
+		if _, err := CompilePattern(pattern); err == nil || errors.Is(err, ErrUnsafePattern) {
+			t.Fatalf("%q: expecting a compilation error: %v", pattern, err)
+		}
+	}
+}
+
+func TestNewAdPattern(t *testing.T) {
+	pattern, err := NewAdPattern("id", `^promo-`)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	node := createElement("div")
+	setAttribute(node, "id", "promo-top")
+
+	if !pattern.matches(node) {
+		t.Fatalf("the pattern does not match the element")
+	}
+
+	if _, err := NewAdPattern("class", `.*`); !errors.Is(err, ErrUnsafePattern) {
+		t.Fatalf("expecting ErrUnsafePattern: %v", err)
+	}
+}
+
+// TestPatternMatchingTime checks that the time spent matching the patterns of
+// the package grows linearly with the input, on inputs built to trigger the
+// worst case of backtracking engines.
+func TestPatternMatchingTime(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping timing test in short mode")
+	}
+
+	patterns := []*regexp.Regexp{
+		rxAttribution, rxAttributionDash, rxBylineDate, rxBylineSeparator, rxBylineConnector,
+		rxGoogleAMP, rxAMPCache, rxConsentVendor, rxConsentText, rxFixedPosition, rxHeroCaption,
+		rxTrackingPixel, rxBullet, rxLiveUpdate, rxTimestamp, rxMarkdownBlankLines, rxNewsletter,
+		rxPullQuote, rxUnlikelyCandidates, rxOkMaybeItsACandidate, rxByline, rxNormalize, rxVideos,
+		rxWhitespace, rxHasContent, rxPropertyPattern, rxNamePattern, rxTitleSeparator,
+		rxTitleHierarchySep, rxTitleRemoveFinalPart, rxTitleAnySeparator, rxDisplayNone, rxShare,
+		rxFaviconSize, rxMetaRefresh, rxSentenceEnd, rxThreadPostType, rxPostAuthor, rxPostScore,
+	}
+
+	random := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", " ", "-", "|", "/", ":", "1", ".", "\n", "by ", "http://", "ad"}
+
+	inputs := func(size int) []string {
+		var mixed strings.Builder
+
+		for mixed.Len() < size {
+			mixed.WriteString(alphabet[random.Intn(len(alphabet))])
+		}
+
+		return []string{
+			strings.Repeat("a", size) + "!",
+			strings.Repeat(" ", size) + "x",
+			strings.Repeat("a-", size/2),
+			mixed.String(),
+		}
+	}
+
+	elapsed := func(size int) time.Duration {
+		inputs := inputs(size)
+		start := time.Now()
+
+		for _, rx := range patterns {
+			for _, input := range inputs {
+				rx.MatchString(input)
+				rx.FindAllStringIndex(input, -1)
+			}
+		}
+
+		return time.Since(start)
+	}
+
+	// Growing the input 16 times makes a linear engine 16 times slower, and
+	// a backtracking engine hundreds of times slower. The bound is generous
+	// to absorb the noise of the measurements.
+	small := elapsed(1 << 10)
+	large := elapsed(1 << 14)
+
+	if large > 64*small+100*time.Millisecond {
+		t.Fatalf("matching time is not linear: %s for 1 KiB, %s for 16 KiB", small, large)
+	}
+}
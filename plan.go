@@ -0,0 +1,175 @@
+package readability
+
+import (
+	"strconv"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// planAttr identifies the elements of the copy of the document analyzed by
+// Plan, so the candidates and removals can be traced back to the input.
+const planAttr = "data-readability-plan"
+
+// Plan is the outcome of the analysis of a document without modifying it, see
+// Readability.Plan. The nodes are the elements of the analyzed document.
+type Plan struct {
+	// Content is the element selected as the top candidate, which contains
+	// the content of the article, or nil if no element was good enough and
+	// the content was taken from the whole body.
+	Content *html.Node
+
+	// Candidates are the elements scored by the selected attempt, sorted by
+	// their score from the highest to the lowest, up to NTopCandidates.
+	Candidates []PlannedCandidate
+
+	// Removals are the elements that would be removed from the document by
+	// the selected attempt, and while the document is prepared.
+	Removals []PlannedRemoval
+
+	// Attempts describes each run of the content grabber.
+	Attempts []Attempt
+}
+
+// PlannedCandidate is an element considered as the container of the content.
+type PlannedCandidate struct {
+	Node  *html.Node
+	Score float64
+}
+
+// PlannedRemoval is an element that would be removed from the document.
+type PlannedRemoval struct {
+	Node *html.Node
+	Removal
+}
+
+// plannedScore is the score of a candidate of an attempt, identified by the
+// position of the element in the analyzed document.
+type plannedScore struct {
+	index int
+	score float64
+}
+
+// planIndex returns the position of the element in the document analyzed by
+// Plan, or -1 if the element was created by the parser.
+func planIndex(node *html.Node) int {
+	index, err := strconv.Atoi(getAttribute(node, planAttr))
+
+	if err != nil {
+		return -1
+	}
+
+	return index
+}
+
+// recordCandidates saves the top candidates of the current attempt when the
+// document is analyzed by Plan.
+func (r *Readability) recordCandidates(candidates []*html.Node, topCandidate *html.Node) ([]plannedScore, int) {
+	if !r.planning {
+		return nil, -1
+	}
+
+	var scores []plannedScore
+
+	for _, candidate := range candidates {
+		if index := planIndex(candidate); index != -1 {
+			scores = append(scores, plannedScore{index: index, score: r.getContentScore(candidate)})
+		}
+	}
+
+	return scores, planIndex(topCandidate)
+}
+
+// Plan analyzes the document like Parse, but without modifying it, and returns
+// the candidates, their scores, the selected content and the elements that
+// would be removed. It is meant for editorial tools that highlight the parts
+// of a page before the extraction is applied.
+func (r *Readability) Plan(doc *html.Node, pageURL string) (*Plan, error) {
+	reportRemovals := r.ReportRemovals
+	r.ReportRemovals = true
+
+	defer func() {
+		r.ReportRemovals = reportRemovals
+	}()
+
+	result, elements, err := r.analyzeTraced(doc, pageURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Attempts: result.Attempts}
+
+	element := func(index int) *html.Node {
+		if index < 0 || index >= len(elements) {
+			return nil
+		}
+
+		return elements[index]
+	}
+
+	plan.Content = r.tracedContent(elements)
+
+	if r.selectedAttempt >= 0 {
+		for _, candidate := range r.attempts[r.selectedAttempt].candidates {
+			plan.Candidates = append(plan.Candidates, PlannedCandidate{
+				Node:  element(candidate.index),
+				Score: candidate.score,
+			})
+		}
+	}
+
+	for _, removal := range r.selectedRemovals() {
+		if node := element(removal.index); node != nil {
+			plan.Removals = append(plan.Removals, PlannedRemoval{Node: node, Removal: removal})
+		}
+	}
+
+	return plan, nil
+}
+
+// analyzeTraced analyzes a copy of the document whose elements are numbered,
+// so the decisions of the parser can be traced back to the elements of doc,
+// which are returned in document order.
+func (r *Readability) analyzeTraced(doc *html.Node, pageURL string) (*Result, []*html.Node, error) {
+	if doc == nil {
+		return nil, nil, ErrNilNode
+	}
+
+	elements := getElementsByTagName(doc, "*")
+	clone := cloneNode(doc)
+
+	for i, node := range getElementsByTagName(clone, "*") {
+		setAttribute(node, planAttr, strconv.Itoa(i))
+	}
+
+	r.planning = true
+
+	defer func() {
+		r.planning = false
+	}()
+
+	result, err := r.analyzeDocument(documentFromNode(clone), pageURL, time.Now())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, elements, nil
+}
+
+// tracedContent returns the element of the traced document selected as the
+// top candidate, or nil if the content was taken from the whole body.
+func (r *Readability) tracedContent(elements []*html.Node) *html.Node {
+	if r.selectedAttempt < 0 {
+		return nil
+	}
+
+	index := r.attempts[r.selectedAttempt].topCandidate
+
+	if index < 0 || index >= len(elements) {
+		return nil
+	}
+
+	return elements[index]
+}
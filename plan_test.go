@@ -0,0 +1,61 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestPlan(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<div class="sidebar">Popular stories</div>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<aside>Related: Duis aute irure dolor in reprehenderit</aside>
+			</article>
+		</body>
+		</html>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	before := outerHTML(doc)
+	plan, err := New().Plan(doc, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if outerHTML(doc) != before {
+		t.Fatalf("the document was modified:\n%s", outerHTML(doc))
+	}
+
+	if plan.Content == nil || tagName(plan.Content) != "article" {
+		t.Fatalf("unexpected content: %#v", plan.Content)
+	}
+
+	if len(plan.Candidates) == 0 || plan.Candidates[0].Node != plan.Content {
+		t.Fatalf("the content is not the top candidate: %#v", plan.Candidates)
+	}
+
+	removed := map[string]*html.Node{}
+
+	for _, removal := range plan.Removals {
+		removed[removal.Tag] = removal.Node
+	}
+
+	if node := removed["div"]; node == nil || className(node) != "sidebar" || node.Parent == nil {
+		t.Fatalf("the sidebar was not planned for removal: %#v", plan.Removals)
+	}
+
+	if node := removed["aside"]; node == nil || node.Parent != plan.Content {
+		t.Fatalf("the aside was not planned for removal: %#v", plan.Removals)
+	}
+}
@@ -0,0 +1,87 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ContentStats describes the content found by one attempt of the grabber, see
+// ContentPolicy.
+type ContentStats struct {
+	// Attempt is the index of the pass of the grabber, 0 for the first pass,
+	// which uses all the heuristics, and up to 3 for the last one.
+	Attempt int
+
+	// Threshold is the minimum length of the text for the pass, taken from
+	// AttemptThresholds or CharThresholds.
+	Threshold int
+
+	// TextLength is the amount of characters of the content.
+	TextLength int
+
+	// Paragraphs is the number of paragraphs of the content with text.
+	Paragraphs int
+
+	// Images is the number of images of the content.
+	Images int
+}
+
+// ContentPolicy decides if the content found by one attempt of the grabber is
+// the article. When it returns false, the grabber tries again with relaxed
+// heuristics. For example, to accept photo essays with little text:
+//
+//	parser.ContentPolicy = func(stats readability.ContentStats) bool {
+//		return stats.TextLength >= stats.Threshold ||
+//			stats.Paragraphs >= 3 ||
+//			(stats.Images > 0 && stats.TextLength >= 200)
+//	}
+type ContentPolicy func(stats ContentStats) bool
+
+// charThreshold returns the minimum length of the text for the pass of the
+// grabber. Passes without an entry in AttemptThresholds use the last one.
+func (r *Readability) charThreshold(attempt int) int {
+	if len(r.AttemptThresholds) == 0 && r.ShortForm && r.CharThresholds > shortFormCharThreshold {
+		return shortFormCharThreshold
+	}
+
+	if len(r.AttemptThresholds) == 0 {
+		return r.CharThresholds
+	}
+
+	if attempt >= len(r.AttemptThresholds) {
+		attempt = len(r.AttemptThresholds) - 1
+	}
+
+	return r.AttemptThresholds[attempt]
+}
+
+// attemptSucceeded returns true if the content found by the pass of the
+// grabber is the article, according to the ContentPolicy or, by default, if
+// its text is long enough. Galleries are always accepted, see DetectGalleries.
+func (r *Readability) attemptSucceeded(articleContent *html.Node, attempt int, textLength int) bool {
+	threshold := r.charThreshold(attempt)
+
+	if r.DetectGalleries && isGallery(articleContent) {
+		return true
+	}
+
+	if r.ContentPolicy == nil {
+		return textLength >= threshold
+	}
+
+	stats := ContentStats{
+		Attempt:    attempt,
+		Threshold:  threshold,
+		TextLength: textLength,
+		Images:     len(getElementsByTagName(articleContent, "img")),
+	}
+
+	for _, p := range getElementsByTagName(articleContent, "p") {
+		if strings.TrimSpace(textContent(p)) != "" {
+			stats.Paragraphs++
+		}
+	}
+
+	return r.ContentPolicy(stats)
+}
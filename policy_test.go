@@ -0,0 +1,78 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCharThreshold(t *testing.T) {
+	parser := New()
+
+	if threshold := parser.charThreshold(2); threshold != 500 {
+		t.Fatalf("expecting CharThresholds without AttemptThresholds; got %d", threshold)
+	}
+
+	parser.AttemptThresholds = []int{1000, 300}
+
+	for attempt, expected := range []int{1000, 300, 300, 300} {
+		if threshold := parser.charThreshold(attempt); threshold != expected {
+			t.Fatalf("charThreshold(%d) = %d, expecting %d", attempt, threshold, expected)
+		}
+	}
+}
+
+const photoEssayPage = `<html>
+	<head><title>hello world</title></head>
+	<body>
+		<article>
+			<h1>Winter in the mountains</h1>
+			<p>We spent a week in the village at the foot of the pass, waiting for the storm to clear, and these are the photos of the days after it.</p>
+			<figure><img src="/one.jpg" alt="Snow on the peaks"><figcaption>Snow on the peaks at dawn, before the first skiers arrive.</figcaption></figure>
+			<figure><img src="/two.jpg" alt="Frozen lake"><figcaption>The frozen lake below the village, seen from the old bridge.</figcaption></figure>
+			<figure><img src="/three.jpg" alt="Cabin"><figcaption>A cabin at the end of the trail, buried under a meter of snow.</figcaption></figure>
+		</article>
+	</body>
+</html>`
+
+func TestContentPolicy(t *testing.T) {
+	var stats []ContentStats
+
+	parser := New()
+	parser.ContentPolicy = func(s ContentStats) bool {
+		stats = append(stats, s)
+		return s.Images > 0 && s.TextLength >= 100
+	}
+
+	res, err := parser.Analyze(strings.NewReader(photoEssayPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(res.Attempts) != 1 || !res.Attempts[0].Selected {
+		t.Fatalf("expecting the first attempt to be accepted: %#v", res.Attempts)
+	}
+
+	if len(stats) != 1 || stats[0].Attempt != 0 || stats[0].Threshold != 500 || stats[0].Images != 3 {
+		t.Fatalf("unexpected content stats: %#v", stats)
+	}
+
+	if !strings.Contains(res.HTML(), "three.jpg") {
+		t.Fatalf("missing images in the content:\n%s", res.HTML())
+	}
+}
+
+func TestAttemptThresholds(t *testing.T) {
+	parser := New()
+	parser.AttemptThresholds = []int{100}
+
+	res, err := parser.Analyze(strings.NewReader(photoEssayPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(res.Attempts) != 1 {
+		t.Fatalf("expecting the first attempt to reach the threshold: %#v", res.Attempts)
+	}
+}
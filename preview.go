@@ -0,0 +1,88 @@
+package readability
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// PreviewCard is the summary of a page shown by chat applications and social
+// networks when a link to the page is shared.
+type PreviewCard struct {
+	// Title is the title of the page.
+	Title string
+
+	// Description is the summary of the page, as declared in its metadata.
+	Description string
+
+	// ImageURL is the absolute URL of the image representing the page.
+	ImageURL string
+
+	// SiteName is the name of the website, or its host name if the page
+	// does not declare one.
+	SiteName string
+
+	// CanonicalURL is the preferred address of the page.
+	CanonicalURL string
+
+	// ThemeColor is the color of the website, as declared with the
+	// theme-color or the msapplication-TileColor meta tags, to decorate
+	// the card.
+	ThemeColor string
+}
+
+// Preview reads the metadata of the document, without looking for the content
+// of the article, and returns the card describing the page.
+func (r *Readability) Preview(input io.Reader, pageURL string) (PreviewCard, error) {
+	doc, err := html.Parse(input)
+
+	if err != nil {
+		return PreviewCard{}, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	metadata, err := r.extractMetadata(doc, pageURL)
+
+	if err != nil {
+		return PreviewCard{}, err
+	}
+
+	return metadata.PreviewCard(), nil
+}
+
+// PreviewCard returns the card describing the page.
+func (m Metadata) PreviewCard() PreviewCard {
+	card := PreviewCard{
+		Title:        m.Title,
+		Description:  m.Excerpt,
+		ImageURL:     m.Image,
+		SiteName:     m.SiteName,
+		CanonicalURL: m.CanonicalURL,
+		ThemeColor:   m.ThemeColor,
+	}
+
+	if card.CanonicalURL == "" {
+		card.CanonicalURL = m.URL
+	}
+
+	if card.ThemeColor == "" {
+		card.ThemeColor = m.TileColor
+	}
+
+	if card.SiteName == "" {
+		card.SiteName = siteHostname(card.CanonicalURL)
+	}
+
+	return card
+}
+
+// siteHostname returns the host name of the URL without the www prefix.
+func siteHostname(pageURL string) string {
+	if uri, err := url.Parse(pageURL); err == nil {
+		return strings.TrimPrefix(uri.Hostname(), "www.")
+	}
+
+	return ""
+}
@@ -0,0 +1,36 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+	input := `<html><head>
+		<title>Parsing HTML in Go</title>
+		<meta name="description" content="How to parse HTML documents.">
+		<meta property="og:image" content="/images/cover.png">
+		<meta name="theme-color" media="(prefers-color-scheme: dark)" content="#000000">
+		<meta name="theme-color" media="(prefers-color-scheme: light)" content="#ffffff">
+		<link rel="canonical" href="/blog/go">
+	</head><body><p>The content is ignored.</p></body></html>`
+
+	card, err := New().Preview(strings.NewReader(input), "https://www.cixtor.com/blog/go?utm_source=chat")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := PreviewCard{
+		Title:        "Parsing HTML in Go",
+		Description:  "How to parse HTML documents.",
+		ImageURL:     "https://www.cixtor.com/images/cover.png",
+		SiteName:     "cixtor.com",
+		CanonicalURL: "https://www.cixtor.com/blog/go",
+		ThemeColor:   "#ffffff",
+	}
+
+	if card != expected {
+		t.Fatalf("unexpected card:\n%#v\n%#v", card, expected)
+	}
+}
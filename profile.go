@@ -0,0 +1,111 @@
+package readability
+
+// Profile is a set of adjustments of the options of the parser for a genre of
+// documents, see ApplyProfile.
+type Profile int
+
+const (
+	// ProfileNews is tuned for news articles: it removes pull-quotes, ad
+	// slots, consent overlays, newsletter sign-ups and tracking pixels.
+	ProfileNews Profile = iota
+
+	// ProfileBlog is tuned for blog posts, which are shorter than the news
+	// articles and often split into many small blocks. Like ProfileNews, it
+	// removes ad slots, consent overlays, newsletter sign-ups and tracking
+	// pixels.
+	ProfileBlog
+
+	// ProfileWiki is tuned for wikis: it keeps the footnotes and the data
+	// tables, and penalizes the navigation boxes and the edit links.
+	ProfileWiki
+
+	// ProfileDocs is tuned for documentation pages: it unwraps the forms
+	// wrapping the content, expands the collapsible sections, and penalizes
+	// the tables of contents and the navigation of the documentation.
+	ProfileDocs
+
+	// ProfileForum is tuned for forum threads, where the posts look like the
+	// comments of other genres.
+	ProfileForum
+)
+
+// String returns the name of the profile.
+func (p Profile) String() string {
+	switch p {
+	case ProfileNews:
+		return "news"
+	case ProfileBlog:
+		return "blog"
+	case ProfileWiki:
+		return "wiki"
+	case ProfileDocs:
+		return "docs"
+	case ProfileForum:
+		return "forum"
+	}
+
+	return "unknown"
+}
+
+// ApplyProfile adjusts the options of the parser for the given genre. The
+// options not related to the genre are left as they are, and the options set
+// by the profile can still be modified afterwards.
+func (r *Readability) ApplyProfile(profile Profile) {
+	if r.ClassWeights == nil {
+		r.ClassWeights = DefaultClassWeights()
+	}
+
+	switch profile {
+	case ProfileNews:
+		r.CharThresholds = 500
+		r.RemovePullQuotes = true
+		r.RemoveAdSlots = true
+		r.RemoveConsentOverlays = true
+		r.RemoveNewsletters = true
+		r.RemoveTrackingPixels = true
+
+	case ProfileBlog:
+		r.CharThresholds = 250
+		r.ConvertBulletParagraphs = true
+		r.RemoveAdSlots = true
+		r.RemoveConsentOverlays = true
+		r.RemoveNewsletters = true
+		r.RemoveTrackingPixels = true
+
+	case ProfileWiki:
+		r.CharThresholds = 500
+		r.NormalizeTables = true
+		delete(r.ClassWeights, "footnote")
+		r.ClassWeights["mw-parser-output"] = 25
+		r.ClassWeights["mw-body-content"] = 25
+		r.ClassWeights["navbox"] = -50
+		r.ClassWeights["mw-editsection"] = -50
+		r.ClassWeights["catlinks"] = -25
+		r.ClassWeights["printfooter"] = -25
+
+	case ProfileDocs:
+		r.CharThresholds = 250
+		r.Forms = FormsUnwrap
+		r.ExpandDetails = true
+		r.NormalizeTables = true
+		r.ClassWeights["markdown-body"] = 25
+		r.ClassWeights["rst-content"] = 25
+		r.ClassWeights["documentation"] = 25
+		r.ClassWeights["toc"] = -25
+		r.ClassWeights["breadcrumb"] = -25
+		r.ClassWeights["edit-page"] = -25
+
+	case ProfileForum:
+		r.CharThresholds = 100
+		delete(r.ClassWeights, "comment")
+		r.ClassWeights["postbody"] = 25
+		r.ClassWeights["post-content"] = 25
+		r.ClassWeights["message"] = 25
+		r.ClassWeights["signature"] = -25
+		r.ClassWeights["userinfo"] = -25
+
+		if indexOf(r.UnlikelyExceptions, "comment") == -1 {
+			r.UnlikelyExceptions = append(r.UnlikelyExceptions, "comment")
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyProfile(t *testing.T) {
+	parser := New()
+	parser.ApplyProfile(ProfileDocs)
+
+	if parser.Forms != FormsUnwrap || !parser.ExpandDetails || parser.CharThresholds != 250 {
+		t.Fatalf("docs profile was not applied: %#v", parser)
+	}
+
+	if parser.ClassWeights["toc"] != -25 || parser.ClassWeights["post"] != 25 {
+		t.Fatalf("unexpected class weights: %#v", parser.ClassWeights)
+	}
+
+	parser = New()
+	parser.ApplyProfile(ProfileWiki)
+
+	if _, ok := parser.ClassWeights["footnote"]; ok || !parser.NormalizeTables {
+		t.Fatalf("wiki profile was not applied: %#v", parser)
+	}
+
+	parser = New()
+
+	if parser.RemoveAdSlots || parser.RemoveConsentOverlays || parser.RemoveNewsletters || parser.RemoveTrackingPixels {
+		t.Fatalf("the removal passes must be disabled by default: %#v", parser)
+	}
+
+	parser.ApplyProfile(ProfileNews)
+
+	if !parser.RemoveAdSlots || !parser.RemoveConsentOverlays || !parser.RemoveNewsletters || !parser.RemoveTrackingPixels {
+		t.Fatalf("news profile was not applied: %#v", parser)
+	}
+
+	if _, ok := New().ClassWeights["footnote"]; !ok {
+		t.Fatalf("profiles must not modify the default class weights")
+	}
+}
+
+func TestForumProfileComments(t *testing.T) {
+	parser := New()
+	parser.ApplyProfile(ProfileForum)
+	parser.ApplyProfile(ProfileForum)
+
+	if len(parser.UnlikelyExceptions) != 1 {
+		t.Fatalf("unexpected exceptions: %q", parser.UnlikelyExceptions)
+	}
+
+	tests := map[string]bool{
+		"comment-body":   false,
+		"comments":       false,
+		"comment footer": true,
+		"sidebar":        true,
+	}
+
+	for input, expected := range tests {
+		if result := parser.isUnlikelyCandidate(input); result != expected {
+			t.Fatalf("isUnlikelyCandidate(%q) = %t, expecting %t", input, result, expected)
+		}
+	}
+
+	if !New().isUnlikelyCandidate("comment-body") {
+		t.Fatalf("comments are unlikely candidates without the forum profile")
+	}
+
+	input := `<html><head><title>hello world</title></head><body>
+		<div class="thread">
+			<div class="comment-body"><p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p></div>
+			<div class="comment-body"><p>Duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore.</p></div>
+		</div>
+		<div class="sidebar"><p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi.</p></div>
+	</body></html>`
+
+	res, err := parser.Analyze(strings.NewReader(input), "https://cixtor.com/forum")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	text := res.Text()
+
+	if !strings.Contains(text, "Lorem ipsum") || !strings.Contains(text, "Duis aute") || strings.Contains(text, "Ut enim") {
+		t.Fatalf("unexpected content: %q", text)
+	}
+
+	if !res.Attempts[0].Selected || !res.Attempts[0].StripUnlikelys {
+		t.Fatalf("the posts should be found by the first attempt: %#v", res.Attempts)
+	}
+}
@@ -0,0 +1,83 @@
+package readability
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultCommas are the characters counted as commas when the paragraphs are
+// scored: the ASCII comma and the commas of Arabic, CJK and other scripts, as
+// recognized by Readability.js, plus the Arabic semicolon and the ideographic
+// comma.
+const DefaultCommas = "\u002C\u060C\u061B\uFE50\uFE10\uFE11\u2E41\u2E34\u2E32\uFF0C\u3001"
+
+// DefaultSentenceTerminators are the characters ending a sentence: the period,
+// the full stops of CJK, Devanagari and Urdu scripts, and the Arabic question
+// mark.
+const DefaultSentenceTerminators = "\u002E\u3002\uFF0E\uFF61\u0964\u06D4\u061F"
+
+// commas returns the characters counted as commas, see Commas.
+func (r *Readability) commas() string {
+	if r.Commas == "" {
+		return DefaultCommas
+	}
+
+	return r.Commas
+}
+
+// countCommas returns the number of commas in text.
+func (r *Readability) countCommas(text string) int {
+	commas := r.commas()
+
+	// Most documents only use the ASCII comma.
+	if commas == "," {
+		return strings.Count(text, ",")
+	}
+
+	count := 0
+
+	for _, char := range text {
+		if strings.ContainsRune(commas, char) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// hasSentenceEnd determines if text contains the end of a sentence, that is,
+// one of the SentenceTerminators followed by a space or by the end of the
+// text. Full-width terminators, used by scripts without spaces between the
+// sentences, do not need to be followed by a space.
+func (r *Readability) hasSentenceEnd(text string) bool {
+	terminators := r.SentenceTerminators
+
+	if terminators == "" {
+		terminators = DefaultSentenceTerminators
+	}
+
+	for i, char := range text {
+		if !strings.ContainsRune(terminators, char) {
+			continue
+		}
+
+		if isFullWidthPunctuation(char) {
+			return true
+		}
+
+		next := i + len(string(char))
+
+		if next == len(text) || text[next] == ' ' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isFullWidthPunctuation determines if char belongs to the CJK symbols and
+// punctuation block or to the half-width and full-width forms block.
+func isFullWidthPunctuation(char rune) bool {
+	isWide := (char >= 0x3000 && char <= 0x303F) || (char >= 0xFF00 && char <= 0xFFEF)
+	return isWide && unicode.IsPunct(char)
+}
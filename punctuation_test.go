@@ -0,0 +1,70 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountCommas(t *testing.T) {
+	tests := []struct {
+		text  string
+		count int
+	}{
+		{"one, two, three", 2},
+		{"一，二，三、四", 3},
+		{"واحد، اثنان؛ ثلاثة", 2},
+		{"no commas here", 0},
+	}
+
+	for _, tt := range tests {
+		if count := New().countCommas(tt.text); count != tt.count {
+			t.Fatalf("%q: unexpected number of commas: %d", tt.text, count)
+		}
+	}
+
+	parser := New()
+	parser.Commas = ","
+
+	if count := parser.countCommas("一，二, 三"); count != 1 {
+		t.Fatalf("unexpected number of ASCII commas: %d", count)
+	}
+}
+
+func TestHasSentenceEnd(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"A sentence.", true},
+		{"A sentence. Another one", true},
+		{"Version 1.2 released", false},
+		{"这是一个句子。这是另一个", true},
+		{"هل هذا سؤال؟", true},
+		{"No terminator", false},
+	}
+
+	for _, tt := range tests {
+		if ok := New().hasSentenceEnd(tt.text); ok != tt.expected {
+			t.Fatalf("%q: expected %v", tt.text, tt.expected)
+		}
+	}
+}
+
+func TestParseChineseCommas(t *testing.T) {
+	paragraph := strings.Repeat("这是一个很长的句子，用来测试中文的逗号，以及文章内容的评分，", 4) + "。"
+
+	input := `<html><body>
+		<div class="sidebar"><p>` + strings.Repeat("链接 ", 30) + `</p></div>
+		<div class="story"><p>` + paragraph + `</p><p>` + paragraph + `</p><p>` + paragraph + `</p></div>
+	</body></html>`
+
+	res, err := New().Analyze(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(res.Text(), "中文的逗号") || strings.Contains(res.Text(), "链接") {
+		t.Fatalf("unexpected content: %q", res.Text())
+	}
+}
@@ -0,0 +1,123 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var rxPullQuote = regexp.MustCompile(`(?i)pull-?quote|pq-|quote-?(callout|highlight|pull)|callout`)
+
+// pullQuoteMinLength is the minimum length of the text of a pull-quote, which
+// avoids matching short phrases that are naturally repeated in the article.
+const pullQuoteMinLength = 20
+
+// quoteNormalizer removes the characters that are usually added around or
+// inside the sentences repeated as pull-quotes.
+var quoteNormalizer = strings.NewReplacer(
+	"“", "", "”", "", "‘", "", "’", "", "«", "", "»", "",
+	"\"", "", "'", "", "…", "", "—", "", "–", "",
+)
+
+// normalizeQuote prepares text to be compared with other parts of the article.
+func normalizeQuote(text string) string {
+	text = quoteNormalizer.Replace(strings.ToLower(text))
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.Trim(text, " .,;:!?-")
+}
+
+// findPullQuoteTexts returns the normalized text of the elements that look
+// like pull-quotes in the document. The search is done before the content is
+// extracted because the class names that identify them, and sometimes their
+// containers, do not survive the extraction.
+func (r *Readability) findPullQuoteTexts(doc *html.Node) map[string]bool {
+	texts := make(map[string]bool)
+
+	for _, node := range getElementsByTagName(doc, "*") {
+		if !r.isPullQuoteCandidate(node) {
+			continue
+		}
+
+		if text := normalizeQuote(textContent(node)); len(text) >= pullQuoteMinLength {
+			texts[text] = true
+		}
+	}
+
+	return texts
+}
+
+// findPullQuotes returns the decorative pull-quotes in the article content,
+// which are the elements found by findPullQuoteTexts that repeat a sentence
+// of the rest of the content. Quotations with their own text are excluded.
+func (r *Readability) findPullQuotes(articleContent *html.Node, texts map[string]bool) []*html.Node {
+	var quotes []*html.Node
+
+	if len(texts) == 0 {
+		return nil
+	}
+
+	fullText := normalizeQuote(textContent(articleContent))
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		text := normalizeQuote(textContent(node))
+
+		if !texts[text] {
+			continue
+		}
+
+		if len(quotes) > 0 && r.hasAncestorIn(node, quotes) {
+			continue
+		}
+
+		// The text of the candidate is part of the full text, so the
+		// sentence must appear at least twice to be a duplicate.
+		if strings.Count(fullText, text) < 2 {
+			continue
+		}
+
+		quotes = append(quotes, node)
+	}
+
+	return quotes
+}
+
+// isPullQuoteCandidate returns true if node looks like a container that could
+// hold a pull-quote, either by its tag name or by its class and id.
+func (r *Readability) isPullQuoteCandidate(node *html.Node) bool {
+	switch tagName(node) {
+	case "blockquote", "figure", "aside":
+		return getAttribute(node, "cite") == ""
+	case "div", "p", "span":
+		return rxPullQuote.MatchString(r.matchString(node))
+	}
+
+	return false
+}
+
+// hasAncestorIn returns true if one of the ancestors of node is in the list.
+func (r *Readability) hasAncestorIn(node *html.Node, list []*html.Node) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if includeNode(list, parent) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractPullQuotes returns the text of the pull-quotes in the article content
+// and, if RemovePullQuotes is enabled, removes them from the content.
+func (r *Readability) extractPullQuotes(articleContent *html.Node, texts map[string]bool) []string {
+	var pullQuotes []string
+
+	for _, node := range r.findPullQuotes(articleContent, texts) {
+		pullQuotes = append(pullQuotes, strings.Join(strings.Fields(textContent(node)), " "))
+
+		if r.RemovePullQuotes && node.Parent != nil {
+			node.Parent.RemoveChild(node)
+		}
+	}
+
+	return pullQuotes
+}
@@ -0,0 +1,66 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPullQuotes(t *testing.T) {
+	page := `<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+				<blockquote>Ut enim ad minim veniam, quis nostrud exercitation ullamco.</blockquote>
+				<p>Duis aute irure dolor in reprehenderit. The best ideas are the simple ones. Excepteur sint occaecat.</p>
+				<div class="pull-quote">“The best ideas are the simple ones”</div>
+				<p>Sunt in culpa qui officia deserunt mollit anim id est laborum, sed ut perspiciatis.</p>
+			</article>
+		</body>
+		</html>`
+
+	parser := New()
+	a, err := parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(a.PullQuotes) != 1 || a.PullQuotes[0] != "“The best ideas are the simple ones”" {
+		t.Fatalf("unexpected pull-quotes: %#v", a.PullQuotes)
+	}
+
+	if !strings.Contains(a.Content, "<p>“The best ideas") {
+		t.Fatalf("pull-quote should be kept by default: %s", a.Content)
+	}
+
+	parser.RemovePullQuotes = true
+	a, err = parser.Parse(strings.NewReader(page), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Contains(a.Content, "<p>“The best ideas") || !strings.Contains(a.Content, "<blockquote>") {
+		t.Fatalf("only the pull-quote should be removed: %s", a.Content)
+	}
+}
+
+func TestPullQuoteCandidateLimit(t *testing.T) {
+	node := createElement("div")
+	setAttribute(node, "class", strings.Repeat("a", 200)+" pullquote")
+
+	parser := New()
+
+	if !parser.isPullQuoteCandidate(node) {
+		t.Fatal("the class name within the default limit was ignored")
+	}
+
+	parser.MaxMatchStringLength = 100
+
+	if parser.isPullQuoteCandidate(node) {
+		t.Fatal("the class name beyond MaxMatchStringLength was matched")
+	}
+}
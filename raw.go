@@ -0,0 +1,23 @@
+package readability
+
+import (
+	"golang.org/x/net/html"
+)
+
+// getRawContent returns the HTML of the body of the document with the URLs
+// resolved against the address of the document. It is called once the scripts
+// were removed, no other cleaning is done.
+func (r *Readability) getRawContent(doc *html.Node) string {
+	var body *html.Node
+
+	if nodes := getElementsByTagName(doc, "body"); len(nodes) > 0 {
+		body = cloneNode(nodes[0])
+	} else {
+		body = cloneNode(doc)
+	}
+
+	r.fixRelativeURIs(body)
+	clearSourceMarks(body)
+
+	return innerHTML(body)
+}
@@ -0,0 +1,46 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeepRawContent(t *testing.T) {
+	input := `<html><head><title>Raw</title></head><body>
+		<nav class="menu"><a href="/home">Home</a></nav>
+		<article><p>Lorem ipsum dolor sit amet, consectetur adipiscing elit. <img src="cat.png"></p></article>
+		<script>alert("hello")</script>
+	</body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog/")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.RawContent != "" {
+		t.Fatalf("the raw content was set without KeepRawContent: %s", a.RawContent)
+	}
+
+	parser := New()
+	parser.KeepRawContent = true
+	a, err = parser.Parse(strings.NewReader(input), "https://cixtor.com/blog/")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, expected := range []string{`<nav class="menu"><a href="https://cixtor.com/home">Home</a></nav>`, `<img src="https://cixtor.com/blog/cat.png"/>`} {
+		if !strings.Contains(a.RawContent, expected) {
+			t.Fatalf("missing %s in raw content: %s", expected, a.RawContent)
+		}
+	}
+
+	if strings.Contains(a.RawContent, "<script") {
+		t.Fatalf("the scripts were not removed: %s", a.RawContent)
+	}
+
+	if strings.Contains(a.Content, "Home") {
+		t.Fatalf("the clean content includes the menu: %s", a.Content)
+	}
+}
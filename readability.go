@@ -1,6 +1,8 @@
 package readability
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -9,30 +11,35 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cixtor/readability/heuristics"
 	"golang.org/x/net/html"
 )
 
+// ErrNotReadable is returned when the document does not look like an article.
+var ErrNotReadable = errors.New("document is not readable")
+
+// ErrNilNode is returned when a nil node is given instead of a document.
+var ErrNilNode = errors.New("node is nil")
+
 // All of the regular expressions in use within readability.
 // Defined up here so we don't instantiate them repeatedly in loops.
 var rxUnlikelyCandidates = regexp.MustCompile(`(?i)-ad-|ai2html|banner|breadcrumbs|combx|comment|community|cover-wrap|disqus|extra|foot|gdpr|header|legends|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|ad-break|agegate|pagination|pager|popup|yom-remote`)
 var rxOkMaybeItsACandidate = regexp.MustCompile(`(?i)and|article|body|column|main|shadow`)
-var rxPositive = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`)
-var rxNegative = regexp.MustCompile(`(?i)hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
 var rxByline = regexp.MustCompile(`(?i)byline|author|dateline|writtenby|p-author`)
 var rxNormalize = regexp.MustCompile(`(?i)\s{2,}`)
 var rxVideos = regexp.MustCompile(`(?i)//(www\.)?((dailymotion|youtube|youtube-nocookie|player\.vimeo|v\.qq)\.com|(archive|upload\.wikimedia)\.org|player\.twitch\.tv)`)
 var rxWhitespace = regexp.MustCompile(`(?i)^\s*$`)
 var rxHasContent = regexp.MustCompile(`(?i)\S$`)
-var rxPropertyPattern = regexp.MustCompile(`(?i)\s*(dc|dcterm|og|twitter)\s*:\s*(author|creator|description|title|site_name|image\S*)\s*`)
-var rxNamePattern = regexp.MustCompile(`(?i)^\s*(?:(dc|dcterm|og|twitter|weibo:(article|webpage))\s*[\.:]\s*)?(author|creator|description|title|site_name|image)\s*$`)
+var rxPropertyPattern = regexp.MustCompile(`(?i)\s*(article|dc|dcterm|og|twitter)\s*:\s*(author|creator|description|published_time|title|site_name|image\S*)\s*`)
+var rxNamePattern = regexp.MustCompile(`(?i)^\s*(?:(dc|dcterm|og|twitter|parsely|weibo:(article|webpage))\s*[-\.:]\s*)?(author|creator|pub-date|description|title|site_name|image)\s*$`)
 var rxTitleSeparator = regexp.MustCompile(`(?i) [\|\-\\/>»] `)
 var rxTitleHierarchySep = regexp.MustCompile(`(?i) [\\/>»] `)
 var rxTitleRemoveFinalPart = regexp.MustCompile(`(?i)(.*)[\|\-\\/>»] .*`)
 var rxTitleRemove1stPart = regexp.MustCompile(`(?i)[^\|\-\\/>»]*[\|\-\\/>»](.*)`)
 var rxTitleAnySeparator = regexp.MustCompile(`(?i)[\|\-\\/>»]+`)
 var rxDisplayNone = regexp.MustCompile(`(?i)display\s*:\s*none`)
-var rxSentencePeriod = regexp.MustCompile(`(?i)\.( |$)`)
 var rxShare = regexp.MustCompile(`(?i)share`)
 var rxFaviconSize = regexp.MustCompile(`(?i)(\d+)x(\d+)`)
 
@@ -42,6 +49,9 @@ var divToPElems = []string{
 	"ol", "p", "pre", "select", "table", "ul",
 }
 
+// modalRoles is a list of ARIA roles of the dialogs rendered over the page.
+var modalRoles = []string{"dialog", "alertdialog"}
+
 // alterToDivExceptions is a list of HTML tags that we want to convert into
 // regular DIV elements to prevent unwanted removal when the parser is cleaning
 // out unnecessary Nodes.
@@ -101,6 +111,22 @@ type flags struct {
 type parseAttempt struct {
 	articleContent *html.Node
 	textLength     int
+	flags          flags
+
+	// candidates and topCandidate identify the scored elements when the
+	// document is analyzed by Plan, see planIndex.
+	candidates   []plannedScore
+	topCandidate int
+
+	// topCandidatePath is the path of the top candidate, see reportPath.
+	topCandidatePath string
+
+	// learned is true if the content was found at the path remembered in the
+	// SelectorStore, see learnedArticle.
+	learned bool
+
+	// succeeded is true if the content was accepted, see attemptSucceeded.
+	succeeded bool
 }
 
 // Article represents the metadata and content of the article.
@@ -142,11 +168,131 @@ type Article struct {
 	// Image is an image URL which represents the article’s content.
 	Image string
 
+	// PublishedTime is the date when the article was published, as found in
+	// the metadata of the document or, as a fallback, in the first <time>
+	// element of the content or in the byline. It is returned as written, no
+	// attempt is made to parse it.
+	PublishedTime string
+
+	// Images is the list of images found in the content of the article.
+	Images []ImageMeta
+
+	// Gallery is true if the content is a gallery or a photo essay, made
+	// mostly of images with captions, see DetectGalleries.
+	Gallery bool
+
+	// CommentCount is the number of comments of the article, as declared in
+	// the schema.org metadata or in the meta tags of the document.
+	CommentCount int
+
+	// InteractionCounts maps the schema.org actions, like "LikeAction" or
+	// "ShareAction", to the number of times users performed them, as found
+	// in the interactionStatistic property of the article.
+	InteractionCounts map[string]int
+
+	// Updates is the list of timestamped entries of a live blog, in the same
+	// order found in the document. It is empty for regular articles.
+	Updates []LiveUpdate
+
+	// Transcript is the list of blocks of an interview transcript or of the
+	// subtitles of a video, in the same order found in the document, with
+	// the name of the speaker of each block. It is empty for other articles.
+	Transcript []TranscriptLine
+
+	// PullQuotes is the list of decorative quotes found in the content of the
+	// article, which repeat a sentence of the article to highlight it.
+	PullQuotes []string
+
+	// Quotes is the list of blockquotes found in the content of the article,
+	// with the source and the author of each one of them.
+	Quotes []Quote
+
+	// Tables is the list of data tables found in the content of the article,
+	// as opposed to the tables used to lay out the page.
+	Tables []TableData
+
+	// Times is the list of <time> elements found in the content of the
+	// article, with their machine-readable datetime attribute, in the same
+	// order found in the document.
+	Times []TimeMeta
+
+	// SourcePositions are the positions in the original document of the
+	// top-level blocks of the content, in the same order. It is only set
+	// when RecordSourcePositions is enabled.
+	SourcePositions []SourcePosition
+
 	// Length is the amount of characters in the article.
 	Length int
 
+	// Hash is the SHA-256, in hexadecimal, of the text of the article in
+	// lower case and with the white space collapsed, so the same article
+	// has the same hash regardless of the markup around the text.
+	Hash string
+
+	// SimHash is a 64-bit fingerprint of the text of the article, computed
+	// from shingles of three words. Unlike Hash, similar articles have close
+	// fingerprints, see SimHashDistance.
+	SimHash uint64
+
+	// URL is the address of the document, used to resolve the relative URLs
+	// of the content. For ParseURL, it is the address of the page after all
+	// the redirects were followed.
+	URL string
+
+	// RequestedURL is the address given to the parser, which is different
+	// from URL if ParseURL was redirected to another page.
+	RequestedURL string
+
+	// ThemeColor is the color suggested by the document to decorate the user
+	// interface around it, as declared with the theme-color meta tag.
+	ThemeColor string
+
+	// TileColor is the background color of the tile of the website in the
+	// Windows start screen, declared with the msapplication-TileColor tag.
+	TileColor string
+
+	// Logo is the absolute URL of the logo of the publisher, as declared in
+	// the JSON-LD data or with the og:logo property.
+	Logo string
+
+	// RelLinks are the links of the document to the previous and the next
+	// pages, to the translations and to the alternate versions of the page.
+	RelLinks []RelLink
+
+	// Translations maps the languages, like "de" or "pt-BR", to the URLs of
+	// the translations of the page declared with hreflang links.
+	Translations map[string]string
+
+	// OEmbedURLs are the oEmbed endpoints declared by the document, which
+	// describe how to embed the page in another one.
+	OEmbedURLs []string
+
+	// OEmbed is the response of the first oEmbed endpoint that responds with
+	// JSON. It is only fetched when FetchOEmbed is enabled.
+	OEmbed *OEmbed
+
+	// RawContent is the whole body of the document with the scripts removed
+	// and the URLs resolved, without any other cleaning. It is only set when
+	// KeepRawContent is enabled.
+	RawContent string
+
+	// NoIndex is true if the document asks search engines not to index it,
+	// with the robots meta tag or, for ParseURL, the X-Robots-Tag header.
+	NoIndex bool
+
+	// NoArchive is true if the document asks not to be stored, so archiving
+	// pipelines can skip it. See RespectNoarchive.
+	NoArchive bool
+
 	// Node is the first element in the HTML document.
 	Node *html.Node
+
+	// content is the element that wraps the content of the article, used to
+	// stream the content with WriteContent and WriteText.
+	content *html.Node
+
+	// transforms are the functions applied to the plain text of the article.
+	transforms []TextTransform
 }
 
 // Readability is an HTML parser that reads and extract relevant content.
@@ -158,6 +304,42 @@ type Readability struct {
 	attempts      []parseAttempt
 	flags         flags
 
+	// bylineNode is a copy of the element of the byline, kept to be inserted
+	// back into the content when KeepBylineInContent is enabled.
+	bylineNode *html.Node
+
+	// localizedBylineTokens are the byline tokens of the language packs used
+	// by the current parse, see bylineTokens.
+	localizedBylineTokens []string
+
+	// removals is the report of the elements removed by the current parse,
+	// collected when ReportRemovals is enabled.
+	removals []Removal
+
+	// planning is true while the document is analyzed by Plan.
+	planning bool
+
+	// grabbing is true while grabArticle runs, to tell the removals of each
+	// attempt apart from the ones done while the document is prepared.
+	grabbing bool
+
+	// texts memoizes the normalized text of the nodes while the document is
+	// scored, see startTextCache.
+	texts map[*html.Node]string
+
+	// source is the serialized document read by analyze, and sourceNodes the
+	// elements of the original document, see markSourcePositions.
+	source      []byte
+	sourceNodes []sourceNode
+
+	// anchorTargets maps the IDs referenced by the fragment links of the
+	// current document to the text of their targets, see findAnchorTargets.
+	anchorTargets map[string]string
+
+	// selectedAttempt is the index of the attempt whose content was returned
+	// by the last call to grabArticle, or -1 if no content was found.
+	selectedAttempt int
+
 	// MaxElemsToParse is the optional maximum number of HTML nodes to parse
 	// from the document. If the number of elements in the document is higher
 	// than this number, the operation immediately errors.
@@ -171,6 +353,15 @@ type Readability struct {
 	// order to return a result.
 	CharThresholds int
 
+	// AttemptThresholds overrides CharThresholds for each pass of the grabber,
+	// in order, so the passes with relaxed heuristics can require more, or
+	// less, text. The last value is used for the remaining passes.
+	AttemptThresholds []int
+
+	// ContentPolicy decides if a pass of the grabber found the article,
+	// instead of comparing the length of the text with the threshold.
+	ContentPolicy ContentPolicy
+
 	// ClassesToPreserve are the classes that readability sets itself.
 	ClassesToPreserve []string
 
@@ -178,17 +369,344 @@ type Readability struct {
 	TagsToScore []string
 
 	KeepClasses bool
+
+	// AllowedAttributes are the attributes kept in the content in addition to
+	// the default ones, which are the attributes needed by links, images,
+	// media, tables and text semantics. Every other attribute is removed. An
+	// entry ending with an asterisk, like "data-*", allows every attribute
+	// with the same prefix.
+	AllowedAttributes []string
+
+	// BeforePrep is called with the document before it is prepared, while
+	// it still has its scripts, styles and hidden elements.
+	BeforePrep Hook
+
+	// AfterGrab is called with the content of the article once it has been
+	// selected and cleaned, before it is post-processed.
+	AfterGrab Hook
+
+	// AfterPostProcess is called with the final content of the article,
+	// with its URLs resolved and its attributes removed.
+	AfterPostProcess Hook
+
+	// KeepBylineInContent inserts the byline as the first paragraph of the
+	// content. By default, the byline is removed from the content and only
+	// returned in the Byline field of the article.
+	KeepBylineInContent bool
+
+	// IncludeHeroImage prepends the image of the article, as declared in the
+	// metadata of the document, to the content when the image is shown in
+	// the document but outside of the content, together with its caption.
+	IncludeHeroImage bool
+
+	// CanonicalizeLinks rewrites the links of the content that point to the
+	// AMP viewer of Google, to the AMP cache or to the web cache of Google,
+	// so they point to the pages behind them instead.
+	CanonicalizeLinks bool
+
+	// Fetcher is used by ParseURL, and other features that need to download
+	// resources, to send HTTP requests. If nil, http.DefaultClient is used.
+	Fetcher Fetcher
+
+	// FetchOEmbed downloads, with the Fetcher, the response of the oEmbed
+	// endpoints declared by the document, see Article.OEmbed.
+	FetchOEmbed bool
+
+	// FollowMetaRefresh makes ParseURL download the page a document redirects
+	// to with a <meta http-equiv="refresh"> element instead of returning a
+	// RedirectError, following at most five redirects.
+	FollowMetaRefresh bool
+
+	// ProbeImages enables HTTP requests, sent with the Fetcher, to verify the
+	// images found in the metadata. If the document declares more than one
+	// image, the largest one that loads is used. The favicon is dropped if
+	// it does not load.
+	ProbeImages bool
+
+	// RemoveTrackingPixels removes the images used by analytics services to
+	// track visitors, recognized by their tiny size or by their URL.
+	RemoveTrackingPixels bool
+
+	// StripIcons replaces the images of emoji, recognized by their class
+	// name or by the URL of the common emoji CDNs, with their alternative
+	// text, and removes the decorative inline SVG icons, which otherwise
+	// pollute the images, the word count and the speech of the article.
+	StripIcons bool
+
+	// GenerateAltText adds an alternative text to the images of the content
+	// that have none, taken from the caption of the figure, from the title
+	// attribute or from the words of the file name of the image, so screen
+	// readers have something to announce. Decorative images, with an empty
+	// alternative text, are left as they are.
+	GenerateAltText bool
+
+	// AuditAccessibility checks the content of the article for the common
+	// accessibility problems, like images without an alternative text,
+	// headings that skip levels and data tables without header cells, and
+	// reports them in the AccessibilityIssues of the Result of Analyze.
+	AuditAccessibility bool
+
+	// CheckInvariants validates the tree of the document after each stage
+	// of the parser, and returns an InvariantError if a stage left a node
+	// with two parents, a cycle or broken links between siblings. It is
+	// meant for debugging, the check visits every node of the document.
+	CheckInvariants bool
+
+	// RepairMarkup rebalances the structures left by the unclosed tags of
+	// legacy pages before the document is scored: the inline elements, like
+	// <font>, that contain blocks are unwrapped, and the <div> elements that
+	// were nested inside of the previous one are turned into siblings.
+	RepairMarkup bool
+
+	// RemoveAdSlots removes the placeholders of ads injected into the content,
+	// recognized by the attributes registered with RegisterAdPattern.
+	RemoveAdSlots bool
+
+	// RemoveConsentOverlays removes the cookie banners and consent dialogs
+	// rendered into the document by consent management platforms.
+	RemoveConsentOverlays bool
+
+	// RemoveNewsletters removes the forms and short blocks inviting the reader
+	// to subscribe to a newsletter, recognized by their class names and by
+	// the NewsletterPatterns found in their text.
+	RemoveNewsletters bool
+
+	// MaxInlineImageBytes is the maximum size of the images embedded in the
+	// content with data URIs. Larger images are stripped from the content,
+	// leaving their alternative text as a placeholder. Zero means no limit.
+	MaxInlineImageBytes int
+
+	// RemovePullQuotes removes the decorative quotes that repeat a sentence
+	// of the article from the content. They are still available in the list
+	// of PullQuotes of the article.
+	RemovePullQuotes bool
+
+	// EmbedImages downloads the images of the article with the Fetcher to get
+	// self-contained content for offline reading. The images are saved in the
+	// AssetStore or, if there is no store, embedded in the content as data
+	// URIs. Images larger than MaxInlineImageBytes are not downloaded in full
+	// and keep their original URL.
+	EmbedImages bool
+
+	// AssetStore is where EmbedImages saves the downloaded images.
+	AssetStore AssetStore
+
+	// ContentEncoding declares the encoding of the input given to Parse and
+	// ParseReaderAt, for example "gzip" or "br", so compressed pages stored
+	// on disk can be parsed without decoding them first. ParseURL ignores
+	// this option and uses the Content-Encoding header of the response.
+	ContentEncoding string
+
+	// ReadablePrefixSize is the number of bytes that ParseReaderAt inspects
+	// to decide whether the document is worth parsing in full.
+	ReadablePrefixSize int64
+
+	// RenderedDOM must be enabled when the input is a snapshot of the DOM
+	// taken from a headless browser, instead of the HTML sent by the server.
+	// The attributes added by client-side frameworks are removed before the
+	// document is analysed, declarative shadow roots are unwrapped, and the
+	// inline styles are not used to decide if an element is visible.
+	RenderedDOM bool
+
+	// DetectAppShell makes the parser fail with ErrClientSideRendered when the
+	// document is the empty shell of an application rendered in the browser.
+	DetectAppShell bool
+
+	// KeepRawContent sets the RawContent of the article, a minimally cleaned
+	// copy of the document, so archiving pipelines can store it next to the
+	// reader version of the content without parsing the document twice.
+	KeepRawContent bool
+
+	// OmitContentStrings leaves the Content and TextContent fields of the
+	// article empty, to avoid holding a copy of very large articles in
+	// memory. Use WriteContent and WriteText to serialize the content.
+	OmitContentStrings bool
+
+	// RespectNoarchive makes the parser fail with ErrNoArchive when the
+	// document asks not to be stored with the noarchive robots directive.
+	RespectNoarchive bool
+
+	// TextTransforms is an optional list of functions applied, in order, to
+	// the plain text version of the article. Use NormalizeTypography to get
+	// straight quotes, plain dashes and ellipses in TextContent.
+	TextTransforms []TextTransform
+
+	// ReadMorePrefixes are the phrases, like "Read more" or "See also", that
+	// introduce links to other articles inside the content. Blocks starting
+	// with one of them and containing only links are removed.
+	ReadMorePrefixes []string
+
+	// NewsletterPatterns are the phrases, like "Sign up for our newsletter",
+	// used to promote newsletters. Short blocks containing one of them are
+	// removed from the content together with their subscription forms.
+	NewsletterPatterns []string
+
+	// TrimBoilerplate removes the syndication footers found in the last short
+	// blocks of the content, like "This article originally appeared on…",
+	// copyright lines and links to the social networks of the website.
+	TrimBoilerplate bool
+
+	// BoilerplatePatterns are the expressions matching the text of the footers
+	// removed by TrimBoilerplate. Use CompilePattern for the expressions that
+	// come from untrusted sources.
+	BoilerplatePatterns []*regexp.Regexp
+
+	// ClassWeights maps the words found in class names and IDs to the weight
+	// they add to the score of an element. Positive weights suggest content,
+	// negative weights suggest boilerplate. Use DefaultClassWeights to start
+	// from the built-in words, each one with a weight of +25 or -25. If nil,
+	// the built-in words are used.
+	ClassWeights map[string]int
+
+	// AdditionalPositiveTokens are words that, found in the class name or ID
+	// of an element, suggest that it is part of the content. They are merged
+	// with the built-in list to tune the extraction for a set of websites.
+	AdditionalPositiveTokens []string
+
+	// AdditionalNegativeTokens are words that, found in the class name or ID
+	// of an element, suggest that it is not part of the content, like the
+	// names of widgets specific to a set of websites.
+	AdditionalNegativeTokens []string
+
+	// UnlikelyExceptions are the words, among the ones that make an element
+	// unlikely to be part of the content, like "comment" or "sidebar", that
+	// are ignored when the unlikely elements are removed. ProfileForum adds
+	// "comment", since the posts of a thread look like comments.
+	UnlikelyExceptions []string
+
+	// Engine is the algorithm used to find the content of the article. The
+	// default is EngineReadability.
+	Engine Engine
+
+	// CrossCheck runs both engines and compares their content. When they
+	// agree, the confidence of the result is raised. When they disagree,
+	// the text found by each engine is returned in the Candidates of the
+	// result.
+	CrossCheck bool
+
+	// ExportFeatures collects the feature vector of each element with text in
+	// the document, labeled with the outcome of the heuristics, to train other
+	// extractors. The features are available in the Result of Analyze.
+	ExportFeatures bool
+
+	// Languages are the expected languages of the content, as BCP 47 tags
+	// like "de" or "pt-BR". The phrases of their language packs extend the
+	// ReadMorePrefixes and NewsletterPatterns, and the words introducing the
+	// author, like "By" or "Von", are removed from the byline.
+	Languages []string
+
+	// NormalizeTables rewrites the data tables of the content with explicit
+	// <thead> and <tbody> sections, and without rowspan and colspan, adding
+	// empty cells where needed so every row has the same number of cells.
+	NormalizeTables bool
+
+	// ConvertBulletParagraphs turns runs of two or more paragraphs starting
+	// with a bullet, like "• First" and "• Second", into an <ul> list. List
+	// items found outside of a list are always wrapped in an <ul> list.
+	ConvertBulletParagraphs bool
+
+	// ExpandDetails adds the open attribute to the <details> elements of the
+	// content, so the body of collapsible sections, like the answers of an
+	// FAQ, is displayed without interaction.
+	ExpandDetails bool
+
+	// CustomElements is how the elements that are not part of the HTML
+	// specification, like <c-wiz> or <my-paragraph>, are treated. Use
+	// RegisterCustomElement to set a different policy for specific tags.
+	CustomElements CustomElementPolicy
+
+	// Forms is how the <form> elements are treated. Documentation pages built
+	// with some frameworks wrap the whole content in a form, which the
+	// conditional cleaning removes together with the content.
+	Forms FormPolicy
+
+	// ReportRemovals collects the elements removed from the document, with
+	// the reason of each removal, in the Removals of the Result of Analyze.
+	ReportRemovals bool
+
+	// Commas are the characters counted as commas when the paragraphs are
+	// scored, every comma adds a point to the score. If empty, DefaultCommas
+	// is used, which includes the commas of Arabic and CJK scripts.
+	Commas string
+
+	// SentenceTerminators are the characters ending a sentence, used to
+	// decide if short paragraphs next to the content are part of it. If
+	// empty, DefaultSentenceTerminators is used.
+	SentenceTerminators string
+
+	// MaxMatchStringLength is the maximum number of bytes of the class names
+	// and of the ID of an element considered by the heuristics. Longer values,
+	// found in obfuscated pages, are truncated before they are matched. If
+	// zero, DefaultMaxMatchStringLength is used.
+	MaxMatchStringLength int
+
+	// Segmenter splits the text into words when the words of the title are
+	// counted. If nil, the text is split at white space, which counts most
+	// titles in Chinese and Japanese as a single word. Use SegmentCJK, or a
+	// dictionary-based segmenter, for these languages.
+	Segmenter Segmenter
+
+	// RecordSourcePositions sets the SourcePositions of the article, with the
+	// path and the byte offset in the input of each top-level block of the
+	// content, so tools can map the text of the article back onto the page.
+	RecordSourcePositions bool
+
+	// KeepComments keeps the HTML comments in the content of the article,
+	// which some pipelines use as markers. The conditional comments of old
+	// versions of Internet Explorer are removed anyway.
+	KeepComments bool
+
+	// SanitizeStyles filters the style attributes of the content down to the
+	// StyleProperties, instead of removing them, to keep the direction and
+	// the alignment of the text. The deprecated align attribute of the blocks
+	// of text is converted into the text-align property.
+	SanitizeStyles bool
+
+	// StyleProperties are the CSS properties kept by SanitizeStyles. Values
+	// with functions, like url(), are always removed.
+	StyleProperties []string
+
+	// SelectorStore remembers the path of the element that contained the
+	// article in the last page of each website. When set, the element at the
+	// remembered path is tried first, which is faster and more consistent
+	// across the pages of a website, and the heuristics are only used if the
+	// element is missing or has too little text.
+	SelectorStore SelectorStore
+
+	// DetectGalleries accepts the content of galleries and photo essays,
+	// which are mostly images with captions and are otherwise rejected for
+	// having too little text. The captioned images are protected from the
+	// conditional cleaning, and the content is accepted if it keeps enough
+	// of them, regardless of CharThresholds and ContentPolicy.
+	DetectGalleries bool
+
+	// ShortForm is tuned for poems and short posts, which are otherwise
+	// rejected for having too little text or damaged by the cleaning. The
+	// threshold of the content length is lowered, the content is never
+	// cleaned conditionally, and the line breaks inside of the paragraphs
+	// are kept in the text of the article.
+	ShortForm bool
 }
 
 // New returns new Readability with sane defaults to parse simple documents.
 func New() *Readability {
 	return &Readability{
-		MaxElemsToParse:   0,
-		NTopCandidates:    5,
-		CharThresholds:    500,
-		ClassesToPreserve: []string{"page"},
-		TagsToScore:       []string{"section", "h2", "h3", "h4", "h5", "h6", "p", "td", "pre"},
-		KeepClasses:       false,
+		MaxElemsToParse:      0,
+		NTopCandidates:       5,
+		CharThresholds:       500,
+		ReadablePrefixSize:   64 * 1024,
+		DetectAppShell:       true,
+		ClassesToPreserve:    []string{"page"},
+		TagsToScore:          []string{"section", "h2", "h3", "h4", "h5", "h6", "p", "td", "pre"},
+		ClassWeights:         DefaultClassWeights(),
+		ReadMorePrefixes:     append([]string{}, defaultReadMorePrefixes...),
+		NewsletterPatterns:   append([]string{}, defaultNewsletterPatterns...),
+		BoilerplatePatterns:  append([]*regexp.Regexp{}, defaultBoilerplatePatterns...),
+		StyleProperties:      append([]string{}, defaultStyleProperties...),
+		KeepClasses:          false,
+		Commas:               DefaultCommas,
+		SentenceTerminators:  DefaultSentenceTerminators,
+		MaxMatchStringLength: DefaultMaxMatchStringLength,
 	}
 }
 
@@ -204,6 +722,7 @@ func (r *Readability) removeNodes(list []*html.Node, filter func(*html.Node) boo
 		parentNode = node.Parent
 
 		if parentNode != nil && (filter == nil || filter(node)) {
+			r.invalidateText(parentNode)
 			parentNode.RemoveChild(node)
 		}
 	}
@@ -262,11 +781,28 @@ func (r *Readability) concatNodeLists(nodeLists ...[]*html.Node) []*html.Node {
 	return result
 }
 
+// getAllNodesWithTag returns the elements with any of the given tag names, in
+// document order, walking the tree only once.
 func (r *Readability) getAllNodesWithTag(node *html.Node, tagNames ...string) []*html.Node {
+	if len(tagNames) == 1 {
+		return getElementsByTagName(node, tagNames[0])
+	}
+
 	var list []*html.Node
+	var finder func(*html.Node)
 
-	for _, tag := range tagNames {
-		list = append(list, getElementsByTagName(node, tag)...)
+	finder = func(n *html.Node) {
+		if n.Type == html.ElementNode && indexOf(tagNames, n.Data) != -1 {
+			list = append(list, n)
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			finder(c)
+		}
+	}
+
+	if node != nil {
+		finder(node)
 	}
 
 	return list
@@ -292,7 +828,7 @@ func (r *Readability) getArticleTitle() string {
 
 		// If the resulting title is too short (3 words or fewer), remove
 		// the first part instead:
-		if wordCount(curTitle) < 3 {
+		if r.wordCount(curTitle) < 3 {
 			curTitle = rxTitleRemove1stPart.ReplaceAllString(origTitle, "$1")
 		}
 	} else if strings.Index(curTitle, ": ") != -1 {
@@ -303,9 +839,8 @@ func (r *Readability) getArticleTitle() string {
 			getElementsByTagName(doc, "h2"),
 		)
 
-		trimmedTitle := strings.TrimSpace(curTitle)
 		match := r.someNode(headings, func(heading *html.Node) bool {
-			return strings.TrimSpace(textContent(heading)) == trimmedTitle
+			return isNearDuplicate(curTitle, textContent(heading))
 		})
 
 		// If we don't, let's extract the title out of the original
@@ -314,12 +849,12 @@ func (r *Readability) getArticleTitle() string {
 			curTitle = origTitle[strings.LastIndex(origTitle, ":")+1:]
 
 			// If the title is now too short, try the first colon instead:
-			if wordCount(curTitle) < 3 {
+			if r.wordCount(curTitle) < 3 {
 				curTitle = origTitle[strings.Index(origTitle, ":")+1:]
 				// But if we have too many words before the colon there's
 				// something weird with the titles and the H tags so let's
 				// just use the original title instead
-			} else if wordCount(origTitle[:strings.Index(origTitle, ":")]) > 5 {
+			} else if r.wordCount(origTitle[:strings.Index(origTitle, ":")]) > 5 {
 				curTitle = origTitle
 			}
 		}
@@ -335,12 +870,12 @@ func (r *Readability) getArticleTitle() string {
 	// 'hierarchical' separators (\, /, > or ») were found in the original
 	// title or we decreased the number of words by more than 1 word, use
 	// the original title.
-	curTitleWordCount := wordCount(curTitle)
+	curTitleWordCount := r.wordCount(curTitle)
 	tmpOrigTitle := rxTitleAnySeparator.ReplaceAllString(origTitle, "")
 
 	if curTitleWordCount <= 4 &&
 		(!titleHadHierarchicalSeparators ||
-			curTitleWordCount != wordCount(tmpOrigTitle)-1) {
+			curTitleWordCount != r.wordCount(tmpOrigTitle)-1) {
 		curTitle = origTitle
 	}
 
@@ -396,10 +931,35 @@ func (r *Readability) getArticleFavicon() string {
 func (r *Readability) prepDocument() {
 	doc := r.doc
 
+	if r.RenderedDOM {
+		r.prepRenderedDocument(doc)
+	}
+
 	r.removeNodes(getElementsByTagName(doc, "style"), nil)
+	r.removeComments(doc)
 
 	if n := getElementsByTagName(doc, "body"); len(n) > 0 && n[0] != nil {
+		r.convertCustomElements(n[0])
+
+		if r.Forms == FormsUnwrap {
+			r.forEachNode(getElementsByTagName(n[0], "form"), func(form *html.Node, _ int) {
+				unwrapNode(form)
+			})
+		}
+
 		r.replaceBrs(n[0])
+
+		if r.RemoveNewsletters {
+			r.removeNewsletterBlocks(n[0])
+		}
+
+		if r.RemoveAdSlots {
+			r.removeAdSlots(n[0])
+		}
+
+		if r.RemoveConsentOverlays {
+			r.removeConsentOverlays(n[0])
+		}
 	}
 
 	r.replaceNodeTags(getElementsByTagName(doc, "font"), "SPAN")
@@ -430,6 +990,11 @@ func (r *Readability) nextElement(node *html.Node) *html.Node {
 //   <div>foo<br>bar<p>abc</p></div>
 func (r *Readability) replaceBrs(elem *html.Node) {
 	r.forEachNode(r.getAllNodesWithTag(elem, "br"), func(br *html.Node, _ int) {
+		// Skip the <br> elements detached while an earlier chain was replaced.
+		if br.Parent == nil {
+			return
+		}
+
 		next := br.NextSibling
 
 		// Whether two or more <br> elements have been found and replaced with
@@ -442,13 +1007,13 @@ func (r *Readability) replaceBrs(elem *html.Node) {
 		for {
 			next = r.nextElement(next)
 
-			if next == nil || tagName(next) == "BR" {
+			if next == nil || tagName(next) != "br" {
 				break
 			}
 
 			replaced = true
 			brSibling := next.NextSibling
-			next.Parent.RemoveChild(next)
+			br.Parent.RemoveChild(next)
 			next = brSibling
 		}
 
@@ -484,7 +1049,7 @@ func (r *Readability) replaceBrs(elem *html.Node) {
 				p.RemoveChild(p.LastChild)
 			}
 
-			if tagName(p.Parent) == "P" {
+			if p.Parent != nil && tagName(p.Parent) == "p" {
 				r.setNodeTag(p.Parent, "div")
 			}
 		}
@@ -492,7 +1057,7 @@ func (r *Readability) replaceBrs(elem *html.Node) {
 }
 
 func (r *Readability) setNodeTag(node *html.Node, newTagName string) {
-	if node.Type == html.ElementNode {
+	if node != nil && node.Type == html.ElementNode {
 		node.Data = newTagName
 	}
 
@@ -505,6 +1070,8 @@ func (r *Readability) setNodeTag(node *html.Node, newTagName string) {
 // getArticleMetadata attempts to get excerpt and byline metadata for the article.
 func (r *Readability) getArticleMetadata() Article {
 	values := make(map[string]string)
+	imageCandidates := []string{}
+	localizedByline := ""
 	metaElements := getElementsByTagName(r.doc, "meta")
 
 	// Find description tags.
@@ -527,6 +1094,10 @@ func (r *Readability) getArticleMetadata() Article {
 				name = strings.Join(strings.Fields(name), "")
 				// multiple authors
 				values[name] = strings.TrimSpace(content)
+
+				if indexOf(imageMetaNames, name) != -1 {
+					imageCandidates = append(imageCandidates, strings.TrimSpace(content))
+				}
 			}
 		}
 
@@ -537,6 +1108,14 @@ func (r *Readability) getArticleMetadata() Article {
 			name = strings.Join(strings.Fields(name), "")
 			name = strings.Replace(name, ".", ":", -1)
 			values[name] = strings.TrimSpace(content)
+
+			if indexOf(imageMetaNames, name) != -1 {
+				imageCandidates = append(imageCandidates, strings.TrimSpace(content))
+			}
+		}
+
+		if len(matches) == 0 && elementName != "" && localizedByline == "" && r.isLocalizedByline(elementName) {
+			localizedByline = strings.TrimSpace(content)
 		}
 	})
 
@@ -574,6 +1153,10 @@ func (r *Readability) getArticleMetadata() Article {
 		}
 	}
 
+	if metadataByline == "" {
+		metadataByline = localizedByline
+	}
+
 	// get description
 	metadataExcerpt := ""
 	for _, name := range []string{
@@ -594,6 +1177,19 @@ func (r *Readability) getArticleMetadata() Article {
 	// get site name
 	metadataSiteName := values["og:site_name"]
 
+	// get published time
+	metadataPublishedTime := ""
+	for _, name := range []string{
+		"article:published_time",
+		"og:published_time",
+		"parsely-pub-date",
+	} {
+		if value, ok := values[name]; ok {
+			metadataPublishedTime = value
+			break
+		}
+	}
+
 	// get image thumbnail
 	metadataImage := ""
 	for _, name := range []string{
@@ -607,16 +1203,48 @@ func (r *Readability) getArticleMetadata() Article {
 		}
 	}
 
+	// If there is more than one image, pick the largest one that loads.
+	if r.ProbeImages && len(imageCandidates) > 1 {
+		if image := r.probeImages(imageCandidates); image != "" {
+			metadataImage = image
+		}
+	}
+
 	// get favicon
 	metadataFavicon := r.getArticleFavicon()
 
+	if r.ProbeImages && metadataFavicon != "" {
+		if _, ok := r.probeImage(metadataFavicon); !ok {
+			metadataFavicon = ""
+		}
+	}
+
+	// get related pages
+	relLinks := r.getRelLinks(r.doc)
+
+	// get oEmbed endpoints
+	oembedURLs := r.getOEmbedURLs(r.doc)
+
+	var oembed *OEmbed
+
+	if r.FetchOEmbed {
+		oembed = r.getOEmbed(oembedURLs)
+	}
+
 	return Article{
-		Title:    metadataTitle,
-		Byline:   metadataByline,
-		Excerpt:  metadataExcerpt,
-		SiteName: metadataSiteName,
-		Image:    metadataImage,
-		Favicon:  metadataFavicon,
+		Title:         metadataTitle,
+		Byline:        metadataByline,
+		Excerpt:       metadataExcerpt,
+		SiteName:      metadataSiteName,
+		Image:         metadataImage,
+		Favicon:       metadataFavicon,
+		PublishedTime: metadataPublishedTime,
+		ThemeColor:    getThemeColor(r.doc),
+		TileColor:     getTileColor(r.doc),
+		RelLinks:      relLinks,
+		Translations:  translations(relLinks),
+		OEmbedURLs:    oembedURLs,
+		OEmbed:        oembed,
 	}
 }
 
@@ -630,15 +1258,24 @@ func (r *Readability) prepArticle(articleContent *html.Node) {
 	// though they're visually linked to other content-ful elements
 	// (text, images, etc.).
 	r.markDataTables(articleContent)
+	r.markDefinitionLists(articleContent)
+	r.markQuoteAttributions(articleContent)
+
+	if r.RemoveTrackingPixels {
+		r.removeTrackingPixels(articleContent)
+	}
+
+	if r.StripIcons {
+		r.stripIcons(articleContent)
+	}
 
 	// Clean out junk from the article content
-	r.cleanConditionally(articleContent, "form")
+	if r.Forms == FormsClean {
+		r.cleanConditionally(articleContent, "form")
+	}
+
 	r.cleanConditionally(articleContent, "fieldset")
-	r.clean(articleContent, "object")
-	r.clean(articleContent, "embed")
-	r.clean(articleContent, "footer")
-	r.clean(articleContent, "link")
-	r.clean(articleContent, "aside")
+	r.clean(articleContent, "object", "embed", "footer", "link", "aside")
 
 	// Clean out elements have "share" in their id/class combinations
 	// from final top candidates, which means we don't remove the top
@@ -649,35 +1286,23 @@ func (r *Readability) prepArticle(articleContent *html.Node) {
 		})
 	})
 
+	// Remove the links to related articles placed inside the content.
+	r.removeReadMoreBlocks(articleContent)
+
+	// Remove the syndication footers at the end of the content.
+	if r.TrimBoilerplate {
+		r.trimBoilerplate(articleContent)
+	}
+
 	// If there is only one h2 and its text content substantially
 	// equals article title, they are probably using it as a header
 	// and not a subheader, so remove it since we already extract
 	// the title separately.
-	if h2s := getElementsByTagName(articleContent, "h2"); len(h2s) == 1 {
-		h2 := h2s[0]
-		h2Text := textContent(h2)
-		lengthSimilarRate := float64(len(h2Text)-len(r.articleTitle)) / float64(len(r.articleTitle))
-
-		if math.Abs(lengthSimilarRate) < 0.5 {
-			titlesMatch := false
-
-			if lengthSimilarRate > 0 {
-				titlesMatch = strings.Contains(h2Text, r.articleTitle)
-			} else {
-				titlesMatch = strings.Contains(r.articleTitle, h2Text)
-			}
-
-			if titlesMatch {
-				r.clean(articleContent, "h2")
-			}
-		}
+	if h2s := getElementsByTagName(articleContent, "h2"); len(h2s) == 1 && r.headerDuplicatesTitle(h2s[0]) {
+		r.clean(articleContent, "h2")
 	}
 
-	r.clean(articleContent, "iframe")
-	r.clean(articleContent, "input")
-	r.clean(articleContent, "textarea")
-	r.clean(articleContent, "select")
-	r.clean(articleContent, "button")
+	r.clean(articleContent, "iframe", "input", "textarea", "select", "button")
 	r.cleanHeaders(articleContent)
 
 	// Do these last as the previous stuff may have removed junk
@@ -687,7 +1312,7 @@ func (r *Readability) prepArticle(articleContent *html.Node) {
 	r.cleanConditionally(articleContent, "div")
 
 	// Remove extra paragraphs
-	r.removeNodes(getElementsByTagName(articleContent, "p"), func(p *html.Node) bool {
+	r.removeNodes(getElementsByTagName(articleContent, "p"), r.reportingFilter(RemovalEmpty, func(p *html.Node) bool {
 		imgCount := len(getElementsByTagName(p, "img"))
 		embedCount := len(getElementsByTagName(p, "embed"))
 		objectCount := len(getElementsByTagName(p, "object"))
@@ -697,7 +1322,9 @@ func (r *Readability) prepArticle(articleContent *html.Node) {
 		totalCount := imgCount + embedCount + objectCount + iframeCount
 
 		return totalCount == 0 && r.getInnerText(p, false) == ""
-	})
+	}))
+
+	r.normalizeLists(articleContent)
 
 	r.forEachNode(getElementsByTagName(articleContent, "br"), func(br *html.Node, _ int) {
 		next := r.nextElement(br.NextSibling)
@@ -739,7 +1366,11 @@ func (r *Readability) prepArticle(articleContent *html.Node) {
 // types), find the content that is most likely to be the stuff a user wants to
 // read. Then return it wrapped up in a div.
 func (r *Readability) grabArticle() *html.Node {
-	for {
+	r.grabbing = true
+	defer func() { r.grabbing = false }()
+
+	for pass := 0; ; pass++ {
+		attemptFlags := r.flags
 		doc := cloneNode(r.doc)
 
 		var page *html.Node
@@ -760,15 +1391,21 @@ func (r *Readability) grabArticle() *html.Node {
 		var node = documentElement(doc)
 
 		for node != nil {
-			matchString := className(node) + "\x20" + id(node)
+			matchString := r.matchString(node)
 
 			if !r.isProbablyVisible(node) {
+				r.reportRemoval(node, RemovalHidden)
 				node = r.removeAndGetNext(node)
 				continue
 			}
 
 			// Remove Node if it is a Byline.
 			if r.checkByline(node, matchString) {
+				if r.KeepBylineInContent {
+					r.bylineNode = cloneNode(node)
+				}
+
+				r.reportRemoval(node, RemovalByline)
 				node = r.removeAndGetNext(node)
 				continue
 			}
@@ -776,16 +1413,27 @@ func (r *Readability) grabArticle() *html.Node {
 			// Remove unlikely candidates.
 			nodeTagName := tagName(node)
 			if r.flags.stripUnlikelys {
-				if rxUnlikelyCandidates.MatchString(matchString) &&
+				if r.isUnlikelyCandidate(matchString) &&
 					!rxOkMaybeItsACandidate.MatchString(matchString) &&
 					!r.hasAncestorTag(node, "table", 3, nil) &&
 					nodeTagName != "body" &&
 					nodeTagName != "a" {
+					r.reportRemoval(node, RemovalUnlikely)
 					node = r.removeAndGetNext(node)
 					continue
 				}
 			}
 
+			// Remove signup and share modals, which are part of the document
+			// even when they are not displayed. Unlike the other unlikely
+			// candidates they are removed in every attempt, otherwise their
+			// text wins when the article is short.
+			if r.isModal(node) {
+				r.reportRemoval(node, RemovalModal)
+				node = r.removeAndGetNext(node)
+				continue
+			}
+
 			// Remove DIV, SECTION and HEADER nodes without any content.
 			switch nodeTagName {
 			case "div",
@@ -798,6 +1446,7 @@ func (r *Readability) grabArticle() *html.Node {
 				"h5",
 				"h6":
 				if r.isElementWithoutContent(node) {
+					r.reportRemoval(node, RemovalEmpty)
 					node = r.removeAndGetNext(node)
 					continue
 				}
@@ -821,8 +1470,8 @@ func (r *Readability) grabArticle() *html.Node {
 							appendChild(p, childNode)
 						} else if !r.isWhitespace(childNode) {
 							p = createElement("p")
-							appendChild(p, cloneNode(childNode))
 							replaceNode(childNode, p)
+							appendChild(p, childNode)
 						}
 					} else if p != nil {
 						for p.LastChild != nil && r.isWhitespace(p.LastChild) {
@@ -841,6 +1490,7 @@ func (r *Readability) grabArticle() *html.Node {
 				// practice, paragraphs.
 				if r.hasSingleTagInsideElement(node, "p") && r.getLinkDensity(node) < 0.25 {
 					newNode := children(node)[0]
+					copyMarks(node, newNode)
 					replaceNode(node, newNode)
 					node = newNode
 					elementsToScore = append(elementsToScore, node)
@@ -858,6 +1508,10 @@ func (r *Readability) grabArticle() *html.Node {
 		// node. A score is determined by things like number of commas, class
 		// names, etc. Maybe eventually link density.
 		var candidates []*html.Node
+
+		// The document is not modified while the candidates are scored.
+		r.startTextCache()
+
 		r.forEachNode(elementsToScore, func(elementToScore *html.Node, _ int) {
 			if elementToScore.Parent == nil || tagName(elementToScore.Parent) == "" {
 				return
@@ -879,7 +1533,7 @@ func (r *Readability) grabArticle() *html.Node {
 			contentScore := 1
 
 			// Add points for any commas within this paragraph.
-			contentScore += strings.Count(innerText, ",")
+			contentScore += r.countCommas(innerText)
 
 			// For every 100 characters in this paragraph, add another point. Up to 3 points.
 			contentScore += int(math.Min(math.Floor(float64(len(innerText))/100.0), 3.0))
@@ -932,6 +1586,8 @@ func (r *Readability) grabArticle() *html.Node {
 			r.setContentScore(candidate, candidateScore)
 		}
 
+		r.stopTextCache()
+
 		// After we have calculated scores, sort through all of the possible
 		// candidate nodes we found and find the one with the highest score.
 		sort.Slice(candidates, func(i int, j int) bool {
@@ -1053,6 +1709,25 @@ func (r *Readability) grabArticle() *html.Node {
 			}
 		}
 
+		// If the top candidate is part of a live blog update, of an image of
+		// a gallery or of a block of a transcript, use the update, the image
+		// or the block instead, so the others are joined as its siblings.
+		for parent := topCandidate.Parent; parent != nil; parent = parent.Parent {
+			if hasAttribute(parent, liveUpdateAttr) || hasAttribute(parent, galleryAttr) || hasAttribute(parent, transcriptAttr) {
+				topCandidate = parent
+			}
+		}
+
+		plannedCandidates, plannedTopCandidate := r.recordCandidates(topCandidates, topCandidate)
+
+		// The container created for the children of the body is not part of
+		// the document, the body is reported instead.
+		topCandidatePath := r.reportPath(page)
+
+		if !neededToCreateTopCandidate {
+			topCandidatePath = r.reportPath(topCandidate)
+		}
+
 		// Now that we have the top candidate, look through its siblings
 		// for content that might also be related. Things like preambles,
 		// content split by ads that we removed, etc.
@@ -1071,6 +1746,8 @@ func (r *Readability) grabArticle() *html.Node {
 
 			if sibling == topCandidate {
 				appendNode = true
+			} else if hasAttribute(sibling, liveUpdateAttr) || hasAttribute(sibling, galleryAttr) || hasAttribute(sibling, transcriptAttr) {
+				appendNode = true
 			} else {
 				contentBonus := float64(0)
 
@@ -1089,7 +1766,7 @@ func (r *Readability) grabArticle() *html.Node {
 					if nodeLength > 80 && linkDensity < 0.25 {
 						appendNode = true
 					} else if nodeLength < 80 && nodeLength > 0 && linkDensity == 0 &&
-						rxSentencePeriod.MatchString(nodeContent) {
+						r.hasSentenceEnd(nodeContent) {
 						appendNode = true
 					}
 				}
@@ -1098,8 +1775,9 @@ func (r *Readability) grabArticle() *html.Node {
 			if appendNode {
 				// We have a node that is not a common block level element,
 				// like a FORM or TD tag. Turn it into a DIV so it does not get
-				// filtered out later by accident.
-				if indexOf(alterToDivExceptions, tagName(sibling)) == -1 {
+				// filtered out later by accident. The figures of galleries are
+				// protected anyway.
+				if indexOf(alterToDivExceptions, tagName(sibling)) == -1 && !hasAttribute(sibling, galleryAttr) {
 					r.setNodeTag(sibling, "div")
 				}
 
@@ -1117,16 +1795,9 @@ func (r *Readability) grabArticle() *html.Node {
 			// point trying to create a new DIV and then move all the children
 			// over. Just assign IDs and CSS class names here. No need to append
 			// because that already happened anyway.
-			//
-			// By the way, this line is different with Readability.js.
-			//
-			// In Readability.js, when using `appendChild`, the node is still
-			// referenced. Meanwhile here, our `appendChild` will clone the
-			// node, put it in the new place, then delete the original.
-			firstChild := firstElementChild(articleContent)
-			if firstChild != nil && tagName(firstChild) == "div" {
-				setAttribute(firstChild, "id", "readability-page-1")
-				setAttribute(firstChild, "class", "page")
+			if topCandidate.Parent == articleContent {
+				setAttribute(topCandidate, "id", "readability-page-1")
+				setAttribute(topCandidate, "class", "page")
 			}
 		} else {
 			div := createElement("div")
@@ -1143,59 +1814,52 @@ func (r *Readability) grabArticle() *html.Node {
 			appendChild(articleContent, div)
 		}
 
-		parseSuccessful := true
-
 		// Now that we've gone through the full algorithm, check to see if we
 		// got any meaningful content. If we did not, we may need to re-run
 		// grabArticle with different flags set. This gives us a higher
 		// likelihood of finding the content, and the sieve approach gives us a
 		// higher likelihood of finding the -right- content.
 		textLength := len(r.getInnerText(articleContent, true))
-		if textLength < r.CharThresholds {
-			parseSuccessful = false
+		succeeded := r.attemptSucceeded(articleContent, pass, textLength)
+		r.attempts = append(r.attempts, parseAttempt{
+			articleContent:   articleContent,
+			textLength:       textLength,
+			flags:            attemptFlags,
+			candidates:       plannedCandidates,
+			topCandidate:     plannedTopCandidate,
+			topCandidatePath: topCandidatePath,
+			succeeded:        succeeded,
+		})
 
-			if r.flags.stripUnlikelys {
-				r.flags.stripUnlikelys = false
-				r.attempts = append(r.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
-			} else if r.flags.useWeightClasses {
-				r.flags.useWeightClasses = false
-				r.attempts = append(r.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
-			} else if r.flags.cleanConditionally {
-				r.flags.cleanConditionally = false
-				r.attempts = append(r.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
-			} else {
-				r.attempts = append(r.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
-
-				// No luck after removing flags, just return the
-				// longest text we found during the different loops *
-				sort.Slice(r.attempts, func(i, j int) bool {
-					return r.attempts[i].textLength > r.attempts[j].textLength
-				})
-
-				// But first check if we actually have something
-				if r.attempts[0].textLength == 0 {
-					return nil
+		if succeeded {
+			r.selectedAttempt = len(r.attempts) - 1
+			return articleContent
+		}
+
+		if r.flags.stripUnlikelys {
+			r.flags.stripUnlikelys = false
+		} else if r.flags.useWeightClasses {
+			r.flags.useWeightClasses = false
+		} else if r.flags.cleanConditionally {
+			r.flags.cleanConditionally = false
+		} else {
+			// No luck after removing flags, just return the longest text we
+			// found during the different loops. The attempts are kept in the
+			// order they ran so they can be reported back to the caller.
+			longest := 0
+			for i := 1; i < len(r.attempts); i++ {
+				if r.attempts[i].textLength > r.attempts[longest].textLength {
+					longest = i
 				}
+			}
 
-				articleContent = r.attempts[0].articleContent
-				parseSuccessful = true
+			// But first check if we actually have something
+			if r.attempts[longest].textLength == 0 {
+				return nil
 			}
-		}
 
-		if parseSuccessful {
-			return articleContent
+			r.selectedAttempt = longest
+			return r.attempts[longest].articleContent
 		}
 	}
 }
@@ -1221,9 +1885,14 @@ func (r *Readability) initializeNode(node *html.Node) {
 
 // removeAndGetNext remove node and returns its next node.
 func (r *Readability) removeAndGetNext(node *html.Node) *html.Node {
+	if node == nil {
+		return nil
+	}
+
 	nextNode := r.getNextNode(node, true)
 
 	if node.Parent != nil {
+		r.invalidateText(node.Parent)
 		node.Parent.RemoveChild(node)
 	}
 
@@ -1237,6 +1906,10 @@ func (r *Readability) removeAndGetNext(node *html.Node) *html.Node {
 //
 // In Readability.js, ignoreSelfAndKids default to false.
 func (r *Readability) getNextNode(node *html.Node, ignoreSelfAndKids bool) *html.Node {
+	if node == nil {
+		return nil
+	}
+
 	// First check for kids if those are not being ignored
 	if firstChild := firstElementChild(node); !ignoreSelfAndKids && firstChild != nil {
 		return firstChild
@@ -1279,7 +1952,7 @@ func (r *Readability) checkByline(node *html.Node, matchString string) bool {
 	rel := getAttribute(node, "rel")
 	itemprop := getAttribute(node, "itemprop")
 	nodeText := textContent(node)
-	if (rel == "author" || strings.Contains(itemprop, "author") || rxByline.MatchString(matchString)) && r.isValidByline(nodeText) {
+	if (rel == "author" || strings.Contains(itemprop, "author") || rxByline.MatchString(matchString) || r.isLocalizedByline(matchString)) && r.isValidByline(nodeText) {
 		nodeText = strings.TrimSpace(nodeText)
 		nodeText = strings.Join(strings.Fields(nodeText), "\x20")
 		r.articleByline = nodeText
@@ -1296,7 +1969,7 @@ func (r *Readability) getNodeAncestors(node *html.Node, maxDepth int) []*html.No
 	level := 0
 	ancestors := []*html.Node{}
 
-	for node.Parent != nil {
+	for node != nil && node.Parent != nil {
 		level++
 		ancestors = append(ancestors, node.Parent)
 
@@ -1409,34 +2082,50 @@ func (r *Readability) isWhitespace(node *html.Node) bool {
 // This also strips out any excess whitespace to be found.
 // In Readability.js, normalizeSpaces default to true.
 func (r *Readability) getInnerText(node *html.Node, normalizeSpaces bool) string {
-	textContent := strings.TrimSpace(textContent(node))
+	if normalizeSpaces && r.texts != nil {
+		return r.cachedText(node)
+	}
 
 	if normalizeSpaces {
-		textContent = rxNormalize.ReplaceAllString(textContent, "\x20")
+		return normalizedText(node)
 	}
 
-	return textContent
+	return strings.TrimSpace(textContent(node))
 }
 
-// getCharCount returns the number of times a string appears in the Node.
-func (r *Readability) getCharCount(node *html.Node, s string) int {
-	innerText := r.getInnerText(node, true)
-	return strings.Count(innerText, s)
+// getCharCount returns the number of commas in the text of the Node.
+func (r *Readability) getCharCount(node *html.Node) int {
+	return r.countCommas(r.getInnerText(node, true))
 }
 
-// cleanStyles removes the style attribute on every node and under.
+// cleanStyles removes the style attribute on every node and under. When
+// SanitizeStyles is enabled, the style attribute keeps the StyleProperties.
 func (r *Readability) cleanStyles(node *html.Node) {
+	if node == nil {
+		return
+	}
+
 	nodeTagName := tagName(node)
 
-	if node == nil || nodeTagName == "svg" {
+	if nodeTagName == "svg" {
 		return
 	}
 
+	style := ""
+
+	if r.SanitizeStyles {
+		style = r.sanitizedStyle(node)
+	}
+
 	// Remove `style` and deprecated presentational attributes
 	for i := 0; i < len(presentationalAttributes); i++ {
 		removeAttribute(node, presentationalAttributes[i])
 	}
 
+	if style != "" {
+		setAttribute(node, "style", style)
+	}
+
 	if indexOf(deprecatedSizeAttributeElems, nodeTagName) != -1 {
 		removeAttribute(node, "width")
 		removeAttribute(node, "height")
@@ -1451,19 +2140,9 @@ func (r *Readability) cleanStyles(node *html.Node) {
 // This is the amount of text that is inside a link divided by the total text
 // in the node.
 func (r *Readability) getLinkDensity(element *html.Node) float64 {
-	textLength := len(r.getInnerText(element, true))
-
-	if textLength == 0 {
-		return 0
-	}
-
-	linkLength := 0
-
-	r.forEachNode(getElementsByTagName(element, "a"), func(linkNode *html.Node, _ int) {
-		linkLength += len(r.getInnerText(linkNode, true))
+	return heuristics.LinkDensityFunc(element, func(node *html.Node) string {
+		return r.getInnerText(node, true)
 	})
-
-	return float64(linkLength) / float64(textLength)
 }
 
 // getClassWeight gets an elements class/id weight. Uses regular expressions to
@@ -1473,38 +2152,20 @@ func (r *Readability) getClassWeight(node *html.Node) int {
 		return 0
 	}
 
-	weight := 0
-
-	// Look for a special classname
-	if nodeClassName := className(node); nodeClassName != "" {
-		if rxNegative.MatchString(nodeClassName) {
-			weight -= 25
-		}
-
-		if rxPositive.MatchString(nodeClassName) {
-			weight += 25
-		}
-	}
+	return heuristics.ClassWeight(node, r.classWeightOptions())
+}
 
-	// Look for a special ID
-	if nodeID := id(node); nodeID != "" {
-		if rxNegative.MatchString(nodeID) {
-			weight -= 25
-		}
+// clean cleans a node of all elements of the given types. The elements of all
+// the types are collected in a single walk of the tree.
+func (r *Readability) clean(node *html.Node, tags ...string) {
+	r.removeNodes(r.getAllNodesWithTag(node, tags...), r.reportingFilter(RemovalTag, func(element *html.Node) bool {
+		isEmbed := indexOf([]string{"object", "embed", "iframe"}, tagName(element)) != -1
 
-		if rxPositive.MatchString(nodeID) {
-			weight += 25
+		// Keep the <footer> elements crediting the author of a blockquote.
+		if r.isQuoteAttribution(element) {
+			return false
 		}
-	}
 
-	return weight
-}
-
-// clean cleans a node of all elements of type "tag".
-func (r *Readability) clean(node *html.Node, tag string) {
-	isEmbed := indexOf([]string{"object", "embed", "iframe"}, tag) != -1
-
-	r.removeNodes(getElementsByTagName(node, tag), func(element *html.Node) bool {
 		// Allow YouTube and Vimeo videos through as people usually want to see those.
 		if isEmbed {
 			// Check the attributes to see if any of them contain YouTube or Vimeo.
@@ -1521,7 +2182,7 @@ func (r *Readability) clean(node *html.Node, tag string) {
 		}
 
 		return true
-	})
+	}))
 }
 
 // hasAncestorTag checks if a given node has one of its ancestor tag name
@@ -1531,7 +2192,7 @@ func (r *Readability) clean(node *html.Node, tag string) {
 func (r *Readability) hasAncestorTag(node *html.Node, tag string, maxDepth int, filterFn func(*html.Node) bool) bool {
 	depth := 0
 
-	for node.Parent != nil {
+	for node != nil && node.Parent != nil {
 		if maxDepth > 0 && depth > maxDepth {
 			return false
 		}
@@ -1602,70 +2263,74 @@ func (r *Readability) setReadabilityDataTable(node *html.Node, isDataTable bool)
 	removeAttribute(node, "data-readability-table")
 }
 
-// markDataTables looks for "data" (as opposed to "layout") tables and mark it.
+// markDataTables looks for "data" (as opposed to "layout") tables and mark it,
+// see heuristics.IsDataTable.
 func (r *Readability) markDataTables(root *html.Node) {
-	tables := getElementsByTagName(root, "table")
-
-	for i := 0; i < len(tables); i++ {
-		table := tables[i]
-
-		role := getAttribute(table, "role")
-		if role == "presentation" {
-			r.setReadabilityDataTable(table, false)
-			continue
-		}
+	for _, table := range getElementsByTagName(root, "table") {
+		r.setReadabilityDataTable(table, heuristics.IsDataTable(table))
+	}
+}
 
-		datatable := getAttribute(table, "datatable")
-		if datatable == "0" {
-			r.setReadabilityDataTable(table, false)
-			continue
-		}
+// isReadabilityDefinitionList determines if a Node is a definition list that
+// is part of the content, see markDefinitionLists.
+func (r *Readability) isReadabilityDefinitionList(node *html.Node) bool {
+	return hasAttribute(node, "data-readability-dl")
+}
 
-		if hasAttribute(table, "summary") {
-			r.setReadabilityDataTable(table, true)
-			continue
+// markDefinitionLists looks for definition lists, like glossaries, FAQs and
+// API references, which are usually the content of the page, and marks them
+// to protect them from the conditional cleaning.
+func (r *Readability) markDefinitionLists(root *html.Node) {
+	for _, dl := range getElementsByTagName(root, "dl") {
+		if r.isDefinitionList(dl) {
+			setAttribute(dl, "data-readability-dl", "true")
 		}
+	}
+}
 
-		if captions := getElementsByTagName(table, "caption"); len(captions) > 0 {
-			if caption := captions[0]; caption != nil && len(childNodes(caption)) > 0 {
-				r.setReadabilityDataTable(table, true)
-				continue
-			}
-		}
+// isDefinitionList returns true if the <dl> element has at least two terms
+// and two descriptions with some text, and is not mostly made of links like
+// the definition lists used for navigation.
+func (r *Readability) isDefinitionList(dl *html.Node) bool {
+	terms := 0
+	descriptions := 0
+	descriptionLength := 0
 
-		// If the table has a descendant with any of these tags, consider a data table:
-		hasDataTableDescendantTags := false
-		for _, descendantTag := range []string{"col", "colgroup", "tfoot", "thead", "th"} {
-			descendants := getElementsByTagName(table, descendantTag)
-			if len(descendants) > 0 && descendants[0] != nil {
-				hasDataTableDescendantTags = true
-				break
-			}
-		}
+	for _, node := range getElementsByTagName(dl, "*") {
+		tag := tagName(node)
 
-		if hasDataTableDescendantTags {
-			r.setReadabilityDataTable(table, true)
+		if (tag != "dt" && tag != "dd") || closestAncestor(node, "dl") != dl {
 			continue
 		}
 
-		// Nested tables indicates a layout table:
-		if len(getElementsByTagName(table, "table")) > 0 {
-			r.setReadabilityDataTable(table, false)
+		if tag == "dt" {
+			terms++
 			continue
 		}
 
-		rows, columns := r.getRowAndColumnCount(table)
+		descriptions++
+		descriptionLength += len(r.getInnerText(node, true))
+	}
+
+	return terms >= 2 && descriptions >= 2 &&
+		descriptionLength >= 50 &&
+		r.getLinkDensity(dl) < 0.5
+}
+
+// containsDefinitionList returns true if most of the text of the node is part
+// of definition lists marked by markDefinitionLists.
+func (r *Readability) containsDefinitionList(node *html.Node) bool {
+	listLength := 0
 
-		if rows >= 10 || columns > 4 {
-			r.setReadabilityDataTable(table, true)
+	for _, dl := range getElementsByTagName(node, "dl") {
+		if dl == node || !r.isReadabilityDefinitionList(dl) || r.hasAncestorTag(dl, "dl", -1, r.isReadabilityDefinitionList) {
 			continue
 		}
 
-		// Now just go by size entirely:
-		if rows*columns > 10 {
-			r.setReadabilityDataTable(table, true)
-		}
+		listLength += len(r.getInnerText(dl, true))
 	}
+
+	return listLength > 0 && listLength*2 >= len(r.getInnerText(node, true))
 }
 
 // cleanConditionally cleans an element of all tags of type "tag" if they look
@@ -1678,10 +2343,13 @@ func (r *Readability) cleanConditionally(element *html.Node, tag string) {
 
 	isList := tag == "ul" || tag == "ol"
 
+	r.startTextCache()
+	defer r.stopTextCache()
+
 	// Gather counts for other typical elements embedded within. Traverse
 	// backwards so we can remove nodes at the same time without effecting
 	// the traversal.
-	r.removeNodes(getElementsByTagName(element, tag), func(node *html.Node) bool {
+	r.removeNodes(getElementsByTagName(element, tag), r.reportingFilter(RemovalConditional, func(node *html.Node) bool {
 		if tag == "table" && r.isReadabilityDataTable(node) {
 			return false
 		}
@@ -1690,26 +2358,48 @@ func (r *Readability) cleanConditionally(element *html.Node, tag string) {
 			return false
 		}
 
+		// Keep glossaries and FAQs, and the elements wrapping them.
+		if r.hasAncestorTag(node, "dl", -1, r.isReadabilityDefinitionList) || r.containsDefinitionList(node) {
+			return false
+		}
+
+		if r.isProtectedLiveUpdate(node) {
+			return false
+		}
+
+		if r.isProtectedGalleryItem(node) {
+			return false
+		}
+
+		if r.isProtectedTranscriptLine(node) {
+			return false
+		}
+
+		if r.isQuoteAttribution(node) {
+			return false
+		}
+
+		// Keep the answers of FAQs, which are often short.
+		if node.Parent != nil && tagName(node.Parent) == "details" {
+			return false
+		}
+
 		weight := r.getClassWeight(node)
 		if weight < 0 {
 			return true
 		}
 
-		if r.getCharCount(node, ",") < 10 {
+		if r.getCharCount(node) < 10 {
 			// If there are not many commas and the number of non-paragraph
 			// elements is more than paragraphs or other ominous signs, remove
 			// the element.
-			p := float64(len(getElementsByTagName(node, "p")))
-			img := float64(len(getElementsByTagName(node, "img")))
-			li := float64(len(getElementsByTagName(node, "li")) - 100)
-			input := float64(len(getElementsByTagName(node, "input")))
+			counts, embeds := r.countElements(node)
+			p := float64(counts["p"])
+			img := float64(counts["img"])
+			li := float64(counts["li"] - 100)
+			input := float64(counts["input"])
 
 			embedCount := 0
-			embeds := r.concatNodeLists(
-				getElementsByTagName(node, "object"),
-				getElementsByTagName(node, "embed"),
-				getElementsByTagName(node, "iframe"),
-			)
 
 			for _, embed := range embeds {
 				// Do not delete if Embed has attribute matching Video regex.
@@ -1740,7 +2430,35 @@ func (r *Readability) cleanConditionally(element *html.Node, tag string) {
 		}
 
 		return false
-	})
+	}))
+}
+
+// countElements counts the paragraphs, images, list items and inputs of the
+// node, and collects its embedded objects, in a single walk of the tree.
+func (r *Readability) countElements(node *html.Node) (map[string]int, []*html.Node) {
+	var embeds []*html.Node
+	var finder func(*html.Node)
+
+	counts := map[string]int{}
+
+	finder = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "p", "img", "li", "input":
+				counts[n.Data]++
+			case "object", "embed", "iframe":
+				embeds = append(embeds, n)
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			finder(c)
+		}
+	}
+
+	finder(node)
+
+	return counts, embeds
 }
 
 // cleanMatchedNodes cleans out elements whose ID and CSS class combinations
@@ -1750,7 +2468,8 @@ func (r *Readability) cleanMatchedNodes(e *html.Node, filter func(*html.Node, st
 	next := r.getNextNode(e, false)
 
 	for next != nil && next != endOfSearchMarkerNode {
-		if filter != nil && filter(next, className(next)+"\x20"+id(next)) {
+		if filter != nil && filter(next, r.matchString(next)) {
+			r.reportRemoval(next, RemovalShare)
 			next = r.removeAndGetNext(next)
 		} else {
 			next = r.getNextNode(next, false)
@@ -1764,27 +2483,56 @@ func (r *Readability) cleanHeaders(e *html.Node) {
 	for headerIndex := 1; headerIndex < 3; headerIndex++ {
 		headerTag := fmt.Sprintf("h%d", headerIndex)
 
-		r.removeNodes(getElementsByTagName(e, headerTag), func(header *html.Node) bool {
+		r.removeNodes(getElementsByTagName(e, headerTag), r.reportingFilter(RemovalHeader, func(header *html.Node) bool {
 			return r.getClassWeight(header) < 0
-		})
+		}))
 	}
 }
 
+// isModal determines if a node is a dialog, either a <dialog> element or an
+// element with a dialog role or the aria-modal attribute.
+func (r *Readability) isModal(node *html.Node) bool {
+	if tagName(node) == "dialog" || getAttribute(node, "aria-modal") == "true" {
+		return true
+	}
+
+	return indexOf(modalRoles, getAttribute(node, "role")) != -1
+}
+
 // isProbablyVisible determines if a node is visible.
 func (r *Readability) isProbablyVisible(node *html.Node) bool {
 	nodeStyle := getAttribute(node, "style")
 	nodeAriaHidden := getAttribute(node, "aria-hidden")
 	className := getAttribute(node, "class")
 
-	return (nodeStyle == "" || !rxDisplayNone.MatchString(nodeStyle)) &&
+	// Inline styles in a rendered DOM are not reliable, frameworks toggle
+	// them constantly and the snapshot already reflects the computed styles.
+	visible := (r.RenderedDOM || nodeStyle == "" || !rxDisplayNone.MatchString(nodeStyle)) &&
 		!hasAttribute(node, "hidden") &&
 		(nodeAriaHidden == "" ||
 			nodeAriaHidden != "true" ||
 			strings.Contains(className, "fallback-image"))
+
+	// The body of collapsible sections is hidden until the reader expands
+	// them, which does not make it less relevant than the summary.
+	return visible || r.hasAncestorTag(node, "details", -1, nil)
 }
 
-// fixRelativeURIs converts each <a> and <img> uri in the given element to an
-// absolute URI, ignoring #ref URIs.
+// isUnlikelyCandidate determines if the class names and id of an element have
+// one of the words of the elements unlikely to be part of the content, other
+// than the UnlikelyExceptions.
+func (r *Readability) isUnlikelyCandidate(matchString string) bool {
+	for _, word := range rxUnlikelyCandidates.FindAllString(matchString, -1) {
+		if indexOf(r.UnlikelyExceptions, strings.ToLower(word)) == -1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fixRelativeURIs converts each <a>, <img> and media uri in the given element
+// to an absolute URI, ignoring #ref URIs.
 func (r *Readability) fixRelativeURIs(articleContent *html.Node) {
 	links := r.getAllNodesWithTag(articleContent, "a")
 
@@ -1813,23 +2561,40 @@ func (r *Readability) fixRelativeURIs(articleContent *html.Node) {
 		setAttribute(link, "href", newHref)
 	})
 
-	imgs := r.getAllNodesWithTag(articleContent, "img")
+	// Resolve the sources of the images and of the media players, including
+	// the images of the responsive images, keeping the descriptors that tell
+	// the browser which one to pick.
+	medias := r.getAllNodesWithTag(articleContent, "img", "picture", "figure", "video", "audio", "source", "track")
 
-	r.forEachNode(imgs, func(img *html.Node, _ int) {
-		src := getAttribute(img, "src")
+	r.forEachNode(medias, func(media *html.Node, _ int) {
+		for _, attrName := range []string{"src", "poster"} {
+			value := getAttribute(media, attrName)
 
-		if src == "" {
-			return
-		}
+			if value == "" {
+				continue
+			}
 
-		newSrc := toAbsoluteURI(src, r.documentURI)
+			newValue := toAbsoluteURI(value, r.documentURI)
 
-		if newSrc == "" {
-			removeAttribute(img, "src")
-			return
+			if newValue == "" {
+				removeAttribute(media, attrName)
+				continue
+			}
+
+			setAttribute(media, attrName, newValue)
 		}
 
-		setAttribute(img, "src", newSrc)
+		if srcset := getAttribute(media, "srcset"); srcset != "" {
+			setAttribute(media, "srcset", absoluteSrcset(srcset, r.documentURI))
+		}
+	})
+
+	quotes := r.getAllNodesWithTag(articleContent, "blockquote", "q")
+
+	r.forEachNode(quotes, func(quote *html.Node, _ int) {
+		if cite := getAttribute(quote, "cite"); cite != "" {
+			setAttribute(quote, "cite", toAbsoluteURI(cite, r.documentURI))
+		}
 	})
 }
 
@@ -1861,6 +2626,9 @@ func (r *Readability) cleanClasses(node *html.Node) {
 func (r *Readability) clearReadabilityAttr(node *html.Node) {
 	removeAttribute(node, "data-readability-score")
 	removeAttribute(node, "data-readability-table")
+	removeAttribute(node, "data-readability-dl")
+	removeAttribute(node, attributionAttr)
+	removeAttribute(node, planAttr)
 
 	for child := firstElementChild(node); child != nil; child = nextElementSibling(child) {
 		r.clearReadabilityAttr(child)
@@ -1881,6 +2649,8 @@ func (r *Readability) isSingleImage(node *html.Node) bool {
 	return r.isSingleImage(children[0])
 }
 
+// removeComments removes the HTML comments of the document, unless KeepComments
+// is enabled, in which case only the conditional comments are removed.
 func (r *Readability) removeComments(doc *html.Node) {
 	var comments []*html.Node
 	var finder func(*html.Node)
@@ -1899,14 +2669,56 @@ func (r *Readability) removeComments(doc *html.Node) {
 		finder(child)
 	}
 
-	r.removeNodes(comments, nil)
+	r.removeNodes(comments, func(comment *html.Node) bool {
+		return !r.KeepComments || isConditionalComment(comment)
+	})
 }
 
 // postProcessContent runs post-process modifications to the article content.
 func (r *Readability) postProcessContent(articleContent *html.Node) {
+	// Strip the inline images that would make the content too large.
+	if r.MaxInlineImageBytes > 0 {
+		r.limitInlineImages(articleContent)
+	}
+
 	// Convert relative URIs to absolute URIs so we can open them.
 	r.fixRelativeURIs(articleContent)
 
+	if r.ShortForm {
+		r.preserveLineBreaks(articleContent)
+	}
+
+	if r.GenerateAltText {
+		r.generateAltText(articleContent)
+	}
+
+	// Point fragment links to the targets that are part of the content.
+	r.repairFragmentLinks(articleContent)
+
+	if r.CanonicalizeLinks {
+		r.canonicalizeLinks(articleContent)
+	}
+
+	// Collapsible sections are kept with their body, which must be visible
+	// once the section is expanded.
+	r.forEachNode(getElementsByTagName(articleContent, "details"), func(details *html.Node, _ int) {
+		for _, node := range getElementsByTagName(details, "*") {
+			if node != details {
+				removeAttribute(node, "hidden")
+				removeAttribute(node, "aria-hidden")
+			}
+		}
+
+		if r.ExpandDetails {
+			setAttribute(details, "open", "")
+		}
+	})
+
+	// Rebuild the data tables before their marks are removed.
+	if r.NormalizeTables {
+		r.normalizeTables(articleContent)
+	}
+
 	// Remove CSS classes.
 	r.cleanClasses(articleContent)
 
@@ -1916,24 +2728,87 @@ func (r *Readability) postProcessContent(articleContent *html.Node) {
 
 // Parse parses input and find the main readable content.
 func (r *Readability) Parse(input io.Reader, pageURL string) (Article, error) {
+	result, err := r.Analyze(input, pageURL)
+
+	if err != nil {
+		return Article{}, err
+	}
+
+	return result.article(), nil
+}
+
+// Analyze parses input and finds the main readable content, same as Parse,
+// but returns the article together with diagnostics about the extraction.
+// The content of the article is not serialized until one of the rendering
+// methods of the Result is called.
+func (r *Readability) Analyze(input io.Reader, pageURL string) (*Result, error) {
+	if r.ContentEncoding != "" {
+		decoded, err := decodeContent(input, r.ContentEncoding)
+
+		if err != nil {
+			return nil, err
+		}
+
+		input = decoded
+	}
+
+	return r.analyze(input, pageURL)
+}
+
+// analyze runs the extraction over an input that is already decoded.
+func (r *Readability) analyze(input io.Reader, pageURL string) (*Result, error) {
+	start := time.Now()
+
+	var source bytes.Buffer
+
+	if r.RecordSourcePositions {
+		input = io.TeeReader(input, &source)
+	}
+
+	// Parse input.
+	doc, err := html.Parse(input)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	r.source = source.Bytes()
+
+	return r.analyzeDocument(doc, pageURL, start)
+}
+
+// analyzeDocument runs the extraction over a parsed document. The document is
+// modified in place, start is the time when the parsing of the input began.
+func (r *Readability) analyzeDocument(doc *html.Node, pageURL string, start time.Time) (*Result, error) {
 	var err error
+	var timings Timings
 
 	// Reset parser data
 	r.articleTitle = ""
 	r.articleByline = ""
+	r.bylineNode = nil
 	r.attempts = []parseAttempt{}
+	r.selectedAttempt = -1
+	r.removals = nil
+	r.localizedBylineTokens = r.bylineTokens()
 	r.flags.stripUnlikelys = true
 	r.flags.useWeightClasses = true
-	r.flags.cleanConditionally = true
+	r.flags.cleanConditionally = !r.ShortForm
 
 	// Parse page URL.
 	if r.documentURI, err = url.ParseRequestURI(pageURL); err != nil {
-		return Article{}, fmt.Errorf("failed to parse URL: %v", err)
+		return nil, fmt.Errorf("failed to parse URL: %v", err)
 	}
 
-	// Parse input.
-	if r.doc, err = html.Parse(input); err != nil {
-		return Article{}, fmt.Errorf("failed to parse input: %v", err)
+	r.doc = doc
+	ensureStructure(r.doc)
+
+	// Record the positions before the document is modified. The paths in
+	// the SelectorStore are paths of the original document too.
+	r.sourceNodes = nil
+
+	if r.RecordSourcePositions || r.SelectorStore != nil {
+		r.markSourcePositions(r.doc)
 	}
 
 	// Avoid parsing too large documents, as per configuration option.
@@ -1941,29 +2816,144 @@ func (r *Readability) Parse(input io.Reader, pageURL string) (Article, error) {
 		numTags := len(getElementsByTagName(r.doc, "*"))
 
 		if numTags > r.MaxElemsToParse {
-			return Article{}, fmt.Errorf("too many elements: %d", numTags)
+			return nil, fmt.Errorf("too many elements: %d", numTags)
 		}
 	}
 
+	// Detect empty application shells before the scripts are removed.
+	if r.DetectAppShell && r.isAppShell(r.doc) {
+		return nil, ErrClientSideRendered
+	}
+
+	robots := getRobotsDirectives(r.doc)
+
+	if r.RespectNoarchive && robots.noArchive {
+		return nil, ErrNoArchive
+	}
+
+	// Interstitial pages that redirect to another page have no content.
+	if redirect := r.metaRefresh(r.doc); redirect != nil {
+		return nil, redirect
+	}
+
+	if err := r.checkContent(r.doc); err != nil {
+		return nil, err
+	}
+
+	timings.Parse = time.Since(start)
+	mark := time.Now()
+
+	if r.RepairMarkup {
+		r.repairMarkup(r.doc)
+	}
+
+	runHook(r.BeforePrep, r.doc)
+
+	// The JSON-LD metadata is removed together with the scripts.
+	stats := r.getInteractionStats(r.doc)
+	logo := r.getPublisherLogo(r.doc)
+
 	// Remove script tags from the document.
 	r.removeScripts(r.doc)
 
+	rawContent := ""
+
+	if r.KeepRawContent {
+		rawContent = r.getRawContent(r.doc)
+	}
+
 	// Prepares the HTML document.
 	r.prepDocument()
 
+	// Keep only the first article of pages with infinite scroll.
+	r.removeAppendedArticles(r.doc)
+
+	// Protect the updates of live blogs from conditional cleaning.
+	r.markLiveUpdates(r.doc)
+
+	// Protect the captioned images of galleries too.
+	if r.DetectGalleries {
+		r.markGallery(r.doc)
+	}
+
+	// And the blocks of the transcripts of interviews.
+	r.markTranscript(r.doc)
+
+	timings.Prepare = time.Since(mark)
+	mark = time.Now()
+
+	if err := r.checkInvariants("prepare", r.doc); err != nil {
+		return nil, err
+	}
+
 	// Fetch metadata.
 	metadata := r.getArticleMetadata()
 	r.articleTitle = metadata.Title
 
+	timings.Metadata = time.Since(mark)
+	mark = time.Now()
+
+	// Find pull-quotes while their class names are still available.
+	pullQuoteTexts := r.findPullQuoteTexts(r.doc)
+
+	var features []NodeFeatures
+
+	if r.ExportFeatures {
+		features = r.markFeatureNodes(r.doc)
+	}
+
+	// Remember the targets of fragment links before their IDs are lost.
+	r.anchorTargets = r.findAnchorTargets(r.doc)
+
 	// Try to grab article content.
-	finalHTMLContent := ""
-	finalTextContent := ""
 	readableNode := &html.Node{}
-	articleContent := r.grabArticle()
+	articleContent, alternativeContent := r.grabContent()
+
+	if r.ExportFeatures {
+		r.labelFeatureNodes(articleContent, features)
+	}
+
+	timings.Grab = time.Since(mark)
+	mark = time.Now()
+
+	if err := r.checkInvariants("grab", r.doc, articleContent); err != nil {
+		return nil, err
+	}
+
+	runHook(r.AfterGrab, articleContent)
 
 	if articleContent != nil {
+		if r.KeepBylineInContent {
+			r.insertByline(articleContent)
+		}
+
+		if r.IncludeHeroImage {
+			r.insertHeroImage(articleContent, metadata.Image)
+		}
+
+		metadata.PullQuotes = r.extractPullQuotes(articleContent, pullQuoteTexts)
+		metadata.Tables = r.extractTables(articleContent)
+		metadata.Quotes = r.extractQuotes(articleContent)
 		r.postProcessContent(articleContent)
 
+		if r.sourceNodes != nil {
+			positions := r.extractSourcePositions(articleContent)
+
+			if r.RecordSourcePositions {
+				metadata.SourcePositions = positions
+			}
+		}
+
+		metadata.Updates = r.extractLiveUpdates(articleContent)
+		metadata.Transcript = r.extractTranscript(articleContent)
+		metadata.Times = r.extractTimes(articleContent)
+
+		// The machine-readable date of the content is more reliable than the
+		// date written in the byline.
+		if metadata.PublishedTime == "" {
+			metadata.PublishedTime = firstDateTime(metadata.Times)
+		}
+
 		// If we have not found an excerpt in the article's metadata, use the
 		// article's first paragraph as the excerpt. This is used for displaying
 		// a preview of the article's content.
@@ -1976,9 +2966,24 @@ func (r *Readability) Parse(input io.Reader, pageURL string) (Article, error) {
 		}
 
 		readableNode = firstElementChild(articleContent)
-		finalHTMLContent = innerHTML(articleContent)
-		finalTextContent = textContent(articleContent)
-		finalTextContent = strings.TrimSpace(finalTextContent)
+		metadata.Images = r.getArticleImages(articleContent)
+		metadata.Gallery = extractGallery(articleContent)
+
+		// Remove the attributes that are not needed to render the content,
+		// once the dimensions of the images have been read.
+		r.scrubAttributes(articleContent)
+
+		if r.EmbedImages {
+			r.embedImages(articleContent)
+		}
+
+		runHook(r.AfterPostProcess, articleContent)
+	}
+
+	timings.PostProcess = time.Since(mark)
+
+	if err := r.checkInvariants("post-process", articleContent); err != nil {
+		return nil, err
 	}
 
 	finalByline := metadata.Byline
@@ -1987,18 +2992,157 @@ func (r *Readability) Parse(input io.Reader, pageURL string) (Article, error) {
 		finalByline = r.articleByline
 	}
 
-	return Article{
-		Title:       r.articleTitle,
-		Byline:      finalByline,
-		Node:        readableNode,
-		Content:     finalHTMLContent,
-		TextContent: finalTextContent,
-		Length:      len(finalTextContent),
-		Excerpt:     metadata.Excerpt,
-		SiteName:    metadata.SiteName,
-		Image:       metadata.Image,
-		Favicon:     metadata.Favicon,
-	}, nil
+	// Bylines often include the date of the article, keep them apart.
+	if author, date := splitBylineDate(finalByline); date != "" {
+		finalByline = author
+
+		if metadata.PublishedTime == "" {
+			metadata.PublishedTime = date
+		}
+	}
+
+	if len(r.Languages) > 0 {
+		finalByline = r.trimBylinePrefix(finalByline)
+	}
+
+	result := &Result{
+		Article: Article{
+			Title:           r.articleTitle,
+			Byline:          finalByline,
+			URL:             pageURL,
+			RequestedURL:    pageURL,
+			ThemeColor:      metadata.ThemeColor,
+			TileColor:       metadata.TileColor,
+			Logo:            logo,
+			RelLinks:        metadata.RelLinks,
+			Translations:    metadata.Translations,
+			OEmbedURLs:      metadata.OEmbedURLs,
+			OEmbed:          metadata.OEmbed,
+			RawContent:      rawContent,
+			NoIndex:         robots.noIndex,
+			NoArchive:       robots.noArchive,
+			Node:            readableNode,
+			Excerpt:         metadata.Excerpt,
+			SiteName:        metadata.SiteName,
+			Image:           metadata.Image,
+			Favicon:         metadata.Favicon,
+			PublishedTime:   metadata.PublishedTime,
+			Images:          metadata.Images,
+			Gallery:         metadata.Gallery,
+			CommentCount:    stats.commentCount,
+			Updates:         metadata.Updates,
+			Transcript:      metadata.Transcript,
+			PullQuotes:      metadata.PullQuotes,
+			Quotes:          metadata.Quotes,
+			Tables:          metadata.Tables,
+			Times:           metadata.Times,
+			SourcePositions: metadata.SourcePositions,
+			content:         articleContent,
+			transforms:      r.TextTransforms,
+		},
+		omitStrings: r.OmitContentStrings,
+	}
+
+	if len(stats.counts) > 0 {
+		result.InteractionCounts = stats.counts
+	}
+
+	result.Features = features
+
+	if r.ReportRemovals {
+		result.Removals = r.selectedRemovals()
+	}
+
+	if r.AuditAccessibility && articleContent != nil {
+		result.AccessibilityIssues = r.auditAccessibility(articleContent)
+	}
+
+	r.fingerprint(result)
+	result.Attempts = r.attemptReports()
+
+	if r.selectedAttempt != -1 {
+		result.TopCandidatePath = r.attempts[r.selectedAttempt].topCandidatePath
+	}
+
+	result.Confidence = r.confidence(articleContent, result.Length)
+	result.Engine = r.Engine
+
+	if r.CrossCheck {
+		r.crossCheck(result, alternativeContent)
+	}
+
+	timings.Total = time.Since(start)
+	result.Timings = timings
+
+	return result, nil
+}
+
+// ParseNode finds the main readable content of a document that was already
+// parsed, for example by another scraper. The given node is not modified.
+//
+// If the node is not a document, it is handled as a fragment of one: an
+// <html> element becomes the root of a new document, a <body> element is
+// placed inside an <html> element, and any other node is placed inside the
+// <body> of an otherwise empty document.
+func (r *Readability) ParseNode(node *html.Node, pageURL string) (Article, error) {
+	if node == nil {
+		return Article{}, ErrNilNode
+	}
+
+	result, err := r.analyzeDocument(documentFromNode(node), pageURL, time.Now())
+
+	if err != nil {
+		return Article{}, err
+	}
+
+	return result.article(), nil
+}
+
+// ParseReaderAt parses a seekable input in two phases. First, it decides if
+// the document is usable with the same heuristics as IsReadable, but looking
+// only at the first ReadablePrefixSize bytes of the input, once decoded with
+// the ContentEncoding. Then, only if the prefix looks like an article, the
+// whole input is read and parsed.
+//
+// If the prefix does not look like an article, ErrNotReadable is returned
+// together with an Article that contains only the metadata that was found
+// in the prefix, which is usually enough to describe the page.
+func (r *Readability) ParseReaderAt(input io.ReaderAt, size int64, pageURL string) (Article, error) {
+	var prefix io.Reader = io.NewSectionReader(input, 0, size)
+
+	if r.ContentEncoding != "" {
+		decoded, err := decodeContent(prefix, r.ContentEncoding)
+
+		if err != nil {
+			return Article{}, err
+		}
+
+		prefix = decoded
+	}
+
+	if r.ReadablePrefixSize > 0 {
+		prefix = io.LimitReader(prefix, r.ReadablePrefixSize)
+	}
+
+	doc, err := html.Parse(prefix)
+
+	if err != nil {
+		return Article{}, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	if !r.isReadableDocument(doc) {
+		if r.documentURI, err = url.ParseRequestURI(pageURL); err != nil {
+			return Article{}, fmt.Errorf("failed to parse URL: %v", err)
+		}
+
+		r.doc = doc
+		r.removeScripts(r.doc)
+		metadata := r.getArticleMetadata()
+
+		return metadata, ErrNotReadable
+	}
+
+	return r.Parse(io.NewSectionReader(input, 0, size), pageURL)
 }
 
 // IsReadable decides whether the document is usable or not without parsing the
@@ -2011,6 +3155,11 @@ func (r *Readability) IsReadable(input io.Reader) bool {
 		return false
 	}
 
+	return r.isReadableDocument(doc)
+}
+
+// isReadableDocument decides whether the parsed document is usable or not.
+func (r *Readability) isReadableDocument(doc *html.Node) bool {
 	// Get <p> and <pre> nodes. Also get DIV nodes which have BR node(s) and
 	// append them into the `nodes` variable. Some articles' DOM structures
 	// might look like:
@@ -2059,8 +3208,8 @@ func (r *Readability) IsReadable(input io.Reader) bool {
 			return false
 		}
 
-		matchString := className(node) + "\x20" + id(node)
-		if rxUnlikelyCandidates.MatchString(matchString) &&
+		matchString := r.matchString(node)
+		if r.isUnlikelyCandidate(matchString) &&
 			!rxOkMaybeItsACandidate.MatchString(matchString) {
 			return false
 		}
@@ -1,6 +1,7 @@
 package readability
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cixtor/readability/diff"
 	"golang.org/x/net/html"
 )
 
@@ -56,115 +58,39 @@ func TestRemoveScripts(t *testing.T) {
 	}
 }
 
-func getNodeExcerpt(node *html.Node) string {
-	outer := outerHTML(node)
-	outer = strings.Join(strings.Fields(outer), "\x20")
-	if len(outer) < 500 {
-		return outer
+func TestReplaceBrs(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<div>foo<br>bar<br>baz</div>`, `<div>foo<br/>bar<br/>baz</div>`},
+		{`<div>foo<br>bar<br><br>abc<br>def</div>`, `<div>foo<br/>bar<p>abc<br/>def</p></div>`},
+		{`<div>foo<br><br><div>bar</div></div>`, `<div>foo<p></p><div>bar</div></div>`},
 	}
-	return outer[:500]
-}
-
-func errColorDiff(label string, a string, b string) error {
-	coloredA := ""
-	coloredB := ""
-	for i := 0; i < len(a); i++ {
-		if b[i] == a[i] {
-			coloredA += a[i : i+1]
-			coloredB += b[i : i+1]
-			continue
-		}
-		coloredA += "\x1b[0;92m" + a[i:] + "\x1b[0m"
-		coloredB += "\x1b[0;91m" + b[i:] + "\x1b[0m"
-		break
-	}
-	return fmt.Errorf("%s\n- %s\n+ %s", label, coloredA, coloredB)
-}
 
-func compareArticleContent(result *html.Node, expected *html.Node) error {
-	// Make sure number of nodes is same
-	resultNodesCount := len(children(result))
-	expectedNodesCount := len(children(expected))
-	if resultNodesCount != expectedNodesCount {
-		return fmt.Errorf(
-			"number of nodes is different, want %d got %d",
-			expectedNodesCount,
-			resultNodesCount,
-		)
-	}
-
-	resultNode := result
-	expectedNode := expected
-	for resultNode != nil && expectedNode != nil {
-		// Get node excerpt
-		resultExcerpt := getNodeExcerpt(resultNode)
-		expectedExcerpt := getNodeExcerpt(expectedNode)
-
-		// Compare tag name
-		resultTagName := tagName(resultNode)
-		expectedTagName := tagName(expectedNode)
-		if resultTagName != expectedTagName {
-			return fmt.Errorf(
-				"tag name is different\nwant: %s (%s)\ngot : %s (%s)",
-				expectedTagName,
-				expectedExcerpt,
-				resultTagName,
-				resultExcerpt,
-			)
-		}
+	for _, test := range tests {
+		doc, err := html.Parse(strings.NewReader(test.input))
 
-		// Compare attributes
-		resultAttrCount := len(resultNode.Attr)
-		expectedAttrCount := len(expectedNode.Attr)
-		if resultAttrCount != expectedAttrCount {
-			return fmt.Errorf(
-				"number of attributes is different\nwant: %d (%s)\ngot : %d (%s)",
-				expectedAttrCount,
-				expectedExcerpt,
-				resultAttrCount,
-				resultExcerpt,
-			)
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
 		}
 
-		for _, resultAttr := range resultNode.Attr {
-			expectedAttrVal := getAttribute(expectedNode, resultAttr.Key)
-			switch resultAttr.Key {
-			case "href", "src":
-				resultAttr.Val = strings.TrimSuffix(resultAttr.Val, "/")
-				expectedAttrVal = strings.TrimSuffix(expectedAttrVal, "/")
-			}
+		div := getElementsByTagName(doc, "div")[0]
+		New().replaceBrs(div)
 
-			if resultAttr.Val != expectedAttrVal {
-				return fmt.Errorf(
-					"attribute %s is different\nwant: %s (%s)\ngot : %s (%s)",
-					resultAttr.Key,
-					expectedAttrVal,
-					expectedExcerpt,
-					resultAttr.Val,
-					resultExcerpt,
-				)
-			}
+		if output := outerHTML(div); output != test.expected {
+			t.Fatalf("replaceBrs(%s) = %s, expecting %s", test.input, output, test.expected)
 		}
+	}
+}
 
-		// Compare text content
-		resultText := strings.TrimSpace(textContent(resultNode))
-		expectedText := strings.TrimSpace(textContent(expectedNode))
-
-		resultText = strings.Join(strings.Fields(resultText), "\x20")
-		expectedText = strings.Join(strings.Fields(expectedText), "\x20")
-
-		if resultText != expectedText {
-			return errColorDiff(
-				"text content is different",
-				expectedExcerpt,
-				resultExcerpt,
-			)
-		}
+// compareArticleContent returns the first difference between the content of
+// the article and the expected content.
+func compareArticleContent(result *html.Node, expected *html.Node) error {
+	diffs := diff.Nodes(expected, result, diff.Options{IgnoreTrailingSlash: true})
 
-		// Move to next node
-		r := Readability{}
-		resultNode = r.getNextNode(resultNode, false)
-		expectedNode = r.getNextNode(expectedNode, false)
+	if len(diffs) > 0 {
+		return fmt.Errorf("%s", diffs[0])
 	}
 
 	return nil
@@ -225,3 +151,291 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeTypography(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<p>“Don’t panic” — said the guide…&nbsp;twice.</p>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.TextTransforms = []TextTransform{NormalizeTypography}
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := `"Don't panic" -- said the guide... twice.`
+
+	if a.TextContent != expected {
+		t.Fatalf("typography was not normalized: %q", a.TextContent)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<h2>Chapter one</h2>
+				<p>Lorem ipsum dolor sit amet, <em>consectetur</em> adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<ul><li>first item</li><li>second <a href="/item">item</a></li></ul>
+			</article>
+		</body>
+		</html>`)
+
+	res, err := New().Analyze(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if res.Content != "" || res.TextContent != "" {
+		t.Fatalf("content should only be available through methods")
+	}
+
+	if res.html != nil || res.text != nil || res.markdown != nil {
+		t.Fatalf("renderings should only be computed on the first call")
+	}
+
+	// The article is shorter than CharThresholds, so every attempt runs and
+	// the first one with the longest text is selected.
+	if len(res.Attempts) != 4 || !res.Attempts[0].Selected {
+		t.Fatalf("expecting the first of four attempts to be selected: %#v", res.Attempts)
+	}
+
+	if res.Length != len(res.Text()) {
+		t.Fatalf("length does not match the text: %d != %d", res.Length, len(res.Text()))
+	}
+
+	expected := "## Chapter one\n\n" +
+		"Lorem ipsum dolor sit amet, _consectetur_ adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.\n\n" +
+		"- first item\n" +
+		"- second [item](https://cixtor.com/item)"
+
+	if markdown := res.Markdown(); markdown != expected {
+		t.Fatalf("unexpected markdown:\n%s", markdown)
+	}
+}
+
+func TestClientSideRendered(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<noscript>You need to enable JavaScript to run this app.</noscript>
+			<div id="root"></div>
+			<script src="/static/js/main.js"></script>
+		</body>
+		</html>`)
+
+	_, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != ErrClientSideRendered {
+		t.Fatalf("expecting ErrClientSideRendered: %v", err)
+	}
+}
+
+func TestAdditionalTokens(t *testing.T) {
+	parser := New()
+	parser.AdditionalPositiveTokens = []string{"Prose"}
+	parser.AdditionalNegativeTokens = []string{"rail"}
+	parser.flags.useWeightClasses = true
+
+	node := createElement("div")
+	setAttribute(node, "class", "prose")
+
+	if weight := parser.getClassWeight(node); weight != 25 {
+		t.Fatalf("unexpected weight for positive tokens: %d", weight)
+	}
+
+	setAttribute(node, "class", "right-rail")
+	setAttribute(node, "id", "prose")
+
+	if weight := parser.getClassWeight(node); weight != 0 {
+		t.Fatalf("unexpected weight for mixed tokens: %d", weight)
+	}
+}
+
+func TestModals(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<div role="dialog" aria-label="Subscribe">
+				<p>Subscribe to our newsletter to get the latest stories, the best of our archive, exclusive offers and much more.</p>
+				<p>Your inbox, every morning, with everything you need to know, curated by our editors and sent at dawn.</p>
+			</div>
+			<dialog><p>Share this article with your friends, family, colleagues and everyone else who might like it.</p></dialog>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit.</p>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.TextContent != "Lorem ipsum dolor sit amet, consectetur adipiscing elit." {
+		t.Fatalf("modal content was not excluded: %q", a.TextContent)
+	}
+}
+
+func TestDetachedNodes(t *testing.T) {
+	detached := func(markup string) *html.Node {
+		doc, _ := html.Parse(strings.NewReader(markup))
+		node := firstElementChild(getElementsByTagName(doc, "body")[0])
+		node.Parent.RemoveChild(node)
+
+		return node
+	}
+
+	parser := New()
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"cleanStyles nil", func() { parser.cleanStyles(nil) }},
+		{"cleanStyles detached", func() { parser.cleanStyles(detached(`<p style="color:red">lorem</p>`)) }},
+		{"replaceBrs detached", func() { parser.replaceBrs(detached(`<div>foo<br><br>bar<br><br>abc</div>`)) }},
+		{"replaceBrs bare br", func() { parser.replaceBrs(detached(`<br>`)) }},
+		{"nextElement nil", func() { parser.nextElement(nil) }},
+		{"getNextNode nil", func() { parser.getNextNode(nil, false) }},
+		{"getNextNode detached", func() { parser.getNextNode(detached(`<p>lorem</p>`), true) }},
+		{"removeAndGetNext nil", func() { parser.removeAndGetNext(nil) }},
+		{"removeAndGetNext detached", func() { parser.removeAndGetNext(detached(`<p>lorem</p>`)) }},
+		{"hasAncestorTag nil", func() { parser.hasAncestorTag(nil, "div", 3, nil) }},
+		{"getNodeAncestors nil", func() { parser.getNodeAncestors(nil, 0) }},
+		{"setNodeTag nil", func() { parser.setNodeTag(nil, "div") }},
+		{"isProbablyVisible detached", func() { parser.isProbablyVisible(detached(`<p hidden>lorem</p>`)) }},
+		{"fixRelativeURIs detached", func() { parser.fixRelativeURIs(detached(`<a href="javascript:void(0)">lorem</a>`)) }},
+		{"tagName nil", func() { tagName(nil) }},
+		{"textContent nil", func() { textContent(nil) }},
+		{"getElementsByTagName nil", func() { getElementsByTagName(nil, "*") }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if err := recover(); err != nil {
+					t.Fatalf("panic: %v", err)
+				}
+			}()
+
+			test.fn()
+		})
+	}
+
+	if _, err := parser.ParseNode(nil, "https://cixtor.com/blog"); err != ErrNilNode {
+		t.Fatalf("expecting ErrNilNode: %v", err)
+	}
+}
+
+// largePage returns a document with an article surrounded by thousands of
+// elements that are removed while the content is cleaned.
+func largePage(blocks int) string {
+	var page strings.Builder
+
+	page.WriteString(`<html><head><title>hello world</title></head><body><article>`)
+
+	for i := 0; i < blocks; i++ {
+		fmt.Fprintf(&page, `<div class="block">
+			<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua %d.</p>
+			<div class="share"><a href="/share/%d">Share</a></div>
+			<aside>Related</aside>
+			<iframe src="/ads/%d"></iframe>
+			<div><br><br></div>
+			<p></p>
+		</div>`, i, i, i)
+	}
+
+	page.WriteString(`</article></body></html>`)
+
+	return page.String()
+}
+
+func BenchmarkParseLargePage(b *testing.B) {
+	page := largePage(5000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := New().Parse(strings.NewReader(page), "https://cixtor.com/blog"); err != nil {
+			b.Fatalf("parser failure: %s", err)
+		}
+	}
+}
+
+func TestMarkDataTables(t *testing.T) {
+	row := "<tr><td>a</td><td>b</td><td>c</td><td>d</td><td>e</td></tr>"
+	doc, err := html.Parse(strings.NewReader(`<table id="data">` + strings.Repeat(row, 3) + `</table>` +
+		`<table id="layout"><tr><td><table><tr><td>nested</td></tr></table></td></tr></table>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	r := New()
+	r.markDataTables(doc)
+
+	for _, table := range getElementsByTagName(doc, "table") {
+		if expected := id(table) == "data"; r.isReadabilityDataTable(table) != expected {
+			t.Fatalf("table %q should be marked as data table: %t", id(table), expected)
+		}
+	}
+}
+
+func TestParseReaderAt(t *testing.T) {
+	paragraph := "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua, ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>"
+	head := `<html><head><title>hello world</title><meta name="description" content="An article"></head><body>`
+	readable := head + "<article>" + strings.Repeat(paragraph, 4) + "</article></body></html>"
+	padded := head + "<nav>" + strings.Repeat(`<a href="/">Home</a>`, 200) + "</nav><article>" + strings.Repeat(paragraph, 4) + "</article></body></html>"
+
+	parser := New()
+	parser.ReadablePrefixSize = 2048
+	input := strings.NewReader(readable)
+	a, err := parser.ParseReaderAt(input, input.Size(), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Title != "hello world" || !strings.Contains(a.TextContent, "Lorem ipsum") {
+		t.Fatalf("the readable document was not parsed: %#v", a)
+	}
+
+	// The paragraphs start after the prefix, only the metadata is returned.
+	input = strings.NewReader(padded)
+	a, err = parser.ParseReaderAt(input, input.Size(), "https://cixtor.com/blog")
+
+	if !errors.Is(err, ErrNotReadable) {
+		t.Fatalf("expecting ErrNotReadable: %v", err)
+	}
+
+	if a.Title != "hello world" || a.Excerpt != "An article" || a.Content != "" {
+		t.Fatalf("expecting only the metadata of the prefix: %#v", a)
+	}
+
+	// The prefix is larger than the input, the whole input is checked.
+	parser.ReadablePrefixSize = int64(len(readable) * 10)
+	input = strings.NewReader(readable)
+	a, err = parser.ParseReaderAt(input, input.Size(), "https://cixtor.com/blog")
+
+	if err != nil || !strings.Contains(a.TextContent, "Lorem ipsum") {
+		t.Fatalf("the short input was not parsed: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package readability
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// defaultReadMorePrefixes is the list of phrases that introduce links to other
+// articles of the same website, in the default languages.
+var defaultReadMorePrefixes = languagePhrases(defaultLanguages, func(pack LanguagePack) []string {
+	return pack.ReadMorePrefixes
+})
+
+// readMoreElems is a list of HTML tag names that can hold a read more block.
+var readMoreElems = []string{"p", "div", "li", "ul", "ol", "section", "h2", "h3", "h4", "h5", "h6"}
+
+// removeReadMoreBlocks removes the blocks that only contain links to other
+// articles introduced by one of the ReadMorePrefixes, for example:
+//
+//	<p>Read more: <a href="/other">Other article</a></p>
+//
+// It also removes the blocks that only contain the introductory phrase when
+// the next block contains only links, which is the usual markup of lists of
+// related articles.
+func (r *Readability) removeReadMoreBlocks(articleContent *html.Node) {
+	prefixes := r.languagePhrases(r.ReadMorePrefixes, func(pack LanguagePack) []string {
+		return pack.ReadMorePrefixes
+	})
+
+	if len(prefixes) == 0 {
+		return
+	}
+
+	var blocks []*html.Node
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		if node == articleContent || indexOf(readMoreElems, tagName(node)) == -1 {
+			continue
+		}
+
+		if len(blocks) > 0 && r.hasAncestorIn(node, blocks) {
+			continue
+		}
+
+		text := strings.TrimSpace(r.getInnerText(node, true))
+		prefix := readMorePrefix(prefixes, text)
+
+		if prefix == "" {
+			continue
+		}
+
+		if isLinkOnly(node, len(prefix)) {
+			blocks = append(blocks, node)
+			continue
+		}
+
+		// The phrase may be a heading for the list of links that follows.
+		rest := strings.TrimFunc(text[len(prefix):], isSeparator)
+		next := nextElementSibling(node)
+
+		if rest == "" && next != nil && isLinkOnly(next, 0) {
+			blocks = append(blocks, node, next)
+		}
+	}
+
+	r.removeNodes(blocks, r.reportingFilter(RemovalReadMore, nil))
+}
+
+// readMorePrefix returns the text matching one of the prefixes at the beginning
+// of text, or an empty string if there is no match. The prefix must be followed
+// by a character that is not a letter to avoid partial matches.
+func readMorePrefix(prefixes []string, text string) string {
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			continue
+		}
+
+		// Compare the same number of characters instead of bytes, changing
+		// the case can change the length of the text in bytes.
+		end, count := 0, utf8.RuneCountInString(prefix)
+
+		for end < len(text) && count > 0 {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+			count--
+		}
+
+		if count > 0 || !strings.EqualFold(text[:end], prefix) {
+			continue
+		}
+
+		if next, _ := utf8.DecodeRuneInString(text[end:]); end == len(text) || !unicode.IsLetter(next) {
+			return text[:end]
+		}
+	}
+
+	return ""
+}
+
+// isLinkOnly returns true if node contains links and, after skipping the first
+// skip bytes of its text, all the text outside the links is punctuation.
+func isLinkOnly(node *html.Node, skip int) bool {
+	links := getElementsByTagName(node, "a")
+
+	if len(links) == 0 {
+		return false
+	}
+
+	var outside strings.Builder
+	var finder func(*html.Node)
+
+	finder = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			outside.WriteString(n.Data)
+		}
+
+		if tagName(n) == "a" {
+			outside.WriteString("\x20")
+			return
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			finder(c)
+		}
+	}
+
+	finder(node)
+
+	text := strings.TrimSpace(outside.String())
+
+	if skip > len(text) {
+		skip = len(text)
+	}
+
+	return strings.TrimFunc(text[skip:], isSeparator) == ""
+}
+
+// isSeparator returns true for the characters used to separate links.
+func isSeparator(c rune) bool {
+	return unicode.IsSpace(c) || unicode.IsPunct(c) || unicode.IsSymbol(c)
+}
@@ -0,0 +1,62 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveReadMoreBlocks(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.</p>
+				<p>Read more: <a href="/other">Other article</a></p>
+				<p>Duis aute irure dolor in reprehenderit, read more about it <a href="/docs">here</a>.</p>
+				<p>Lire aussi :</p>
+				<ul><li><a href="/un">Un article</a></li><li><a href="/deux">Deux articles</a></li></ul>
+				<p>Related reading is the best kind of reading, sed ut perspiciatis unde omnis.</p>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, removed := range []string{"Other article", "Lire aussi", "Un article"} {
+		if strings.Contains(a.Content, removed) {
+			t.Fatalf("read more block was not removed: %s", a.Content)
+		}
+	}
+
+	for _, kept := range []string{"read more about it", "Related reading"} {
+		if !strings.Contains(a.Content, kept) {
+			t.Fatalf("paragraph was removed: %s", a.Content)
+		}
+	}
+}
+
+func TestReadMorePrefix(t *testing.T) {
+	prefixes := []string{"read more", "kelvin", "lee también"}
+
+	tests := map[string]string{
+		"Read more: the next story": "Read more",
+		"READ MORE":                 "READ MORE",
+		"Read moreover":             "",
+		"LEE TAMBIÉN: otra noticia": "LEE TAMBIÉN",
+		"Kelvin news":               "Kelvin",
+		"İİİİİİİİİ":                 "",
+		"Read":                      "",
+	}
+
+	for text, expected := range tests {
+		if result := readMorePrefix(prefixes, text); result != expected {
+			t.Fatalf("readMorePrefix(%q) = %q, expecting %q", text, result, expected)
+		}
+	}
+}
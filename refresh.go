@@ -0,0 +1,87 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ErrRedirectPage is returned, wrapped in a RedirectError, when the document
+// is an interstitial page that redirects the browser to another page.
+var ErrRedirectPage = errors.New("document redirects to another page")
+
+// metaRefreshMaxDelay is the longest delay, in seconds, of a meta refresh for
+// the document to be considered an interstitial page. Pages with a longer
+// delay are regular pages that send the reader somewhere else later.
+const metaRefreshMaxDelay = 10
+
+// rxMetaRefresh matches the content of a meta refresh, like "0; url=/next",
+// capturing the delay and the target URL, which can be quoted.
+var rxMetaRefresh = regexp.MustCompile(`(?i)^\s*(\d+)(?:\.\d*)?\s*(?:[;,]\s*(?:url\s*=\s*)?["']?([^"']*)["']?)?\s*$`)
+
+// RedirectError is returned when the document redirects the browser to
+// another page with a meta refresh. It wraps ErrRedirectPage.
+type RedirectError struct {
+	// URL is the absolute address of the page the document redirects to.
+	URL string
+
+	// Delay is the number of seconds the browser waits before redirecting.
+	Delay int
+}
+
+// Error implements the error interface.
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrRedirectPage, e.URL)
+}
+
+// Unwrap returns ErrRedirectPage, so errors.Is can be used to check the error.
+func (e *RedirectError) Unwrap() error {
+	return ErrRedirectPage
+}
+
+// metaRefresh returns the redirect declared by the <meta http-equiv="refresh">
+// element of the document, or nil if the document does not redirect to
+// another page soon enough to be an interstitial page.
+func (r *Readability) metaRefresh(doc *html.Node) *RedirectError {
+	for _, meta := range getElementsByTagName(doc, "meta") {
+		if !strings.EqualFold(strings.TrimSpace(getAttribute(meta, "http-equiv")), "refresh") {
+			continue
+		}
+
+		m := rxMetaRefresh.FindStringSubmatch(getAttribute(meta, "content"))
+
+		if m == nil || strings.TrimSpace(m[2]) == "" {
+			continue
+		}
+
+		delay, err := strconv.Atoi(m[1])
+
+		if err != nil || delay > metaRefreshMaxDelay {
+			continue
+		}
+
+		target := toAbsoluteURI(strings.TrimSpace(m[2]), r.documentURI)
+
+		// A page refreshing itself is not a redirect.
+		if target == "" || stripFragment(target) == stripFragment(r.documentURI.String()) {
+			continue
+		}
+
+		return &RedirectError{URL: target, Delay: delay}
+	}
+
+	return nil
+}
+
+// stripFragment removes the fragment identifier from the URL.
+func stripFragment(uri string) string {
+	if hash := strings.Index(uri, "#"); hash != -1 {
+		return uri[:hash]
+	}
+
+	return uri
+}
@@ -0,0 +1,80 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetaRefresh(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		target  string
+	}{
+		{"relative", `0; url=/next`, "https://cixtor.com/next"},
+		{"quoted", `1;URL='https://example.com/a'`, "https://example.com/a"},
+		{"without url key", `0, https://example.com/b`, "https://example.com/b"},
+		{"no target", `5`, ""},
+		{"long delay", `300; url=/next`, ""},
+		{"same page", `0; url=/blog#top`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := fmt.Sprintf(`<html><head><meta http-equiv="Refresh" content="%s"></head><body><p>Redirecting…</p></body></html>`, tt.content)
+
+			_, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+			var redirect *RedirectError
+
+			if tt.target == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if !errors.As(err, &redirect) || !errors.Is(err, ErrRedirectPage) {
+				t.Fatalf("expected redirect error, got %v", err)
+			}
+
+			if redirect.URL != tt.target {
+				t.Fatalf("unexpected target: %q", redirect.URL)
+			}
+		})
+	}
+}
+
+func TestParseURLFollowMetaRefresh(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`<html><head><meta http-equiv="refresh" content="0; url=/new"></head><body></body></html>`))
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(fetcherTestPage))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	if _, err := New().ParseURL(server.URL + "/old"); !errors.Is(err, ErrRedirectPage) {
+		t.Fatalf("expected redirect error, got %v", err)
+	}
+
+	parser := New()
+	parser.FollowMetaRefresh = true
+	a, err := parser.ParseURL(server.URL + "/old")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.TextContent != "lorem ipsum" || a.URL != server.URL+"/new" || a.RequestedURL != server.URL+"/old" {
+		t.Fatalf("unexpected article: %q %q %q", a.TextContent, a.URL, a.RequestedURL)
+	}
+}
@@ -0,0 +1,148 @@
+package readability
+
+import (
+	"golang.org/x/net/html"
+)
+
+// RemovalReason identifies the rule that removed an element from the document.
+type RemovalReason string
+
+const (
+	// RemovalHidden is used for the elements hidden with CSS or attributes.
+	RemovalHidden RemovalReason = "hidden"
+
+	// RemovalByline is used for the byline, which is returned apart.
+	RemovalByline RemovalReason = "byline"
+
+	// RemovalUnlikely is used for the elements whose class name or ID, like
+	// "sidebar" or "comments", suggest that they are not part of the content.
+	RemovalUnlikely RemovalReason = "unlikely"
+
+	// RemovalModal is used for dialogs, like signup and share modals.
+	RemovalModal RemovalReason = "modal"
+
+	// RemovalEmpty is used for the blocks and paragraphs without content.
+	RemovalEmpty RemovalReason = "empty"
+
+	// RemovalTag is used for the elements removed because of their tag name,
+	// like <aside>, <footer> or <iframe>.
+	RemovalTag RemovalReason = "tag"
+
+	// RemovalConditional is used for the elements removed by the conditional
+	// cleaning, which considers their link density, the number of images
+	// and embeds, and their class weight.
+	RemovalConditional RemovalReason = "conditional"
+
+	// RemovalShare is used for the share buttons found inside the content.
+	RemovalShare RemovalReason = "share"
+
+	// RemovalHeader is used for the headings with a negative class weight.
+	RemovalHeader RemovalReason = "header"
+
+	// RemovalAdSlot is used for the placeholders of the ad services.
+	RemovalAdSlot RemovalReason = "ad-slot"
+
+	// RemovalConsent is used for cookie banners and consent dialogs.
+	RemovalConsent RemovalReason = "consent"
+
+	// RemovalNewsletter is used for the newsletter signup blocks.
+	RemovalNewsletter RemovalReason = "newsletter"
+
+	// RemovalReadMore is used for the links to related articles.
+	RemovalReadMore RemovalReason = "read-more"
+
+	// RemovalTrackingPixel is used for the invisible tracking images.
+	RemovalTrackingPixel RemovalReason = "tracking-pixel"
+
+	// RemovalBoilerplate is used for the syndication footers.
+	RemovalBoilerplate RemovalReason = "boilerplate"
+
+	// RemovalIcon is used for the inline SVG icons, see StripIcons.
+	RemovalIcon RemovalReason = "icon"
+)
+
+// Removal describes an element removed from the document while the content
+// was extracted.
+type Removal struct {
+	// Tag is the tag name of the element.
+	Tag string
+
+	// Class is the class name of the element.
+	Class string
+
+	// ID is the id attribute of the element.
+	ID string
+
+	// Reason is the rule that removed the element.
+	Reason RemovalReason
+
+	// TextLength is the length of the text of the element, without leading
+	// and trailing whitespace, which helps to tell apart the removal of an
+	// important block from the removal of an empty wrapper.
+	TextLength int
+
+	// Attempt is the index of the grabber attempt that removed the element,
+	// or -1 if it was removed while the document was prepared. See Attempts.
+	Attempt int
+
+	// Path is the path of the element in the document, like
+	// "html>body>div#main>aside", see Result.TopCandidatePath.
+	Path string
+
+	// index is the position of the element in the document analyzed by Plan.
+	index int
+}
+
+// reportRemoval adds node to the removal report, if enabled. It must be called
+// before the element is removed.
+func (r *Readability) reportRemoval(node *html.Node, reason RemovalReason) {
+	if !r.ReportRemovals || node.Type != html.ElementNode {
+		return
+	}
+
+	attempt := -1
+
+	if r.grabbing {
+		attempt = len(r.attempts)
+	}
+
+	r.removals = append(r.removals, Removal{
+		Tag:        tagName(node),
+		Class:      className(node),
+		ID:         id(node),
+		Reason:     reason,
+		TextLength: len(r.getInnerText(node, true)),
+		Attempt:    attempt,
+		Path:       r.reportPath(node),
+		index:      planIndex(node),
+	})
+}
+
+// reportingFilter wraps a filter for removeNodes so the nodes that it selects
+// are added to the removal report.
+func (r *Readability) reportingFilter(reason RemovalReason, filter func(*html.Node) bool) func(*html.Node) bool {
+	return func(node *html.Node) bool {
+		if filter != nil && !filter(node) {
+			return false
+		}
+
+		r.reportRemoval(node, reason)
+
+		return true
+	}
+}
+
+// selectedRemovals returns the removals that affected the returned content,
+// which are the ones done while the document was prepared and the ones done
+// by the selected attempt.
+func (r *Readability) selectedRemovals() []Removal {
+	var removals []Removal
+
+	for _, removal := range r.removals {
+		if removal.Attempt == -1 || removal.Attempt == r.selectedAttempt {
+			removals = append(removals, removal)
+		}
+	}
+
+	return removals
+}
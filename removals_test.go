@@ -0,0 +1,44 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReportRemovals(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<div class="sidebar">Popular stories</div>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<img src="/hero.jpg" style="display:none">
+				<aside>Related: Ut enim ad minim veniam</aside>
+			</article>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.ReportRemovals = true
+	res, err := parser.Analyze(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	reasons := map[string]RemovalReason{}
+
+	for _, removal := range res.Removals {
+		if removal.Attempt != -1 && removal.Attempt != 0 {
+			t.Fatalf("unexpected removal from a discarded attempt: %#v", removal)
+		}
+
+		reasons[removal.Tag+"."+removal.Class] = removal.Reason
+	}
+
+	if reasons["div.sidebar"] != RemovalUnlikely || reasons["img."] != RemovalHidden || reasons["aside."] != RemovalTag {
+		t.Fatalf("unexpected removals: %#v", res.Removals)
+	}
+}
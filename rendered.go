@@ -0,0 +1,65 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// frameworkAttributePrefixes is a list of prefixes of the attributes added by
+// client-side frameworks like React, Vue, Angular and Svelte. The attributes
+// carry no content but they bloat rendered documents considerably.
+var frameworkAttributePrefixes = []string{
+	"_ngcontent-",
+	"_nghost-",
+	"data-emotion",
+	"data-hydration",
+	"data-ng-",
+	"data-react",
+	"data-server-rendered",
+	"data-svelte",
+	"data-testid",
+	"data-v-",
+	"jsaction",
+	"jscontroller",
+	"jsmodel",
+	"jsname",
+	"ng-",
+}
+
+// prepRenderedDocument removes the noise left in a document rendered by a
+// headless browser, so the heuristics see the same structure they would see
+// in a document rendered by the server.
+func (r *Readability) prepRenderedDocument(doc *html.Node) {
+	// Declarative shadow roots are serialized as <template> elements, unwrap
+	// them so their content is treated as part of the document.
+	r.forEachNode(getElementsByTagName(doc, "template"), func(template *html.Node, _ int) {
+		if hasAttribute(template, "shadowroot") || hasAttribute(template, "shadowrootmode") {
+			unwrapNode(template)
+		}
+	})
+
+	r.forEachNode(getElementsByTagName(doc, "*"), func(node *html.Node, _ int) {
+		attrs := node.Attr[:0]
+
+		for _, attr := range node.Attr {
+			if !isFrameworkAttribute(attr.Key) {
+				attrs = append(attrs, attr)
+			}
+		}
+
+		node.Attr = attrs
+	})
+}
+
+// isFrameworkAttribute determines if the attribute was added by a client-side
+// framework.
+func isFrameworkAttribute(name string) bool {
+	for _, prefix := range frameworkAttributePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
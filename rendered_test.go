@@ -0,0 +1,102 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestPrepRenderedDocument(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body>
+		<div id="app" class="app" data-reactroot="" data-v-1a2b3c="" _ngcontent-abc="" jsaction="click:x">
+			<my-card><template shadowrootmode="open"><p class="shadow">Shadow paragraph</p></template></my-card>
+			<template shadowroot="open"><p class="legacy">Legacy shadow paragraph</p></template>
+			<template id="row"><p>Template row</p></template>
+		</div>
+	</body></html>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	New().prepRenderedDocument(doc)
+
+	app := getElementsByTagName(doc, "div")[0]
+
+	if len(app.Attr) != 2 || id(app) != "app" || className(app) != "app" {
+		t.Fatalf("unexpected attributes: %#v", app.Attr)
+	}
+
+	templates := getElementsByTagName(doc, "template")
+
+	if len(templates) != 1 || id(templates[0]) != "row" {
+		t.Fatalf("only the shadow roots should be unwrapped: %d templates left", len(templates))
+	}
+
+	shadows := 0
+
+	for _, paragraph := range getElementsByTagName(doc, "p") {
+		if class := className(paragraph); class != "" {
+			if tagName(paragraph.Parent) == "template" {
+				t.Fatalf("shadow root %q was not unwrapped", class)
+			}
+
+			shadows++
+		}
+	}
+
+	if shadows != 2 {
+		t.Fatalf("the content of the shadow roots was lost: %d paragraphs", shadows)
+	}
+}
+
+func TestIsFrameworkAttribute(t *testing.T) {
+	tests := map[string]bool{
+		"data-reactroot":  true,
+		"data-v-1a2b3c":   true,
+		"_nghost-c12":     true,
+		"ng-version":      true,
+		"jsname":          true,
+		"data-testid":     true,
+		"data-src":        false,
+		"class":           false,
+		"href":            false,
+		"data-navigation": false,
+	}
+
+	for name, expected := range tests {
+		if result := isFrameworkAttribute(name); result != expected {
+			t.Fatalf("isFrameworkAttribute(%q) = %t, expecting %t", name, result, expected)
+		}
+	}
+}
+
+func TestRenderedDOMVisibility(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<div id="styled" style="display: none">lorem</div>` +
+		`<div id="hidden" hidden>ipsum</div>` +
+		`<div id="aria" aria-hidden="true">dolor</div>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	tests := []struct {
+		rendered bool
+		expected map[string]bool
+	}{
+		{false, map[string]bool{"styled": false, "hidden": false, "aria": false}},
+		{true, map[string]bool{"styled": true, "hidden": false, "aria": false}},
+	}
+
+	for _, test := range tests {
+		parser := New()
+		parser.RenderedDOM = test.rendered
+
+		for _, node := range getElementsByTagName(doc, "div") {
+			if visible := parser.isProbablyVisible(node); visible != test.expected[id(node)] {
+				t.Fatalf("RenderedDOM %t: element %q visible %t, expecting %t", test.rendered, id(node), visible, test.expected[id(node)])
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package readability
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// ArticleRenderer converts an article into a document format, for example a
+// standalone HTML page or a PDF file, and writes the result into w.
+type ArticleRenderer interface {
+	Render(w io.Writer, article Article) error
+}
+
+// HTMLRenderer renders the article as a standalone HTML document.
+type HTMLRenderer struct {
+	Theme Theme
+}
+
+// Render implements the ArticleRenderer interface.
+func (h HTMLRenderer) Render(w io.Writer, article Article) error {
+	return ExportHTML(w, article, h.Theme)
+}
+
+// HTMLConverter converts the HTML document read from r into a different
+// format, like PDF, and writes the result into w.
+type HTMLConverter interface {
+	Convert(w io.Writer, r io.Reader) error
+}
+
+// PDFRenderer renders the article as a standalone HTML document and passes it
+// to the Converter to produce a PDF file. Set EmbedImages when the article is
+// parsed so the converter does not need network access to render the images.
+type PDFRenderer struct {
+	Theme     Theme
+	Converter HTMLConverter
+}
+
+// Render implements the ArticleRenderer interface.
+func (p PDFRenderer) Render(w io.Writer, article Article) error {
+	if p.Converter == nil {
+		return fmt.Errorf("missing html converter")
+	}
+
+	var buffer bytes.Buffer
+
+	if err := ExportHTML(&buffer, article, p.Theme); err != nil {
+		return err
+	}
+
+	if err := p.Converter.Convert(w, &buffer); err != nil {
+		return fmt.Errorf("failed to convert html: %v", err)
+	}
+
+	return nil
+}
+
+// ExecConverter is an HTMLConverter that runs an external program, which reads
+// the HTML document from the standard input and writes the converted document
+// into the standard output. For example:
+//
+//	ExecConverter{Name: "wkhtmltopdf", Args: []string{"--quiet", "-", "-"}}
+type ExecConverter struct {
+	Name string
+	Args []string
+}
+
+// Convert implements the HTMLConverter interface.
+func (e ExecConverter) Convert(w io.Writer, r io.Reader) error {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(e.Name, e.Args...)
+	cmd.Stdin = r
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %v: %s", e.Name, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return nil
+}
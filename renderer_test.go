@@ -0,0 +1,27 @@
+package readability
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestPDFRenderer(t *testing.T) {
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("missing cat command")
+	}
+
+	var buffer bytes.Buffer
+
+	renderer := PDFRenderer{Theme: ThemeLight, Converter: ExecConverter{Name: "cat"}}
+	article := Article{Title: "hello world", Content: "<p>lorem ipsum</p>"}
+
+	if err := renderer.Render(&buffer, article); err != nil {
+		t.Fatalf("render failure: %s", err)
+	}
+
+	if !strings.HasPrefix(buffer.String(), "<!DOCTYPE html>") || !strings.Contains(buffer.String(), "<p>lorem ipsum</p>") {
+		t.Fatalf("converter did not receive the html document:\n%s", buffer.String())
+	}
+}
@@ -0,0 +1,125 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// repairInlineElems is a list of the presentational inline elements that old
+// pages leave unclosed, so the HTML parser extends them over the blocks that
+// follow them, like <font> or <b>.
+var repairInlineElems = []string{
+	"b", "big", "center", "em", "font", "i", "nobr", "s", "small", "span",
+	"strike", "strong", "tt", "u",
+}
+
+// repairBlockElems is a list of the block elements that cannot be inside of
+// an inline element.
+var repairBlockElems = []string{
+	"address", "article", "aside", "blockquote", "div", "dl", "fieldset",
+	"figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6", "header",
+	"hr", "main", "nav", "ol", "p", "pre", "section", "table", "ul",
+}
+
+// repairMinChain is the minimum number of nested <div> elements, each one with
+// its own text before the next one, to consider them unclosed.
+const repairMinChain = 3
+
+// repairMarkup rebalances the structures of the document left by unclosed
+// tags, which are common in legacy pages and produce deep trees where every
+// paragraph is nested inside of the previous one, so the score of the text is
+// only propagated to its closest ancestors and the content is split among many
+// candidates. See RepairMarkup.
+func (r *Readability) repairMarkup(doc *html.Node) {
+	// Unwrap the inline elements that contain blocks, starting with the
+	// innermost ones, so the blocks become siblings again.
+	nodes := r.getAllNodesWithTag(doc, repairInlineElems...)
+
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if hasChildBlock(nodes[i]) {
+			r.invalidateText(nodes[i].Parent)
+			unwrapNode(nodes[i])
+		}
+	}
+
+	// Flatten the chains of unclosed <div> elements into siblings.
+	for _, div := range getElementsByTagName(doc, "div") {
+		if div.Parent == nil || isChainLink(div.Parent, div) {
+			continue
+		}
+
+		chain := []*html.Node{div}
+
+		for next := lastChildDiv(div); next != nil && isChainLink(chain[len(chain)-1], next); next = lastChildDiv(next) {
+			chain = append(chain, next)
+		}
+
+		if len(chain) < repairMinChain {
+			continue
+		}
+
+		// Move each link after its parent, starting with the outermost one,
+		// so the links keep their order and their own text.
+		for i := 1; i < len(chain); i++ {
+			r.invalidateText(chain[i-1])
+			chain[i-1].Parent.InsertBefore(detach(chain[i]), chain[i-1].NextSibling)
+		}
+	}
+}
+
+// hasChildBlock returns true if node has a child block element.
+func hasChildBlock(node *html.Node) bool {
+	for child := firstElementChild(node); child != nil; child = nextElementSibling(child) {
+		if indexOf(repairBlockElems, tagName(child)) != -1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lastChildDiv returns the last child of node if it is a <div> and there is
+// no text after it, or nil.
+func lastChildDiv(node *html.Node) *html.Node {
+	for child := node.LastChild; child != nil; child = child.PrevSibling {
+		if child.Type == html.TextNode && strings.TrimSpace(child.Data) == "" || child.Type == html.CommentNode {
+			continue
+		}
+
+		if tagName(child) == "div" {
+			return child
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// isChainLink returns true if next is the last child of the <div> element,
+// as returned by lastChildDiv, and the element has its own text before it,
+// which is what an unclosed <div> looks like. Wrappers without text of their
+// own are left as they are, they are how the content is usually laid out.
+func isChainLink(div *html.Node, next *html.Node) bool {
+	if tagName(div) != "div" || lastChildDiv(div) != next {
+		return false
+	}
+
+	for child := div.FirstChild; child != nil && child != next; child = child.NextSibling {
+		if strings.TrimSpace(textContent(child)) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// detach removes node from its parent, if any, and returns it.
+func detach(node *html.Node) *html.Node {
+	if node.Parent != nil {
+		node.Parent.RemoveChild(node)
+	}
+
+	return node
+}
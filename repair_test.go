@@ -0,0 +1,85 @@
+package readability
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestRepairMarkup(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`<div>a<div>b<div>c<div>d</div></div></div></div>`,
+			`<div>a</div><div>b</div><div>c</div><div>d</div>`,
+		},
+		{
+			`<font size="2"><p>a</p><p>b</p></font><b>c</b>`,
+			`<p>a</p><p>b</p><b>c</b>`,
+		},
+		{
+			// Wrappers without text of their own are not unclosed.
+			`<div id="page"><div id="main"><div class="article">a</div></div></div>`,
+			`<div id="page"><div id="main"><div class="article">a</div></div></div>`,
+		},
+		{
+			// Chains shorter than repairMinChain are left as they are.
+			`<div>a<div>b</div></div>`,
+			`<div>a<div>b</div></div>`,
+		},
+		{
+			`<div>a<div>b<div>c</div>d</div></div>`,
+			`<div>a<div>b<div>c</div>d</div></div>`,
+		},
+	}
+
+	for _, test := range tests {
+		doc, err := html.Parse(strings.NewReader(test.input))
+
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
+		}
+
+		New().repairMarkup(doc)
+
+		if output := innerHTML(getElementsByTagName(doc, "body")[0]); output != test.expected {
+			t.Fatalf("repairMarkup(%s) = %s, expecting %s", test.input, output, test.expected)
+		}
+	}
+}
+
+func TestRepairMarkupExtraction(t *testing.T) {
+	var page strings.Builder
+
+	page.WriteString(`<html><head><title>hello world</title></head><body>`)
+	page.WriteString(`<div class="nav"><a href="/">Home</a> | <a href="/news">News</a></div>`)
+	page.WriteString(`<font face="Verdana" size="2">`)
+
+	for i := 1; i <= 12; i++ {
+		fmt.Fprintf(&page, `<div>Paragraph %d of the story, lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor.`, i)
+	}
+
+	page.WriteString(`</body></html>`)
+
+	parser := New()
+	parser.RepairMarkup = true
+	parser.CheckInvariants = true
+
+	res, err := parser.Analyze(strings.NewReader(page.String()), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if count := strings.Count(res.Text(), "Paragraph"); count != 12 {
+		t.Fatalf("expecting twelve paragraphs; got %d in %q", count, res.Text())
+	}
+
+	if strings.Contains(res.Text(), "Home") {
+		t.Fatalf("the navigation should not be part of the content: %q", res.Text())
+	}
+}
@@ -0,0 +1,255 @@
+package readability
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// ParseState is what Reparse needs to know about the previous parse of a page
+// to extract the content again after the page changed.
+type ParseState struct {
+	// Article is the article extracted by the last parse.
+	Article Article
+
+	pageURL string
+
+	// path is the position of the content container in the document, as the
+	// index of each ancestor among the children of its parent. It is empty
+	// if the content was not found inside a single element.
+	path []int
+
+	// contentHash is the fingerprint of the content container.
+	contentHash uint64
+
+	// pageHash is the fingerprint of the rest of the document.
+	pageHash uint64
+}
+
+// ParseDocument finds the main readable content of a document that was already
+// parsed, same as ParseNode, and returns the state needed to extract it again
+// with Reparse once the document changes. The given node is not modified.
+func (r *Readability) ParseDocument(doc *html.Node, pageURL string) (Article, *ParseState, error) {
+	result, elements, err := r.analyzeTraced(doc, pageURL)
+
+	if err != nil {
+		return Article{}, nil, err
+	}
+
+	state := &ParseState{Article: result.article(), pageURL: pageURL}
+
+	if content := r.tracedContent(elements); content != nil {
+		state.path = nodePath(doc, content)
+		state.contentHash = hashNode(content, nil)
+		state.pageHash = hashNode(doc, content)
+	}
+
+	return state.Article, state, nil
+}
+
+// Reparse extracts the content of a document that was patched since the parse
+// that produced state. This is meant for pages that are checked repeatedly,
+// like live blogs, where most updates only touch the content of the article:
+//
+//   - If neither the content container nor the rest of the document changed,
+//     the previous article is returned as is.
+//   - If only the content container changed, only the container is parsed
+//     again, and the metadata of the document, like the title, the site name
+//     and the links of the head, is kept from the previous article.
+//   - Otherwise, the whole document is parsed again.
+//
+// The given node is not modified.
+func (r *Readability) Reparse(state *ParseState, doc *html.Node) (Article, *ParseState, error) {
+	if doc == nil {
+		return Article{}, nil, ErrNilNode
+	}
+
+	if state == nil || len(state.path) == 0 {
+		return r.ParseDocument(doc, state.url())
+	}
+
+	content := nodeAtPath(doc, state.path)
+
+	if content == nil || hashNode(doc, content) != state.pageHash {
+		return r.ParseDocument(doc, state.pageURL)
+	}
+
+	contentHash := hashNode(content, nil)
+
+	if contentHash == state.contentHash {
+		return state.Article, state, nil
+	}
+
+	regionalDoc := documentFromNode(content)
+	regionalPath := domPath(regionalContent(regionalDoc, content))
+	result, err := r.analyzeDocument(regionalDoc, state.pageURL, time.Now())
+
+	if err != nil {
+		return Article{}, nil, err
+	}
+
+	// Everything extracted from the content, like the text, the images and
+	// the fingerprints, comes from the regional parse, while the metadata of
+	// the document, found outside of the container, is kept.
+	article := result.article()
+	article.SourcePositions = rebasePositions(article.SourcePositions, regionalPath, domPath(content))
+	previous := state.Article
+	article.Title = previous.Title
+	article.Byline = previous.Byline
+	article.Dir = previous.Dir
+	article.SiteName = previous.SiteName
+	article.Favicon = previous.Favicon
+	article.Image = previous.Image
+	article.PublishedTime = previous.PublishedTime
+	article.CommentCount = previous.CommentCount
+	article.InteractionCounts = previous.InteractionCounts
+	article.URL = previous.URL
+	article.RequestedURL = previous.RequestedURL
+	article.ThemeColor = previous.ThemeColor
+	article.TileColor = previous.TileColor
+	article.Logo = previous.Logo
+	article.RelLinks = previous.RelLinks
+	article.Translations = previous.Translations
+	article.OEmbedURLs = previous.OEmbedURLs
+	article.OEmbed = previous.OEmbed
+	article.RawContent = previous.RawContent
+	article.NoIndex = previous.NoIndex
+	article.NoArchive = previous.NoArchive
+
+	if previous.Excerpt != "" {
+		article.Excerpt = previous.Excerpt
+	}
+
+	next := *state
+	next.Article = article
+	next.contentHash = contentHash
+
+	return article, &next, nil
+}
+
+// url returns the address of the page of the state, or an empty string.
+func (state *ParseState) url() string {
+	if state == nil {
+		return ""
+	}
+
+	return state.pageURL
+}
+
+// regionalContent returns the copy of node in doc, the document created for it
+// by documentFromNode.
+func regionalContent(doc *html.Node, node *html.Node) *html.Node {
+	if node.Type == html.DocumentNode {
+		return doc
+	}
+
+	root := doc.FirstChild
+
+	if tagName(node) == "html" {
+		return root
+	}
+
+	if tagName(node) == "body" {
+		return root.LastChild
+	}
+
+	return root.LastChild.FirstChild
+}
+
+// rebasePositions replaces the path of the container of a regional parse, at
+// the start of the paths of the positions, with its path in the document.
+func rebasePositions(positions []SourcePosition, from string, to string) []SourcePosition {
+	if from == to {
+		return positions
+	}
+
+	for i, position := range positions {
+		if position.Path == from || strings.HasPrefix(position.Path, from+">") {
+			positions[i].Path = to + position.Path[len(from):]
+		}
+	}
+
+	return positions
+}
+
+// nodePath returns the index of node, and of each of its ancestors up to root,
+// among the children of their parent, starting from the top.
+func nodePath(root *html.Node, node *html.Node) []int {
+	var path []int
+
+	for ; node != root && node.Parent != nil; node = node.Parent {
+		index := 0
+
+		for sibling := node.PrevSibling; sibling != nil; sibling = sibling.PrevSibling {
+			index++
+		}
+
+		path = append([]int{index}, path...)
+	}
+
+	return path
+}
+
+// nodeAtPath returns the node found following path from root, see nodePath, or
+// nil if the document does not have such node.
+func nodeAtPath(root *html.Node, path []int) *html.Node {
+	node := root
+
+	for _, index := range path {
+		child := node.FirstChild
+
+		for ; child != nil && index > 0; index-- {
+			child = child.NextSibling
+		}
+
+		if child == nil {
+			return nil
+		}
+
+		node = child
+	}
+
+	return node
+}
+
+// hashNode returns a fingerprint of node and its descendants, leaving out the
+// skip node, which is not part of the fingerprint.
+func hashNode(node *html.Node, skip *html.Node) uint64 {
+	h := fnv.New64a()
+	writeNodeHash(h, node, skip)
+	return h.Sum64()
+}
+
+// writeNodeHash writes the type, data and attributes of node and its
+// descendants into the hash.
+func writeNodeHash(h hash.Hash64, node *html.Node, skip *html.Node) {
+	var buf [4]byte
+
+	if node == skip {
+		h.Write([]byte{0})
+		return
+	}
+
+	binary.BigEndian.PutUint32(buf[:], uint32(node.Type))
+	h.Write(buf[:])
+	h.Write([]byte(node.Data))
+
+	for _, attr := range node.Attr {
+		h.Write([]byte{1})
+		h.Write([]byte(attr.Key))
+		h.Write([]byte{2})
+		h.Write([]byte(attr.Val))
+	}
+
+	h.Write([]byte{3})
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeNodeHash(h, child, skip)
+	}
+
+	h.Write([]byte{4})
+}
@@ -0,0 +1,135 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestReparse(t *testing.T) {
+	page := `<html>
+		<head>
+			<title>%s</title>
+		</head>
+		<body>
+			<nav><a href="/">Home</a></nav>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				%s
+			</article>
+		</body>
+		</html>`
+
+	parse := func(title string, update string) *html.Node {
+		doc, err := html.Parse(strings.NewReader(strings.Replace(strings.Replace(page, "%s", title, 1), "%s", update, 1)))
+
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
+		}
+
+		return doc
+	}
+
+	parser := New()
+	article, state, err := parser.ParseDocument(parse("Live", ""), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(state.path) == 0 {
+		t.Fatalf("the content container was not found")
+	}
+
+	unchanged, next, err := parser.Reparse(state, parse("Live", ""))
+
+	if err != nil || next != state || unchanged.Content != article.Content {
+		t.Fatalf("unexpected reparse of an unchanged document: %v", err)
+	}
+
+	update := "<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>"
+	patched, next, err := parser.Reparse(state, parse("Live", update))
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(patched.TextContent, "Ut enim ad minim veniam") || patched.Title != "Live" {
+		t.Fatalf("the update was not extracted: %#v", patched)
+	}
+
+	retitled, _, err := parser.Reparse(next, parse("Finished", update))
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if retitled.Title != "Finished" || retitled.TextContent != patched.TextContent {
+		t.Fatalf("the document was not parsed again: %#v", retitled)
+	}
+}
+
+func TestReparseContentFields(t *testing.T) {
+	page := `<html>
+		<head>
+			<title>Live</title>
+			<meta property="og:site_name" content="Cixtor">
+		</head>
+		<body>
+			<div class="menu"><a href="/">Home</a></div>
+			<div>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<p>Duis aute irure dolor in reprehenderit, in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+				%s
+			</div>
+		</body>
+		</html>`
+
+	parse := func(update string) *html.Node {
+		doc, err := html.Parse(strings.NewReader(strings.Replace(page, "%s", update, 1)))
+
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
+		}
+
+		return doc
+	}
+
+	parser := New()
+	parser.RecordSourcePositions = true
+	article, state, err := parser.ParseDocument(parse(""), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(state.path) == 0 {
+		t.Fatalf("the content container was not found")
+	}
+
+	update := `<p>Ut enim ad minim veniam, <time datetime="2020-01-02T10:00:00Z">at ten</time>, quis nostrud exercitation ullamco laboris.</p>`
+	patched, _, err := parser.Reparse(state, parse(update))
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if patched.Title != "Live" || patched.SiteName != "Cixtor" {
+		t.Fatalf("the metadata of the document was not kept: %#v", patched)
+	}
+
+	if patched.Hash == article.Hash || patched.SimHash == article.SimHash || len(patched.Times) != 1 {
+		t.Fatalf("the fields of the content were not updated: %#v", patched)
+	}
+
+	if len(patched.SourcePositions) == 0 {
+		t.Fatalf("the source positions were not recorded")
+	}
+
+	for _, position := range patched.SourcePositions {
+		if !strings.HasPrefix(position.Path, "html>body>div:nth-of-type(2)") {
+			t.Fatalf("the source position is not in the document: %s", position.Path)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package readability
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Result is the outcome of Analyze. It wraps the extracted Article together
+// with diagnostics about the extraction process.
+//
+// To reduce allocations, the Content and TextContent fields of the embedded
+// Article are left empty. Use the HTML, Text and Markdown methods instead, the
+// renderings are computed on the first call and cached afterwards.
+type Result struct {
+	Article
+
+	// Attempts lists every pass of the content grabber in the order they
+	// ran. Each pass relaxes one of the heuristics used by the previous one.
+	Attempts []Attempt
+
+	// Confidence is a value between 0 and 1 estimating how likely it is that
+	// the extracted content is the main content of the document.
+	Confidence float64
+
+	// Timings is the time spent in each stage of the extraction.
+	Timings Timings
+
+	// Engine is the engine that found the content.
+	Engine Engine
+
+	// Agreement is the similarity, between 0 and 1, of the content found by
+	// both engines. It is only computed if CrossCheck is enabled.
+	Agreement float64
+
+	// Candidates holds the text found by each engine when CrossCheck is
+	// enabled and the engines disagree about the content.
+	Candidates []Candidate
+
+	// Features are the feature vectors of the elements of the document, in
+	// document order, collected only if ExportFeatures is enabled.
+	Features []NodeFeatures
+
+	// Removals are the elements removed from the document while the content
+	// was extracted, collected only if ReportRemovals is enabled. Only the
+	// removals of the selected attempt are included.
+	Removals []Removal
+
+	// TopCandidatePath is the path of the element chosen as the container of
+	// the content, which can be turned into a selector for the website. The
+	// path is found in the original document if RecordSourcePositions is
+	// enabled, otherwise in the document as prepared for scoring.
+	TopCandidatePath string
+
+	// AccessibilityIssues are the accessibility problems of the content of
+	// the article, found only if AuditAccessibility is enabled.
+	AccessibilityIssues []AccessibilityIssue
+
+	// omitStrings is true if the Content and TextContent fields of the
+	// Article are left empty, see OmitContentStrings.
+	omitStrings bool
+
+	html     *string
+	text     *string
+	markdown *string
+}
+
+// Attempt describes one pass of the content grabber.
+type Attempt struct {
+	// StripUnlikelys is true if nodes that look like comments, sidebars,
+	// footers and similar were removed before scoring.
+	StripUnlikelys bool
+
+	// UseWeightClasses is true if class names and IDs were used to weight
+	// the score of the candidates.
+	UseWeightClasses bool
+
+	// CleanConditionally is true if suspicious elements were removed from
+	// the content after the top candidate was selected.
+	CleanConditionally bool
+
+	// TextLength is the amount of characters found in this attempt.
+	TextLength int
+
+	// Selected is true if the content of this attempt was returned.
+	Selected bool
+
+	// TopCandidatePath is the path of the element chosen as the container of
+	// the content in this attempt, like "html>body>div#main>article".
+	TopCandidatePath string
+
+	// Learned is true if the top candidate was the element found at the path
+	// remembered in the SelectorStore, instead of the one chosen by scoring.
+	Learned bool
+}
+
+// Timings is the time spent in each stage of the extraction.
+type Timings struct {
+	Parse       time.Duration
+	Prepare     time.Duration
+	Metadata    time.Duration
+	Grab        time.Duration
+	PostProcess time.Duration
+	Total       time.Duration
+}
+
+// HTML returns the relevant content of the article with HTML tags.
+func (res *Result) HTML() string {
+	if res.html == nil {
+		content := ""
+
+		if res.content != nil {
+			content = innerHTML(res.content)
+		}
+
+		res.html = &content
+	}
+
+	return *res.html
+}
+
+// Text returns the relevant content of the article without HTML tags.
+func (res *Result) Text() string {
+	if res.text == nil {
+		text := ""
+
+		if res.content != nil {
+			text = strings.TrimSpace(textContent(res.content))
+		}
+
+		for _, transform := range res.transforms {
+			if transform != nil {
+				text = transform(text)
+			}
+		}
+
+		res.text = &text
+	}
+
+	return *res.text
+}
+
+// Markdown returns the relevant content of the article formatted as Markdown.
+func (res *Result) Markdown() string {
+	if res.markdown == nil {
+		markdown := ""
+
+		if res.content != nil {
+			markdown = renderMarkdown(res.content)
+		}
+
+		res.markdown = &markdown
+	}
+
+	return *res.markdown
+}
+
+// article returns a copy of the Article with the content fields populated,
+// unless OmitContentStrings was enabled.
+func (res *Result) article() Article {
+	article := res.Article
+
+	if !res.omitStrings {
+		article.Content = res.HTML()
+		article.TextContent = res.Text()
+	}
+
+	return article
+}
+
+// attemptReports returns the public description of the grabber attempts.
+func (r *Readability) attemptReports() []Attempt {
+	reports := make([]Attempt, len(r.attempts))
+
+	for i, attempt := range r.attempts {
+		reports[i] = Attempt{
+			StripUnlikelys:     attempt.flags.stripUnlikelys,
+			UseWeightClasses:   attempt.flags.useWeightClasses,
+			CleanConditionally: attempt.flags.cleanConditionally,
+			TextLength:         attempt.textLength,
+			Selected:           i == r.selectedAttempt,
+			TopCandidatePath:   attempt.topCandidatePath,
+			Learned:            attempt.learned,
+		}
+	}
+
+	return reports
+}
+
+// confidence estimates how likely it is that the extracted content is the
+// main content of the document. Content found in the first attempt, with
+// plenty of text and few links scores close to 1. Every attempt that had to
+// relax the heuristics lowers the score.
+func (r *Readability) confidence(articleContent *html.Node, textLength int) float64 {
+	if articleContent == nil || textLength == 0 {
+		return 0
+	}
+
+	threshold := float64(r.CharThresholds)
+
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	score := math.Min(1, float64(textLength)/(2*threshold))
+	// Each relaxed attempt of the content grabber lowers the confidence.
+	if r.Engine == EngineReadability && r.selectedAttempt > 0 {
+		score *= math.Pow(0.8, float64(r.selectedAttempt))
+	}
+
+	score *= 1 - r.getLinkDensity(articleContent)
+
+	return score
+}
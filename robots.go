@@ -0,0 +1,57 @@
+package readability
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ErrNoArchive is returned when RespectNoarchive is enabled and the document
+// asks, with the robots meta tag or the X-Robots-Tag header, not to be stored.
+var ErrNoArchive = errors.New("document does not allow archiving")
+
+// robotsMetaNames are the names of the meta tags with directives for crawlers.
+var robotsMetaNames = []string{"robots", "googlebot", "bingbot"}
+
+// robotsDirectives are the indexing rules declared by a document.
+type robotsDirectives struct {
+	noIndex   bool
+	noArchive bool
+}
+
+// parse adds the comma-separated directives of a robots meta tag or of the
+// X-Robots-Tag header, like "noindex, nofollow" or "googlebot: noarchive".
+func (d *robotsDirectives) parse(value string) {
+	for _, directive := range strings.Split(strings.ToLower(value), ",") {
+		// The header can restrict a directive to one crawler.
+		if colon := strings.Index(directive, ":"); colon != -1 {
+			directive = directive[colon+1:]
+		}
+
+		switch strings.TrimSpace(directive) {
+		case "noindex":
+			d.noIndex = true
+		case "noarchive", "nocache":
+			d.noArchive = true
+		case "none":
+			d.noIndex = true
+		}
+	}
+}
+
+// getRobotsDirectives returns the directives of the robots meta tags of the
+// document.
+func getRobotsDirectives(doc *html.Node) robotsDirectives {
+	var directives robotsDirectives
+
+	for _, meta := range getElementsByTagName(doc, "meta") {
+		name := strings.ToLower(strings.TrimSpace(getAttribute(meta, "name")))
+
+		if indexOf(robotsMetaNames, name) != -1 {
+			directives.parse(getAttribute(meta, "content"))
+		}
+	}
+
+	return directives
+}
@@ -0,0 +1,77 @@
+package readability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRobotsDirectives(t *testing.T) {
+	tests := []struct {
+		content   string
+		noIndex   bool
+		noArchive bool
+	}{
+		{"index, follow", false, false},
+		{"noindex, nofollow", true, false},
+		{"NOARCHIVE", false, true},
+		{"none", true, false},
+		{"googlebot: noindex, noarchive", true, true},
+	}
+
+	for _, tt := range tests {
+		var directives robotsDirectives
+		directives.parse(tt.content)
+
+		if directives.noIndex != tt.noIndex || directives.noArchive != tt.noArchive {
+			t.Fatalf("%q: unexpected directives: %#v", tt.content, directives)
+		}
+	}
+}
+
+func TestRespectNoarchive(t *testing.T) {
+	input := `<html><head><meta name="robots" content="noindex, noarchive"></head><body><p>lorem ipsum</p></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !a.NoIndex || !a.NoArchive {
+		t.Fatalf("robots directives were not detected: %#v", a)
+	}
+
+	parser := New()
+	parser.RespectNoarchive = true
+
+	if _, err := parser.Parse(strings.NewReader(input), "https://cixtor.com/blog"); err != ErrNoArchive {
+		t.Fatalf("expecting ErrNoArchive: %v", err)
+	}
+}
+
+func TestParseURLRobotsHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Robots-Tag", "noarchive")
+		w.Write([]byte(fetcherTestPage))
+	}))
+	defer server.Close()
+
+	a, err := New().ParseURL(server.URL)
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.NoIndex || !a.NoArchive {
+		t.Fatalf("unexpected robots directives: %v %v", a.NoIndex, a.NoArchive)
+	}
+
+	parser := New()
+	parser.RespectNoarchive = true
+
+	if _, err := parser.ParseURL(server.URL); err != ErrNoArchive {
+		t.Fatalf("expecting ErrNoArchive: %v", err)
+	}
+}
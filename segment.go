@@ -0,0 +1,62 @@
+package readability
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Segmenter splits a text into words. It is used to count the words of the
+// title candidates, which decides when the title of the document is cut at a
+// separator, like " | " or ": ", or used as it is.
+type Segmenter func(text string) []string
+
+// SegmentCJK is a Segmenter for texts written in Chinese, Japanese or Korean,
+// which do not separate words with spaces. Every Han, Hiragana and Katakana
+// character is a word, and the rest of the text is split at white space and at
+// the boundaries between scripts.
+func SegmentCJK(text string) []string {
+	var words []string
+
+	start := -1
+
+	for i, char := range text {
+		switch {
+		case unicode.IsSpace(char):
+			if start != -1 {
+				words = append(words, text[start:i])
+				start = -1
+			}
+		case isIdeographic(char):
+			if start != -1 {
+				words = append(words, text[start:i])
+				start = -1
+			}
+
+			words = append(words, string(char))
+		default:
+			if start == -1 {
+				start = i
+			}
+		}
+	}
+
+	if start != -1 {
+		words = append(words, text[start:])
+	}
+
+	return words
+}
+
+// isIdeographic determines if char is written without spaces between words.
+func isIdeographic(char rune) bool {
+	return unicode.In(char, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// wordCount returns the number of words in str, as split by the Segmenter.
+func (r *Readability) wordCount(str string) int {
+	if r.Segmenter == nil {
+		return len(strings.Fields(str))
+	}
+
+	return len(r.Segmenter(str))
+}
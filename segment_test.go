@@ -0,0 +1,52 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSegmentCJK(t *testing.T) {
+	tests := []struct {
+		text  string
+		words []string
+	}{
+		{"hello world", []string{"hello", "world"}},
+		{"北京天气", []string{"北", "京", "天", "气"}},
+		{"Go语言 入门", []string{"Go", "语", "言", "入", "门"}},
+		{"서울 날씨", []string{"서울", "날씨"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if words := SegmentCJK(tt.text); !reflect.DeepEqual(words, tt.words) {
+			t.Fatalf("%q: unexpected words: %q", tt.text, words)
+		}
+	}
+}
+
+func TestTitleSegmenter(t *testing.T) {
+	input := `<html><head><title>北京今日天气晴朗 | 新华网</title></head><body><p>北京今日天气晴朗。</p></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Title != "北京今日天气晴朗 | 新华网" {
+		t.Fatalf("unexpected title without segmenter: %q", a.Title)
+	}
+
+	parser := New()
+	parser.Segmenter = SegmentCJK
+	a, err = parser.Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.Title != "北京今日天气晴朗" {
+		t.Fatalf("unexpected title with segmenter: %q", a.Title)
+	}
+}
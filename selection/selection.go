@@ -0,0 +1,43 @@
+// Package selection converts between goquery selections and the input and
+// output of the readability parser, so scrapers built with goquery can hand
+// their selections to readability without serializing them back to HTML.
+package selection
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"github.com/cixtor/readability"
+	"golang.org/x/net/html"
+)
+
+// Parse finds the main readable content of the nodes in the selection. The
+// selection is not modified. If the selection holds a document, the document
+// is parsed as a whole, otherwise all the selected nodes are placed, in order,
+// inside the <body> of an otherwise empty document.
+func Parse(r *readability.Readability, sel *goquery.Selection, pageURL string) (readability.Article, error) {
+	if sel.Length() == 1 {
+		return r.ParseNode(sel.Get(0), pageURL)
+	}
+
+	body := &html.Node{Type: html.ElementNode, Data: "body"}
+
+	for _, node := range sel.Clone().Nodes {
+		body.AppendChild(node)
+	}
+
+	return r.ParseNode(body, pageURL)
+}
+
+// ParseDocument finds the main readable content of a goquery document.
+func ParseDocument(r *readability.Readability, doc *goquery.Document, pageURL string) (readability.Article, error) {
+	return Parse(r, doc.Selection, pageURL)
+}
+
+// FromArticle returns a goquery document rooted at the readable content of the
+// article, so the result can be queried and modified with goquery.
+func FromArticle(article readability.Article) *goquery.Document {
+	if article.Node == nil {
+		return goquery.NewDocumentFromNode(&html.Node{Type: html.DocumentNode})
+	}
+
+	return goquery.NewDocumentFromNode(article.Node)
+}
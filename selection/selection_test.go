@@ -0,0 +1,43 @@
+package selection
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/cixtor/readability"
+)
+
+func TestParseSelection(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<div id="nav"><a href="/">home</a></div>
+			<div id="main"><p>lorem ipsum</p><p>dolor sit amet</p></div>
+		</body>
+		</html>`))
+
+	if err != nil {
+		t.Fatalf("goquery failure: %s", err)
+	}
+
+	a, err := Parse(readability.New(), doc.Find("#main p"), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.TextContent != "lorem ipsumdolor sit amet" {
+		t.Fatalf("unexpected content: %q", a.TextContent)
+	}
+
+	if doc.Find("#main p").Length() != 2 {
+		t.Fatalf("the selection was modified")
+	}
+
+	if text := FromArticle(a).Find("p").First().Text(); text != "lorem ipsum" {
+		t.Fatalf("unexpected goquery result: %q", text)
+	}
+}
@@ -0,0 +1,201 @@
+package readability
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// SelectorStore remembers, for each website, the path of the element that
+// contains the articles, like "html>body>div#main>article", as found by the
+// heuristics. Implementations must be safe for concurrent use if the store is
+// shared by several parsers. See NewMemorySelectorStore.
+type SelectorStore interface {
+	// Load returns the path remembered for the host, if any.
+	Load(host string) (string, bool)
+
+	// Store remembers the path for the host, replacing the previous one.
+	Store(host string, path string)
+
+	// Delete forgets the path of the host, after it failed to find an
+	// article in one of the pages of the website.
+	Delete(host string)
+}
+
+// MemorySelectorStore is a SelectorStore that keeps the paths in memory.
+type MemorySelectorStore struct {
+	mu    sync.RWMutex
+	paths map[string]string
+}
+
+// NewMemorySelectorStore returns an empty SelectorStore kept in memory, which
+// is safe for concurrent use.
+func NewMemorySelectorStore() *MemorySelectorStore {
+	return &MemorySelectorStore{paths: map[string]string{}}
+}
+
+// Load implements the SelectorStore interface.
+func (s *MemorySelectorStore) Load(host string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	path, ok := s.paths[host]
+
+	return path, ok
+}
+
+// Store implements the SelectorStore interface.
+func (s *MemorySelectorStore) Store(host string, path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.paths[host] = path
+}
+
+// Delete implements the SelectorStore interface.
+func (s *MemorySelectorStore) Delete(host string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.paths, host)
+}
+
+// selectorHost returns the key of the website of the document in the store.
+func (r *Readability) selectorHost() string {
+	if r.documentURI == nil {
+		return ""
+	}
+
+	return strings.ToLower(r.documentURI.Hostname())
+}
+
+// learnedArticle returns the content of the element found at the path stored
+// for the website, cleaned like the content found by the heuristics, or nil if
+// there is no path, if the element is not in the document or if its content
+// is not accepted, see attemptSucceeded. A path that fails is deleted from the
+// store, so the next page of the website learns a new one.
+func (r *Readability) learnedArticle() *html.Node {
+	host := r.selectorHost()
+
+	if r.SelectorStore == nil || host == "" {
+		return nil
+	}
+
+	path, ok := r.SelectorStore.Load(host)
+
+	if !ok || path == "" {
+		return nil
+	}
+
+	node := r.findSourceNode(path)
+
+	if node == nil {
+		r.SelectorStore.Delete(host)
+		return nil
+	}
+
+	r.grabbing = true
+	defer func() { r.grabbing = false }()
+
+	page := createElement("div")
+	setAttribute(page, "id", "readability-page-1")
+	setAttribute(page, "class", "page")
+	page.AppendChild(cloneNode(node))
+
+	articleContent := createElement("div")
+	articleContent.AppendChild(page)
+
+	r.prepArticle(articleContent)
+
+	textLength := len(r.getInnerText(articleContent, true))
+	succeeded := r.attemptSucceeded(articleContent, 0, textLength)
+
+	r.attempts = append(r.attempts, parseAttempt{
+		articleContent:   articleContent,
+		textLength:       textLength,
+		flags:            r.flags,
+		topCandidate:     -1,
+		topCandidatePath: path,
+		learned:          true,
+		succeeded:        succeeded,
+	})
+
+	if !succeeded {
+		r.SelectorStore.Delete(host)
+		return nil
+	}
+
+	r.selectedAttempt = len(r.attempts) - 1
+
+	return articleContent
+}
+
+// learnSelector stores the path of the top candidate of the selected attempt
+// for the website, if the content of the attempt was accepted, rather than
+// being the longest content found after all the attempts failed.
+func (r *Readability) learnSelector() {
+	host := r.selectorHost()
+
+	if r.SelectorStore == nil || host == "" || r.selectedAttempt == -1 {
+		return
+	}
+
+	attempt := r.attempts[r.selectedAttempt]
+
+	if attempt.learned || !attempt.succeeded || attempt.topCandidatePath == "" {
+		return
+	}
+
+	r.SelectorStore.Store(host, attempt.topCandidatePath)
+}
+
+// findSourceNode returns the element of the document that was found at the
+// path in the original document, or nil if there is no such element or if it
+// was removed while the document was prepared. See markSourcePositions.
+func (r *Readability) findSourceNode(path string) *html.Node {
+	segments := strings.Split(path, ">")
+	depths := make([]int, len(r.sourceNodes))
+	target := -1
+
+	// The parents are listed before their children, so the depth of the
+	// parent is known, and -1 if the parent is not in the path.
+	for i, node := range r.sourceNodes {
+		depth := 0
+
+		if node.parent != -1 {
+			depth = depths[node.parent] + 1
+
+			if depths[node.parent] == -1 {
+				depth = -1
+			}
+		}
+
+		if depth == -1 || depth >= len(segments) || node.segment != segments[depth] {
+			depths[i] = -1
+			continue
+		}
+
+		depths[i] = depth
+
+		if depth == len(segments)-1 {
+			target = i
+			break
+		}
+	}
+
+	if target == -1 {
+		return nil
+	}
+
+	mark := strconv.Itoa(target)
+
+	for _, node := range getElementsByTagName(r.doc, "*") {
+		if getAttribute(node, sourceAttr) == mark {
+			return node
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func selectorsTestPage(id string, text string) string {
+	paragraph := "<p>" + text + ", lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>"
+
+	return `<html>
+		<head><title>hello world</title></head>
+		<body>
+			<div id="nav"><a href="/">home</a> <a href="/about">about</a></div>
+			<div id="` + id + `"><article>` + strings.Repeat(paragraph, 5) + `</article></div>
+		</body>
+		</html>`
+}
+
+func TestSelectorStore(t *testing.T) {
+	store := NewMemorySelectorStore()
+	parser := New()
+	parser.SelectorStore = store
+
+	analyze := func(page string, pageURL string) *Result {
+		result, err := parser.Analyze(strings.NewReader(page), pageURL)
+
+		if err != nil {
+			t.Fatalf("parser failure: %s", err)
+		}
+
+		return result
+	}
+
+	first := analyze(selectorsTestPage("main", "First"), "https://cixtor.com/blog/first")
+
+	if path, ok := store.Load("cixtor.com"); !ok || path != "html>body>div#main" || first.Attempts[0].Learned {
+		t.Fatalf("unexpected learned path: %q, %#v", path, first.Attempts)
+	}
+
+	second := analyze(selectorsTestPage("main", "Second"), "https://CIXTOR.com/blog/second")
+
+	if len(second.Attempts) != 1 || !second.Attempts[0].Learned || !strings.Contains(second.Text(), "Second, lorem") {
+		t.Fatalf("the learned path was not used: %#v\n%s", second.Attempts, second.Text())
+	}
+
+	if strings.Contains(second.HTML(), sourceAttr) {
+		t.Fatalf("the marks were not removed: %s", second.HTML())
+	}
+
+	// A page with a different layout falls back to the heuristics, and the
+	// path of the new layout is learned.
+	third := analyze(selectorsTestPage("content", "Third"), "https://cixtor.com/blog/third")
+
+	if third.Attempts[0].Learned || !strings.Contains(third.Text(), "Third, lorem") {
+		t.Fatalf("unexpected attempts: %#v\n%s", third.Attempts, third.Text())
+	}
+
+	if path, _ := store.Load("cixtor.com"); path != "html>body>div#content" {
+		t.Fatalf("unexpected learned path: %q", path)
+	}
+
+	// Other websites are not affected.
+	if other := analyze(selectorsTestPage("content", "Other"), "https://example.com/post"); other.Attempts[0].Learned {
+		t.Fatalf("the path of another website was used: %#v", other.Attempts)
+	}
+}
@@ -0,0 +1,87 @@
+package readability
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ErrClientSideRendered is returned when the document is the empty shell of
+// an application rendered in the browser. The content of such documents is
+// only available after running their scripts, so callers should retry with a
+// headless browser instead of storing an empty article.
+var ErrClientSideRendered = errors.New("document is rendered client-side")
+
+// appShellMountIDs is a list of IDs commonly used by client-side frameworks
+// for the element where the application is mounted.
+var appShellMountIDs = []string{
+	"___gatsby",
+	"__next",
+	"__nuxt",
+	"app",
+	"app-root",
+	"react-root",
+	"root",
+	"svelte",
+}
+
+// appShellMaxTextLength is the maximum number of characters that a document
+// can have to be considered the empty shell of an application.
+const appShellMaxTextLength = 200
+
+// isAppShell determines if the document is the empty shell of an application
+// rendered in the browser: it has scripts, an empty mount point, and barely
+// any text outside of the scripts.
+func (r *Readability) isAppShell(doc *html.Node) bool {
+	if len(getElementsByTagName(doc, "script")) == 0 {
+		return false
+	}
+
+	var body *html.Node
+
+	if nodes := getElementsByTagName(doc, "body"); len(nodes) > 0 {
+		body = nodes[0]
+	}
+
+	if body == nil || len(strings.TrimSpace(visibleText(body))) > appShellMaxTextLength {
+		return false
+	}
+
+	return r.someNode(getElementsByTagName(body, "*"), func(node *html.Node) bool {
+		isMountPoint := indexOf(appShellMountIDs, id(node)) != -1 ||
+			hasAttribute(node, "data-reactroot") ||
+			hasAttribute(node, "ng-version") ||
+			hasAttribute(node, "ng-app") ||
+			hasAttribute(node, "data-server-rendered")
+
+		return isMountPoint && strings.TrimSpace(visibleText(node)) == ""
+	})
+}
+
+// visibleText returns the text content of node, ignoring the text inside of
+// elements that are never rendered, like scripts and styles, and inside of
+// <noscript> elements, which usually ask the user to enable JavaScript.
+func visibleText(node *html.Node) string {
+	var buffer strings.Builder
+	var finder func(*html.Node)
+
+	finder = func(n *html.Node) {
+		switch tagName(n) {
+		case "script", "style", "noscript", "template":
+			return
+		}
+
+		if n.Type == html.TextNode {
+			buffer.WriteString(n.Data)
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			finder(c)
+		}
+	}
+
+	finder(node)
+
+	return buffer.String()
+}
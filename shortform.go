@@ -0,0 +1,29 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// shortFormCharThreshold is the maximum number of chars an article must have
+// in order to return a result when ShortForm is enabled.
+const shortFormCharThreshold = 50
+
+// preserveLineBreaks adds a new line after every <br> element inside of the
+// paragraphs of the content, unless there is one already, so the lines of
+// poems and short posts are kept in the text of the article, which otherwise
+// joins the last word of a line with the first word of the next one.
+func (r *Readability) preserveLineBreaks(articleContent *html.Node) {
+	for _, br := range getElementsByTagName(articleContent, "br") {
+		if br.Parent == nil || !r.hasAncestorTag(br, "p", -1, nil) {
+			continue
+		}
+
+		if next := br.NextSibling; next != nil && next.Type == html.TextNode && strings.HasPrefix(next.Data, "\n") {
+			continue
+		}
+
+		br.Parent.InsertBefore(&html.Node{Type: html.TextNode, Data: "\n"}, br.NextSibling)
+	}
+}
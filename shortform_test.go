@@ -0,0 +1,60 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+const shortFormTestPage = `<html>
+	<head><title>Dust of Snow</title></head>
+	<body>
+		<nav><a href="/">Home</a> <a href="/poems">Poems</a></nav>
+		<div class="poem">
+			<p>The way a crow<br>Shook down on me<br>The dust of snow<br>From a hemlock tree</p>
+			<p>Has given my heart<br>A change of mood<br>And saved some part<br>Of a day I had rued.</p>
+		</div>
+	</body>
+</html>`
+
+func TestShortForm(t *testing.T) {
+	parser := New()
+	parser.ShortForm = true
+
+	res, err := parser.Analyze(strings.NewReader(shortFormTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(res.Attempts) != 1 || res.Attempts[0].CleanConditionally {
+		t.Fatalf("expecting one attempt without conditional cleaning: %#v", res.Attempts)
+	}
+
+	if !strings.Contains(res.Text(), "The way a crow\nShook down on me\nThe dust of snow\nFrom a hemlock tree") {
+		t.Fatalf("the line breaks were not preserved: %q", res.Text())
+	}
+
+	if strings.Count(res.HTML(), "<br/>") != 6 {
+		t.Fatalf("expecting six line breaks:\n%s", res.HTML())
+	}
+}
+
+func TestShortFormThreshold(t *testing.T) {
+	parser := New()
+
+	if threshold := parser.charThreshold(0); threshold != 500 {
+		t.Fatalf("expecting the default threshold; got %d", threshold)
+	}
+
+	parser.ShortForm = true
+
+	if threshold := parser.charThreshold(0); threshold != shortFormCharThreshold {
+		t.Fatalf("expecting the short-form threshold; got %d", threshold)
+	}
+
+	parser.AttemptThresholds = []int{20}
+
+	if threshold := parser.charThreshold(0); threshold != 20 {
+		t.Fatalf("expecting the threshold of the attempt; got %d", threshold)
+	}
+}
@@ -0,0 +1,65 @@
+package readability
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// titleSimilarityThreshold is the minimum similarity between a heading and the
+// title of the article for the heading to be considered a copy of the title.
+const titleSimilarityThreshold = 0.75
+
+// similarityTokens splits the text into lowercase words, ignoring punctuation,
+// so "Hello, World!" and "hello world" have the same tokens.
+func similarityTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsNumber(char)
+	})
+}
+
+// textSimilarity compares textB against textA, and returns a value between 0
+// and 1 measuring how much of textB is made of words found in textA, weighted
+// by the length of the words. It returns 0 if either text has no words.
+//
+// The comparison is the same as the one made by Readability.js, which is not
+// symmetric: a short heading made of a few words of the title is similar to
+// the title. Use isNearDuplicate to compare both ways.
+func textSimilarity(textA string, textB string) float64 {
+	tokensA := similarityTokens(textA)
+	tokensB := similarityTokens(textB)
+
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	uniqueTokensB := make([]string, 0, len(tokensB))
+
+	for _, token := range tokensB {
+		if indexOf(tokensA, token) == -1 {
+			uniqueTokensB = append(uniqueTokensB, token)
+		}
+	}
+
+	distanceB := float64(len(strings.Join(uniqueTokensB, "\x20"))) / float64(len(strings.Join(tokensB, "\x20")))
+
+	return 1 - distanceB
+}
+
+// isNearDuplicate determines if both texts have mostly the same words, despite
+// differences in punctuation, case or a few extra words.
+func isNearDuplicate(textA string, textB string) bool {
+	return textSimilarity(textA, textB) > titleSimilarityThreshold &&
+		textSimilarity(textB, textA) > titleSimilarityThreshold
+}
+
+// headerDuplicatesTitle determines if the heading repeats the title of the
+// article, which is extracted separately.
+func (r *Readability) headerDuplicatesTitle(heading *html.Node) bool {
+	if r.articleTitle == "" {
+		return false
+	}
+
+	return isNearDuplicate(r.articleTitle, r.getInnerText(heading, false))
+}
@@ -0,0 +1,50 @@
+package readability
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestTextSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		similarity float64
+	}{
+		{"Hello, World!", "hello world", 1},
+		{"hello world", "goodbye moon", 0},
+		{"hello world", "hello moon", 1 - 4.0/10},
+		{"", "hello", 0},
+	}
+
+	for _, tt := range tests {
+		if similarity := textSimilarity(tt.a, tt.b); math.Abs(similarity-tt.similarity) > 1e-9 {
+			t.Fatalf("%q vs %q: unexpected similarity: %f", tt.a, tt.b, similarity)
+		}
+	}
+
+	if !isNearDuplicate("Go 1.14 — what's new?", "Go 1.14: What’s New") {
+		t.Fatalf("expected near duplicates")
+	}
+
+	if isNearDuplicate("Introduction to the Go language", "Introduction") {
+		t.Fatalf("a single word of the title is not a duplicate")
+	}
+}
+
+func TestHeaderDuplicatesTitle(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+	input := `<html><head><title>Parsing HTML in Go</title></head><body><article>
+		<h2>Parsing HTML, in Go!</h2>` + paragraph + paragraph + paragraph + `
+	</article></body></html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Contains(a.Content, "<h2>") {
+		t.Fatalf("the heading repeating the title was not removed: %s", a.Content)
+	}
+}
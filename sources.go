@@ -0,0 +1,196 @@
+package readability
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// sourceAttr marks the elements of the document with their index in the list
+// of source nodes, so the elements of the content can be traced back to their
+// original position after the document was cloned and rearranged.
+const sourceAttr = "data-readability-source"
+
+// SourcePosition is the position of a block of the content in the original
+// document, used to map the text of the article back onto the page.
+type SourcePosition struct {
+	// Path is the path of the element in the original document, like
+	// "html>body>div#main>p:nth-of-type(3)", see RecordSourcePositions.
+	Path string
+
+	// Offset is the approximate byte offset of the start tag of the element
+	// in the input, or -1 if it is unknown, for example for ParseNode, which
+	// does not read the serialized document.
+	Offset int
+}
+
+// sourceNode is an element of the original document.
+type sourceNode struct {
+	parent  int
+	segment string
+	offset  int
+}
+
+// markSourcePositions marks every element of the document with its index in
+// the list of source nodes, and records its path and the offset of its start
+// tag in the source, if the serialized document is available.
+//
+// The offsets are found by matching the n-th element with a tag name with the
+// n-th start tag with the same name in the source. The elements added by the
+// HTML parser, like a missing <tbody>, have no start tag, so the offsets are
+// an approximation.
+func (r *Readability) markSourcePositions(doc *html.Node) {
+	offsets := startTagOffsets(r.source)
+	counters := map[string]int{}
+
+	r.source = nil
+	r.sourceNodes = nil
+
+	var walk func(parent *html.Node, parentIndex int)
+
+	walk = func(parent *html.Node, parentIndex int) {
+		totals := map[string]int{}
+
+		for child := firstElementChild(parent); child != nil; child = nextElementSibling(child) {
+			totals[child.Data]++
+		}
+
+		indexes := map[string]int{}
+
+		for child := firstElementChild(parent); child != nil; child = nextElementSibling(child) {
+			offset := -1
+
+			if tagOffsets := offsets[child.Data]; counters[child.Data] < len(tagOffsets) {
+				offset = tagOffsets[counters[child.Data]]
+			}
+
+			counters[child.Data]++
+
+			index := len(r.sourceNodes)
+			r.sourceNodes = append(r.sourceNodes, sourceNode{
+				parent:  parentIndex,
+				segment: pathSegment(child, indexes[child.Data], totals[child.Data]),
+				offset:  offset,
+			})
+			indexes[child.Data]++
+
+			setAttribute(child, sourceAttr, strconv.Itoa(index))
+			walk(child, index)
+		}
+	}
+
+	walk(doc, -1)
+}
+
+// startTagOffsets returns the byte offsets of the start tags of the source,
+// grouped by tag name, in the same order found in the source.
+func startTagOffsets(source []byte) map[string][]int {
+	offsets := map[string][]int{}
+
+	if len(source) == 0 {
+		return offsets
+	}
+
+	offset := 0
+	z := html.NewTokenizer(bytes.NewReader(source))
+
+	for {
+		tt := z.Next()
+
+		if tt == html.ErrorToken {
+			break
+		}
+
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			name, _ := z.TagName()
+			offsets[string(name)] = append(offsets[string(name)], offset)
+		}
+
+		offset += len(z.Raw())
+	}
+
+	return offsets
+}
+
+// sourcePosition returns the position of the source node with the index.
+func (r *Readability) sourcePosition(index int) SourcePosition {
+	var segments []string
+
+	for i := index; i >= 0; i = r.sourceNodes[i].parent {
+		segments = append(segments, r.sourceNodes[i].segment)
+	}
+
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+
+	return SourcePosition{
+		Path:   strings.Join(segments, ">"),
+		Offset: r.sourceNodes[index].offset,
+	}
+}
+
+// sourceIndex returns the index of the source node of the element, or of its
+// first descendant with one, for the elements created by the parser, like the
+// paragraphs that wrap loose text.
+func (r *Readability) sourceIndex(node *html.Node) int {
+	for _, elem := range getElementsByTagName(node, "*") {
+		if index, err := strconv.Atoi(getAttribute(elem, sourceAttr)); err == nil && index >= 0 && index < len(r.sourceNodes) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// extractSourcePositions returns the positions in the original document of
+// the top-level blocks of the content, which are the children of the element
+// that wraps the whole content, and removes the marks of the elements.
+func (r *Readability) extractSourcePositions(articleContent *html.Node) []SourcePosition {
+	container := articleContent
+
+	for {
+		child := firstElementChild(container)
+
+		if child == nil || nextElementSibling(child) != nil || hasTextChild(container) {
+			break
+		}
+
+		container = child
+	}
+
+	var positions []SourcePosition
+
+	for block := firstElementChild(container); block != nil; block = nextElementSibling(block) {
+		if index := r.sourceIndex(block); index != -1 {
+			positions = append(positions, r.sourcePosition(index))
+		} else {
+			positions = append(positions, SourcePosition{Offset: -1})
+		}
+	}
+
+	clearSourceMarks(articleContent)
+
+	return positions
+}
+
+// hasTextChild returns true if node has a child text node that is not only
+// white space.
+func hasTextChild(node *html.Node) bool {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.TextNode && strings.TrimSpace(child.Data) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clearSourceMarks removes the marks set by markSourcePositions.
+func clearSourceMarks(node *html.Node) {
+	for _, elem := range getElementsByTagName(node, "*") {
+		removeAttribute(elem, sourceAttr)
+	}
+}
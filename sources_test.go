@@ -0,0 +1,78 @@
+package readability
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const sourcesTestPage = `<html>
+<head><title>hello world</title></head>
+<body>
+<div id="nav"><a href="/">home</a></div>
+<div id="main">
+<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+</div>
+</body>
+</html>`
+
+func TestRecordSourcePositions(t *testing.T) {
+	parser := New()
+	parser.RecordSourcePositions = true
+	parser.KeepRawContent = true
+
+	a, err := parser.Parse(strings.NewReader(sourcesTestPage), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(a.SourcePositions) != 3 {
+		t.Fatalf("unexpected positions: %#v", a.SourcePositions)
+	}
+
+	for i, position := range a.SourcePositions {
+		path := "html>body>div#main>p:nth-of-type(" + strconv.Itoa(i+1) + ")"
+
+		if position.Path != path {
+			t.Fatalf("unexpected path #%d: %q, expecting %q", i, position.Path, path)
+		}
+
+		if !strings.HasPrefix(sourcesTestPage[position.Offset:], "<p>") {
+			t.Fatalf("unexpected offset #%d: %q", i, sourcesTestPage[position.Offset:])
+		}
+	}
+
+	if strings.Contains(a.Content, sourceAttr) || strings.Contains(a.RawContent, sourceAttr) {
+		t.Fatalf("the marks were not removed:\n%s\n%s", a.Content, a.RawContent)
+	}
+}
+
+func TestSourcePositionsWithoutSource(t *testing.T) {
+	parser := New()
+	parser.RecordSourcePositions = true
+
+	doc, err := html.Parse(strings.NewReader(sourcesTestPage))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	a, err := parser.ParseNode(doc, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(a.SourcePositions) != 3 || a.SourcePositions[0].Offset != -1 || a.SourcePositions[0].Path == "" {
+		t.Fatalf("unexpected positions: %#v", a.SourcePositions)
+	}
+
+	if strings.Contains(a.Content, sourceAttr) || strings.Contains(outerHTML(doc), sourceAttr) {
+		t.Fatalf("the marks were not removed: %s", a.Content)
+	}
+}
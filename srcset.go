@@ -0,0 +1,147 @@
+package readability
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ImageCandidate is one of the images listed in a srcset attribute.
+type ImageCandidate struct {
+	// URL is the address of the image, as written in the attribute.
+	URL string
+
+	// Width is the value of the width descriptor, like 640 for "640w", or
+	// zero if the candidate does not have one.
+	Width int
+
+	// Height is the value of the height descriptor, like 480 for "480h", or
+	// zero if the candidate does not have one.
+	Height int
+
+	// Density is the value of the pixel density descriptor, like 2 for "2x",
+	// or zero if the candidate does not have one.
+	Density float64
+
+	// Descriptors is the text that follows the URL, as written in the
+	// attribute. It is written back as is by FormatSrcset, so descriptors
+	// that are not understood by the parser are not lost.
+	Descriptors string
+}
+
+// ParseSrcset parses a srcset attribute into its image candidates. Commas are
+// allowed inside of the URLs, which is common in data URIs, and inside of
+// parentheses in the descriptors.
+//
+// See: https://html.spec.whatwg.org/multipage/images.html#parsing-a-srcset-attribute
+func ParseSrcset(srcset string) []ImageCandidate {
+	var candidates []ImageCandidate
+
+	pos := 0
+
+	for pos < len(srcset) {
+		// Skip the whitespace and commas before the URL.
+		for pos < len(srcset) && (isSpace(srcset[pos]) || srcset[pos] == ',') {
+			pos++
+		}
+
+		start := pos
+
+		// The URL extends until the next whitespace.
+		for pos < len(srcset) && !isSpace(srcset[pos]) {
+			pos++
+		}
+
+		if start == pos {
+			break
+		}
+
+		// A URL ending with commas has no descriptors.
+		if srcset[pos-1] == ',' {
+			candidates = append(candidates, ImageCandidate{URL: strings.TrimRight(srcset[start:pos], ",")})
+			continue
+		}
+
+		candidate := ImageCandidate{URL: srcset[start:pos]}
+
+		// The descriptors extend until the next comma outside parentheses.
+		depth := 0
+		start = pos
+
+		for pos < len(srcset) && (srcset[pos] != ',' || depth > 0) {
+			if srcset[pos] == '(' {
+				depth++
+			} else if srcset[pos] == ')' && depth > 0 {
+				depth--
+			}
+			pos++
+		}
+
+		candidate.Descriptors = strings.TrimSpace(srcset[start:pos])
+		candidate.parseDescriptors()
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates
+}
+
+// parseDescriptors sets the width, height and density of the candidate from
+// its descriptors. Invalid descriptors are ignored.
+func (c *ImageCandidate) parseDescriptors() {
+	for _, descriptor := range strings.Fields(c.Descriptors) {
+		if len(descriptor) < 2 {
+			continue
+		}
+
+		value := descriptor[:len(descriptor)-1]
+
+		switch descriptor[len(descriptor)-1] {
+		case 'w':
+			if width, err := strconv.Atoi(value); err == nil && width > 0 {
+				c.Width = width
+			}
+		case 'h':
+			if height, err := strconv.Atoi(value); err == nil && height > 0 {
+				c.Height = height
+			}
+		case 'x':
+			if density, err := strconv.ParseFloat(value, 64); err == nil && density > 0 {
+				c.Density = density
+			}
+		}
+	}
+}
+
+// FormatSrcset writes the image candidates back into a srcset attribute. The
+// descriptors of each candidate are written as they were parsed.
+func FormatSrcset(candidates []ImageCandidate) string {
+	parts := make([]string, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		if candidate.Descriptors == "" {
+			parts = append(parts, candidate.URL)
+			continue
+		}
+
+		parts = append(parts, candidate.URL+" "+candidate.Descriptors)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// absoluteSrcset resolves the URLs of the image candidates against base,
+// keeping their descriptors.
+func absoluteSrcset(srcset string, base *url.URL) string {
+	candidates := ParseSrcset(srcset)
+
+	for i := range candidates {
+		candidates[i].URL = toAbsoluteURI(candidates[i].URL, base)
+	}
+
+	return FormatSrcset(candidates)
+}
+
+// isSpace determines if the byte is ASCII whitespace.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,79 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSrcset(t *testing.T) {
+	tests := []struct {
+		srcset   string
+		expected []ImageCandidate
+	}{
+		{"", nil},
+		{"a.png", []ImageCandidate{{URL: "a.png"}}},
+		{"a.png 320w, b.png 640w 480h", []ImageCandidate{
+			{URL: "a.png", Width: 320, Descriptors: "320w"},
+			{URL: "b.png", Width: 640, Height: 480, Descriptors: "640w 480h"},
+		}},
+		{"a.png, b.png 1.5x", []ImageCandidate{
+			{URL: "a.png"},
+			{URL: "b.png", Density: 1.5, Descriptors: "1.5x"},
+		}},
+		{"/img.php?size=1,2 2x,/c.png 1x", []ImageCandidate{
+			{URL: "/img.php?size=1,2", Density: 2, Descriptors: "2x"},
+			{URL: "/c.png", Density: 1, Descriptors: "1x"},
+		}},
+		{"data:image/png;base64,iVBO 1x, b.png future(a, b) 2x", []ImageCandidate{
+			{URL: "data:image/png;base64,iVBO", Density: 1, Descriptors: "1x"},
+			{URL: "b.png", Density: 2, Descriptors: "future(a, b) 2x"},
+		}},
+	}
+
+	for _, test := range tests {
+		if candidates := ParseSrcset(test.srcset); !reflect.DeepEqual(candidates, test.expected) {
+			t.Fatalf("ParseSrcset(%q) = %#v", test.srcset, candidates)
+		}
+	}
+
+	srcset := "a.png 320w, /img.php?size=1,2 future(a, b) 2x, c.png"
+
+	if formatted := FormatSrcset(ParseSrcset(srcset)); formatted != srcset {
+		t.Fatalf("descriptors were not preserved: %q", formatted)
+	}
+}
+
+func TestAbsoluteSrcset(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<picture>
+					<source srcset="/photo.webp?crop=1,1 1x, //cdn.cixtor.com/photo@2x.webp 2x" type="image/webp">
+					<img src="/photo.jpg" srcset="photo-640.jpg 640w, /photo-1280.jpg 1280w" alt="photo">
+				</picture>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog/post")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []string{
+		`srcset="https://cixtor.com/photo.webp?crop=1,1 1x, https://cdn.cixtor.com/photo@2x.webp 2x"`,
+		`srcset="https://cixtor.com/blog/photo-640.jpg 640w, https://cixtor.com/photo-1280.jpg 1280w"`,
+	}
+
+	for _, srcset := range expected {
+		if !strings.Contains(a.Content, srcset) {
+			t.Fatalf("missing %s in %s", srcset, a.Content)
+		}
+	}
+}
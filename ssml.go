@@ -0,0 +1,225 @@
+package readability
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var rxSentenceEnd = regexp.MustCompile(`([.!?…]["'”’)]?)\s+`)
+
+// ssmlBlockElems is a list of HTML tag names read as separate paragraphs.
+var ssmlBlockElems = []string{
+	"address", "article", "aside", "blockquote", "dd", "div", "dl", "dt",
+	"figcaption", "figure", "footer", "h1", "h2", "h3", "h4", "h5", "h6",
+	"header", "li", "ol", "p", "pre", "section", "table", "td", "th", "tr",
+	"ul",
+}
+
+// SSMLRenderer renders the article as a Speech Synthesis Markup Language
+// document for text-to-speech engines. Each block of text is read as its own
+// paragraph, emphasized text is read with emphasis and short pauses are added
+// between sentences.
+type SSMLRenderer struct {
+	// SkipCode leaves code blocks and inline code out of the narration.
+	SkipCode bool
+
+	// SkipTables leaves tables out of the narration.
+	SkipTables bool
+}
+
+// ssmlWriter accumulates the paragraphs of an SSML document.
+type ssmlWriter struct {
+	renderer  SSMLRenderer
+	document  strings.Builder
+	paragraph strings.Builder
+
+	// heading is true while the text of a heading is written, the blocks
+	// inside of it are read as inline text to keep the emphasis balanced.
+	heading bool
+}
+
+// Render implements the ArticleRenderer interface.
+func (s SSMLRenderer) Render(w io.Writer, article Article) error {
+	nodes, err := ssmlContent(article)
+
+	if err != nil {
+		return err
+	}
+
+	writer := &ssmlWriter{renderer: s}
+	writer.document.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	writer.document.WriteString("<speak version=\"1.1\" xmlns=\"http://www.w3.org/2001/10/synthesis\">\n")
+
+	if article.Title != "" {
+		writer.paragraph.WriteString("<emphasis level=\"strong\">" + html.EscapeString(article.Title) + "</emphasis>")
+		writer.flush()
+	}
+
+	for _, node := range nodes {
+		writer.walk(node)
+	}
+
+	writer.flush()
+	writer.document.WriteString("</speak>\n")
+
+	if _, err := io.WriteString(w, writer.document.String()); err != nil {
+		return fmt.Errorf("failed to write ssml: %v", err)
+	}
+
+	return nil
+}
+
+// ssmlContent returns the top-level nodes of the content of the article. The
+// retained content is used when there is one, since Content is empty if the
+// article was parsed with OmitContentStrings.
+func ssmlContent(article Article) ([]*html.Node, error) {
+	if article.content != nil {
+		var nodes []*html.Node
+
+		for child := article.content.FirstChild; child != nil; child = child.NextSibling {
+			nodes = append(nodes, child)
+		}
+
+		return nodes, nil
+	}
+
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(article.Content), context)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse content: %v", err)
+	}
+
+	return nodes, nil
+}
+
+// flush writes the accumulated text as a paragraph of the document.
+func (s *ssmlWriter) flush() {
+	text := strings.TrimSpace(collapseWhitespace([]byte(s.paragraph.String()), 1))
+	s.paragraph.Reset()
+
+	if text == "" {
+		return
+	}
+
+	s.document.WriteString("<p>" + text + "</p>\n")
+}
+
+// walk converts node and its descendants into SSML.
+func (s *ssmlWriter) walk(node *html.Node) {
+	if node.Type == html.TextNode {
+		s.paragraph.WriteString(ssmlText(node.Data))
+		return
+	}
+
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	switch node.Data {
+	case "script", "style", "noscript", "template", "img", "picture", "svg",
+		"video", "audio", "iframe", "object", "embed", "button", "input":
+		return
+	case "pre", "code":
+		if s.renderer.SkipCode {
+			return
+		}
+	case "table":
+		if s.renderer.SkipTables {
+			return
+		}
+	case "br":
+		s.paragraph.WriteString(" ")
+		return
+	case "em", "i", "strong", "b":
+		if !hasSSMLBlock(node) {
+			level := "moderate"
+
+			if node.Data == "strong" || node.Data == "b" {
+				level = "strong"
+			}
+
+			s.paragraph.WriteString("<emphasis level=\"" + level + "\">")
+			s.walkChildren(node)
+			s.paragraph.WriteString("</emphasis>")
+			return
+		}
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		if s.heading {
+			break
+		}
+
+		s.flush()
+		s.heading = true
+		s.walkChildren(node)
+		s.heading = false
+
+		text := strings.TrimSpace(collapseWhitespace([]byte(s.paragraph.String()), 1))
+		s.paragraph.Reset()
+
+		if text != "" {
+			s.paragraph.WriteString("<emphasis level=\"strong\">" + text + "</emphasis>")
+		}
+
+		s.flush()
+		return
+	}
+
+	if indexOf(ssmlBlockElems, node.Data) == -1 {
+		s.walkChildren(node)
+		return
+	}
+
+	if s.heading {
+		s.paragraph.WriteString(" ")
+		s.walkChildren(node)
+		s.paragraph.WriteString(" ")
+		return
+	}
+
+	s.flush()
+	s.walkChildren(node)
+	s.flush()
+}
+
+// ssmlText escapes the text, adding a pause after each sentence. The sentences
+// are split before the text is escaped, so the closing quotes are recognized.
+func ssmlText(text string) string {
+	var buffer strings.Builder
+
+	last := 0
+
+	for _, match := range rxSentenceEnd.FindAllStringSubmatchIndex(text, -1) {
+		buffer.WriteString(html.EscapeString(text[last:match[3]]))
+		buffer.WriteString(" <break strength=\"medium\"/> ")
+		last = match[1]
+	}
+
+	buffer.WriteString(html.EscapeString(text[last:]))
+
+	return buffer.String()
+}
+
+// walkChildren converts the children of node into SSML.
+func (s *ssmlWriter) walkChildren(node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		s.walk(child)
+	}
+}
+
+// hasSSMLBlock returns true if node contains an element that is read as a
+// separate paragraph, in which case it cannot be wrapped with inline tags.
+func hasSSMLBlock(node *html.Node) bool {
+	for _, elem := range getElementsByTagName(node, "*") {
+		if elem != node && indexOf(ssmlBlockElems, elem.Data) != -1 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package readability
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSSMLRenderer(t *testing.T) {
+	article := Article{
+		Title: "Fish & Chips",
+		Content: `<div><h2>Batter</h2><p>Mix the <em>flour</em> and the <strong>beer</strong>. Rest it.</p>` +
+			`<pre><code>fry(fish)</code></pre><table><tr><td>salt</td></tr></table></div>`,
+	}
+
+	var buffer bytes.Buffer
+
+	if err := (SSMLRenderer{SkipCode: true, SkipTables: true}).Render(&buffer, article); err != nil {
+		t.Fatalf("render failure: %s", err)
+	}
+
+	expected := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+		"<speak version=\"1.1\" xmlns=\"http://www.w3.org/2001/10/synthesis\">\n" +
+		"<p><emphasis level=\"strong\">Fish &amp; Chips</emphasis></p>\n" +
+		"<p><emphasis level=\"strong\">Batter</emphasis></p>\n" +
+		"<p>Mix the <emphasis level=\"moderate\">flour</emphasis> and the <emphasis level=\"strong\">beer</emphasis>. <break strength=\"medium\"/> Rest it.</p>\n" +
+		"</speak>\n"
+
+	if buffer.String() != expected {
+		t.Fatalf("unexpected ssml:\n%s", buffer.String())
+	}
+
+	buffer.Reset()
+
+	if err := (SSMLRenderer{}).Render(&buffer, article); err != nil {
+		t.Fatalf("render failure: %s", err)
+	}
+
+	if !strings.Contains(buffer.String(), "<p>fry(fish)</p>") || !strings.Contains(buffer.String(), "<p>salt</p>") {
+		t.Fatalf("code and tables should be read:\n%s", buffer.String())
+	}
+}
+
+func TestSSMLHeadingBlocks(t *testing.T) {
+	article := Article{
+		Content: `<h2><div>Part one</div><p>The "batter".</p></h2><p>He said "rest it." Then fry.</p>`,
+	}
+
+	var buffer bytes.Buffer
+
+	if err := (SSMLRenderer{}).Render(&buffer, article); err != nil {
+		t.Fatalf("render failure: %s", err)
+	}
+
+	expected := []string{
+		"<p><emphasis level=\"strong\">Part one The &#34;batter&#34;.</emphasis></p>\n",
+		"<p>He said &#34;rest it.&#34; <break strength=\"medium\"/> Then fry.</p>\n",
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(buffer.String(), fragment) {
+			t.Fatalf("expecting %q in the ssml:\n%s", fragment, buffer.String())
+		}
+	}
+}
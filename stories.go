@@ -0,0 +1,162 @@
+package readability
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// storyMinLength is the minimum length of the text of a story, excluding its
+// heading, which filters out navigation blocks with headings.
+const storyMinLength = 80
+
+// storyHeadings is a list of HTML tag names used as the headings of stories.
+var storyHeadings = []string{"h1", "h2", "h3"}
+
+// storyContainers returns the containers of the stories listed in an index
+// page, in document order. Each heading of the page is expanded to the largest
+// ancestor that does not contain another heading, which groups the heading
+// with its text, and the groups with enough text become the stories.
+func (r *Readability) storyContainers(doc *html.Node) []*html.Node {
+	var stories []*html.Node
+
+	for _, heading := range getElementsByTagName(doc, "*") {
+		if indexOf(storyHeadings, tagName(heading)) == -1 || !r.isProbablyVisible(heading) {
+			continue
+		}
+
+		container := heading
+
+		for parent := heading.Parent; parent != nil && tagName(parent) != "body"; parent = parent.Parent {
+			if countStoryHeadings(parent) > 1 {
+				break
+			}
+
+			container = parent
+		}
+
+		if container == heading || includeNode(stories, container) {
+			continue
+		}
+
+		textLength := len(r.getInnerText(container, true)) - len(r.getInnerText(heading, true))
+
+		if textLength < storyMinLength || r.getLinkDensity(container) > 0.5 {
+			continue
+		}
+
+		stories = append(stories, container)
+	}
+
+	return stories
+}
+
+// countStoryHeadings returns the number of story headings inside node.
+func countStoryHeadings(node *html.Node) int {
+	count := 0
+
+	for _, elem := range getElementsByTagName(node, "*") {
+		if indexOf(storyHeadings, tagName(elem)) != -1 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// storyURL returns the absolute URL of the first link in the heading of the
+// story, which usually points to the full article.
+func (r *Readability) storyURL(story *html.Node) string {
+	for _, elem := range getElementsByTagName(story, "*") {
+		if indexOf(storyHeadings, tagName(elem)) == -1 {
+			continue
+		}
+
+		for _, link := range getElementsByTagName(elem, "a") {
+			if href := getAttribute(link, "href"); href != "" && href[0] != '#' {
+				return toAbsoluteURI(href, r.documentURI)
+			}
+		}
+
+		break
+	}
+
+	return r.segmentURL(story)
+}
+
+// ExtractAll parses an index page, like the front page of a blog or a news
+// briefing, and returns one article for each story listed in the page instead
+// of merging all of them into a single article. The stories are returned in
+// document order, each with the URL of the link in its heading, if any.
+//
+// If the page does not list at least two stories, the result contains the
+// same article returned by Parse.
+func (r *Readability) ExtractAll(input io.Reader, pageURL string) ([]Article, error) {
+	doc, err := html.Parse(input)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	// The page URL is needed to resolve the URLs of the stories.
+	if r.documentURI, err = url.ParseRequestURI(pageURL); err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %v", err)
+	}
+
+	prepared := cloneNode(doc)
+	r.removeScripts(prepared)
+	r.removeNodes(getElementsByTagName(prepared, "style"), nil)
+
+	stories := r.storyContainers(prepared)
+
+	if len(stories) < 2 {
+		result, err := r.analyzeDocument(doc, pageURL, time.Now())
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []Article{result.article()}, nil
+	}
+
+	var storyURLs []string
+
+	for _, story := range stories {
+		storyURLs = append(storyURLs, r.storyURL(story))
+	}
+
+	articles := make([]Article, 0, len(stories))
+
+	for i, story := range stories {
+		storyURL := storyURLs[i]
+
+		if storyURL == "" {
+			storyURL = pageURL
+		}
+
+		result, err := r.analyzeDocument(segmentDocument(prepared, story, false), storyURL, time.Now())
+
+		if err != nil {
+			return nil, err
+		}
+
+		articles = append(articles, result.article())
+	}
+
+	return articles, nil
+}
+
+// firstHeading returns the text of the first story heading inside node.
+func firstHeading(node *html.Node) string {
+	for _, elem := range getElementsByTagName(node, "*") {
+		if indexOf(storyHeadings, tagName(elem)) != -1 {
+			return strings.TrimSpace(textContent(elem))
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,45 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractAll(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>Morning briefing</title>
+		</head>
+		<body>
+			<nav><h3>Sections</h3><a href="/world">World</a> <a href="/sports">Sports</a></nav>
+			<main>
+				<div class="story">
+					<h2><a href="/markets">Markets rally</a></h2>
+					<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore.</p>
+				</div>
+				<div class="story">
+					<h2><a href="/weather">Storm approaches</a></h2>
+					<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+				</div>
+			</main>
+		</body>
+		</html>`)
+
+	articles, err := New().ExtractAll(input, "https://cixtor.com/briefing")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(articles) != 2 {
+		t.Fatalf("expecting two stories: %d", len(articles))
+	}
+
+	if articles[0].Title != "Markets rally" || !strings.Contains(articles[0].TextContent, "Lorem ipsum") {
+		t.Fatalf("unexpected first story: %q %q", articles[0].Title, articles[0].TextContent)
+	}
+
+	if articles[1].Title != "Storm approaches" || strings.Contains(articles[1].TextContent, "Lorem ipsum") {
+		t.Fatalf("unexpected second story: %q %q", articles[1].Title, articles[1].TextContent)
+	}
+}
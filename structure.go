@@ -0,0 +1,153 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ErrEmptyDocument is returned when the document has nothing in its body, so
+// there is no content to extract.
+var ErrEmptyDocument = errors.New("document has no content")
+
+// FramesetError is returned when the document is a frameset, which has no
+// body and loads its content from other pages. It wraps ErrEmptyDocument.
+type FramesetError struct {
+	// Frames are the absolute addresses of the pages loaded by the frames,
+	// in the same order found in the document.
+	Frames []string
+}
+
+// Error implements the error interface.
+func (e *FramesetError) Error() string {
+	return fmt.Sprintf("%s: frameset with %d frames", ErrEmptyDocument, len(e.Frames))
+}
+
+// Unwrap returns ErrEmptyDocument, so errors.Is can be used to check the error.
+func (e *FramesetError) Unwrap() error {
+	return ErrEmptyDocument
+}
+
+// ensureStructure adds the <html>, <head> and <body> elements missing from
+// the document, which happens with documents built by hand or by other
+// parsers, moving the other nodes into them, so the rest of the parser can
+// rely on them. Framesets are left untouched, they have no body.
+func ensureStructure(doc *html.Node) {
+	root := documentElement(doc)
+
+	if root == nil {
+		root = createElement("html")
+
+		for _, child := range childNodes(doc) {
+			if child.Type != html.DoctypeNode {
+				doc.RemoveChild(child)
+				root.AppendChild(child)
+			}
+		}
+
+		doc.AppendChild(root)
+	}
+
+	if len(getElementsByTagName(root, "frameset")) > 0 {
+		return
+	}
+
+	head := firstChildElement(root, "head")
+
+	if head == nil {
+		head = createElement("head")
+		root.InsertBefore(head, root.FirstChild)
+	}
+
+	if len(getElementsByTagName(root, "body")) > 0 {
+		return
+	}
+
+	body := createElement("body")
+
+	for _, child := range childNodes(root) {
+		if child != head {
+			root.RemoveChild(child)
+			body.AppendChild(child)
+		}
+	}
+
+	root.AppendChild(body)
+}
+
+// firstChildElement returns the first child of node with the tag name.
+func firstChildElement(node *html.Node, tag string) *html.Node {
+	for child := firstElementChild(node); child != nil; child = nextElementSibling(child) {
+		if tagName(child) == tag {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// checkContent returns an error if there is no content to extract from the
+// document, because it is a frameset or because its body is empty.
+func (r *Readability) checkContent(doc *html.Node) error {
+	bodies := getElementsByTagName(doc, "body")
+
+	if len(bodies) == 0 {
+		var frames []string
+
+		for _, frame := range getElementsByTagName(doc, "frame") {
+			if src := toAbsoluteURI(getAttribute(frame, "src"), r.documentURI); src != "" {
+				frames = append(frames, src)
+			}
+		}
+
+		return &FramesetError{Frames: frames}
+	}
+
+	if firstElementChild(bodies[0]) == nil && strings.TrimSpace(textContent(bodies[0])) == "" {
+		return ErrEmptyDocument
+	}
+
+	return nil
+}
+
+// ParseFragment finds the main readable content of an HTML fragment, like the
+// content of a feed entry or a snippet stored in a database, which is parsed
+// as if it were the content of a <body> element.
+func (r *Readability) ParseFragment(input io.Reader, pageURL string) (Article, error) {
+	start := time.Now()
+
+	nodes, err := html.ParseFragment(input, &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+
+	if err != nil {
+		return Article{}, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	doc := &html.Node{Type: html.DocumentNode}
+	root := createElement("html")
+	body := createElement("body")
+
+	root.AppendChild(createElement("head"))
+	root.AppendChild(body)
+	doc.AppendChild(root)
+
+	for _, node := range nodes {
+		body.AppendChild(node)
+	}
+
+	result, err := r.analyzeDocument(doc, pageURL, start)
+
+	if err != nil {
+		return Article{}, err
+	}
+
+	return result.article(), nil
+}
@@ -0,0 +1,87 @@
+package readability
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const structureTestParagraphs = `<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+	<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>`
+
+func TestEnsureStructure(t *testing.T) {
+	nodes, err := html.ParseFragment(strings.NewReader(structureTestParagraphs), &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div})
+
+	if err != nil {
+		t.Fatalf("cannot parse fragment: %s", err)
+	}
+
+	// A document without <html>, <head> and <body>.
+	doc := &html.Node{Type: html.DocumentNode}
+	doc.AppendChild(&html.Node{Type: html.DoctypeNode, Data: "html"})
+
+	for _, node := range nodes {
+		doc.AppendChild(node)
+	}
+
+	a, err := New().ParseNode(doc, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.Contains(a.TextContent, "Lorem ipsum") || !strings.Contains(a.TextContent, "Ut enim") {
+		t.Fatalf("unexpected content: %q", a.TextContent)
+	}
+
+	ensureStructure(doc)
+
+	if doc.FirstChild.Type != html.DoctypeNode || outerHTML(doc.LastChild) != "<html><head></head><body>"+structureTestParagraphs+"</body></html>" {
+		t.Fatalf("unexpected structure: %s", outerHTML(doc.LastChild))
+	}
+}
+
+func TestEmptyDocument(t *testing.T) {
+	_, err := New().Parse(strings.NewReader(`<html><head><title>hello world</title></head><body> </body></html>`), "https://cixtor.com/blog")
+
+	if !errors.Is(err, ErrEmptyDocument) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = New().Parse(strings.NewReader(`<html>
+		<head><title>hello world</title></head>
+		<frameset cols="25%,75%">
+			<frame src="/menu.html">
+			<frame src="https://example.com/content.html">
+		</frameset>
+		</html>`), "https://cixtor.com/blog/")
+
+	var frameset *FramesetError
+
+	if !errors.As(err, &frameset) || !errors.Is(err, ErrEmptyDocument) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(frameset.Frames) != 2 || frameset.Frames[0] != "https://cixtor.com/menu.html" || frameset.Frames[1] != "https://example.com/content.html" {
+		t.Fatalf("unexpected frames: %#v", frameset.Frames)
+	}
+}
+
+func TestParseFragment(t *testing.T) {
+	a, err := New().ParseFragment(strings.NewReader(structureTestParagraphs+`<td>stray cell</td>`), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if !strings.HasPrefix(a.TextContent, "Lorem ipsum") || !strings.Contains(a.TextContent, "Ut enim") {
+		t.Fatalf("unexpected content: %q", a.TextContent)
+	}
+
+	if _, err := New().ParseFragment(strings.NewReader("  "), "https://cixtor.com/blog"); !errors.Is(err, ErrEmptyDocument) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
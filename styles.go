@@ -0,0 +1,69 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// defaultStyleProperties is the list of CSS properties kept by SanitizeStyles.
+// They carry the direction and the alignment of the text, which matter for
+// right-to-left languages and for poetry, and none of them can load resources.
+var defaultStyleProperties = []string{
+	"direction",
+	"text-align",
+	"text-indent",
+	"unicode-bidi",
+	"white-space",
+	"writing-mode",
+}
+
+// rxStyleValue matches the CSS values kept by SanitizeStyles, which are made of
+// keywords and lengths. Functions, like url() and expression(), and escape
+// sequences are rejected.
+var rxStyleValue = regexp.MustCompile(`^[A-Za-z0-9 .%+-]+$`)
+
+// rxImportant matches the !important annotation at the end of a CSS value.
+var rxImportant = regexp.MustCompile(`(?i)\s*!\s*important\s*$`)
+
+// alignValues are the values of the deprecated align attribute converted into
+// the text-align property by SanitizeStyles.
+var alignValues = []string{"left", "right", "center", "justify"}
+
+// sanitizedStyle returns the declarations of the style attribute of node with
+// one of the StyleProperties and a safe value, in the same order, or an empty
+// string if there are none. The deprecated align attribute of the blocks of
+// text is converted into the text-align property.
+func (r *Readability) sanitizedStyle(node *html.Node) string {
+	var declarations []string
+
+	seen := map[string]bool{}
+
+	for _, declaration := range strings.Split(getAttribute(node, "style"), ";") {
+		idx := strings.Index(declaration, ":")
+
+		if idx == -1 {
+			continue
+		}
+
+		property := strings.ToLower(strings.TrimSpace(declaration[:idx]))
+		value := strings.TrimSpace(rxImportant.ReplaceAllString(declaration[idx+1:], ""))
+
+		if indexOf(r.StyleProperties, property) == -1 || !rxStyleValue.MatchString(value) {
+			continue
+		}
+
+		seen[property] = true
+		declarations = append(declarations, property+": "+value)
+	}
+
+	align := strings.ToLower(strings.TrimSpace(getAttribute(node, "align")))
+
+	if !seen["text-align"] && indexOf(r.StyleProperties, "text-align") != -1 &&
+		indexOf(alignValues, align) != -1 && indexOf(divToPElems, tagName(node)) != -1 {
+		declarations = append(declarations, "text-align: "+align)
+	}
+
+	return strings.Join(declarations, "; ")
+}
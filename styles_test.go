@@ -0,0 +1,81 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestSanitizedStyle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<p style="color: red; TEXT-ALIGN: center !important;">`, `text-align: center`},
+		{`<p style="direction:rtl;unicode-bidi:embed">`, `direction: rtl; unicode-bidi: embed`},
+		{`<p style="text-indent: -2em; white-space: pre-wrap">`, `text-indent: -2em; white-space: pre-wrap`},
+		{`<p style="text-align: expression(alert(1)); direction: url(x)">`, ``},
+		{`<p style="font-size: 2em">`, ``},
+		{`<p align="right">`, `text-align: right`},
+		{`<p align="right" style="text-align: left">`, `text-align: left`},
+		{`<p align="middle">`, ``},
+		{`<span align="right">`, ``},
+	}
+
+	r := New()
+
+	for _, test := range tests {
+		doc, err := html.Parse(strings.NewReader(test.input))
+
+		if err != nil {
+			t.Fatalf("cannot parse document: %s", err)
+		}
+
+		node := getElementsByTagName(doc, "body")[0].FirstChild
+
+		if style := r.sanitizedStyle(node); style != test.expected {
+			t.Fatalf("sanitizedStyle(%s) = %q, expecting %q", test.input, style, test.expected)
+		}
+	}
+}
+
+func TestSanitizeStyles(t *testing.T) {
+	input := `<html>
+		<head><title>hello world</title></head>
+		<body>
+			<article>
+				<p style="color: red; text-align: center">Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore.</p>
+				<p style="direction: rtl">Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+				<p align="right">Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			</article>
+		</body>
+		</html>`
+
+	a, err := New().Parse(strings.NewReader(input), "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if strings.Contains(a.Content, "style=") || strings.Contains(a.Content, "align=") {
+		t.Fatalf("the styles were not removed: %s", a.Content)
+	}
+
+	parser := New()
+	parser.SanitizeStyles = true
+
+	if a, err = parser.Parse(strings.NewReader(input), "https://cixtor.com/blog"); err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	for _, style := range []string{`<p style="text-align: center">`, `<p style="direction: rtl">`, `<p style="text-align: right">`} {
+		if !strings.Contains(a.Content, style) {
+			t.Fatalf("style %s was not kept: %s", style, a.Content)
+		}
+	}
+
+	if strings.Contains(a.Content, "color") {
+		t.Fatalf("the styles were not sanitized: %s", a.Content)
+	}
+}
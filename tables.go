@@ -0,0 +1,367 @@
+package readability
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"golang.org/x/net/html"
+)
+
+// TableData is the text of a data table found in the content of the article.
+type TableData struct {
+	// Caption is the text of the <caption> element of the table, if any.
+	Caption string
+
+	// Headers is the text of the cells of the header row of the table, which
+	// is the last row of the <thead> element or, if there is none, the first
+	// row of the table if all its cells are <th> elements.
+	Headers []string
+
+	// Rows is the text of the cells of the other rows of the table. Cells
+	// spanning multiple columns are followed by empty cells, so the columns
+	// of every row are aligned with the headers.
+	Rows [][]string
+}
+
+// WriteCSV writes the headers, if any, and the rows of the table as CSV.
+func (t TableData) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if len(t.Headers) > 0 {
+		if err := writer.Write(t.Headers); err != nil {
+			return fmt.Errorf("failed to write headers: %v", err)
+		}
+	}
+
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return fmt.Errorf("failed to write rows: %v", err)
+	}
+
+	return nil
+}
+
+// extractTables returns the text of the tables of the content that were marked
+// as data tables by markDataTables.
+func (r *Readability) extractTables(articleContent *html.Node) []TableData {
+	var tables []TableData
+
+	for _, table := range getElementsByTagName(articleContent, "table") {
+		if !r.isReadabilityDataTable(table) {
+			continue
+		}
+
+		tables = append(tables, r.tableData(table))
+	}
+
+	return tables
+}
+
+// tableData returns the text of the caption and the cells of a table, ignoring
+// the rows of nested tables.
+func (r *Readability) tableData(table *html.Node) TableData {
+	var data TableData
+
+	for _, caption := range getElementsByTagName(table, "caption") {
+		if closestAncestor(caption, "table") == table {
+			data.Caption = r.getInnerText(caption, true)
+			break
+		}
+	}
+
+	for _, tr := range getElementsByTagName(table, "tr") {
+		if closestAncestor(tr, "table") != table {
+			continue
+		}
+
+		row, allHeaders := r.tableRow(tr)
+
+		if len(row) == 0 {
+			continue
+		}
+
+		inHead := tagName(tr.Parent) == "thead"
+
+		if inHead || (allHeaders && data.Headers == nil && len(data.Rows) == 0) {
+			data.Headers = row
+			continue
+		}
+
+		data.Rows = append(data.Rows, row)
+	}
+
+	return data
+}
+
+// tableRow returns the text of the cells of a table row, and whether all of
+// them are header cells.
+func (r *Readability) tableRow(tr *html.Node) ([]string, bool) {
+	var row []string
+
+	allHeaders := true
+
+	for _, cell := range children(tr) {
+		tag := tagName(cell)
+
+		if tag != "td" && tag != "th" {
+			continue
+		}
+
+		if tag == "td" {
+			allHeaders = false
+		}
+
+		if len(row) >= maxTableColumns {
+			break
+		}
+
+		row = append(row, r.getInnerText(cell, true))
+
+		for i := 1; i < tableSpan(cell, "colspan") && len(row) < maxTableColumns; i++ {
+			row = append(row, "")
+		}
+	}
+
+	return row, allHeaders && len(row) > 0
+}
+
+// maxTableSpan limits the rowspan and colspan of the cells when the tables are
+// normalized, as the browsers do, so malformed tables cannot grow unbounded.
+const maxTableSpan = 1000
+
+// maxTableColumns limits the number of cells of the rows of the tables, so a
+// row with many cells spanning many columns cannot grow unbounded either.
+const maxTableColumns = maxTableSpan
+
+// tableSpan returns the value of a rowspan or colspan attribute, or 1 if the
+// attribute is missing or not valid.
+func tableSpan(cell *html.Node, attrName string) int {
+	span, err := strconv.Atoi(getAttribute(cell, attrName))
+
+	if err != nil || span < 1 {
+		return 1
+	}
+
+	if span > maxTableSpan {
+		return maxTableSpan
+	}
+
+	return span
+}
+
+// pendingSpan is a cell spanning from a previous row, with the number of rows
+// it still spans.
+type pendingSpan struct {
+	tag  string
+	rows int
+}
+
+// normalizeTables rewrites the data tables of the content, see NormalizeTables.
+func (r *Readability) normalizeTables(articleContent *html.Node) {
+	for _, table := range getElementsByTagName(articleContent, "table") {
+		if r.isReadabilityDataTable(table) {
+			normalizeTable(table)
+		}
+	}
+}
+
+// normalizeTable rebuilds the table with a grid of cells where every row has
+// the same number of cells. The cells spanning multiple rows or columns keep
+// the first position and the other positions get empty cells of the same
+// type. The header rows go into <thead>, the footer rows into <tfoot> and the
+// other rows into <tbody>. The column groups are kept after the caption, with
+// their spans limited like the spans of the cells.
+func normalizeTable(table *html.Node) {
+	var rows []*html.Node
+	var colgroups []*html.Node
+	var caption *html.Node
+
+	for _, node := range getElementsByTagName(table, "*") {
+		if closestAncestor(node, "table") != table {
+			continue
+		}
+
+		switch tagName(node) {
+		case "tr":
+			rows = append(rows, node)
+		case "caption":
+			if caption == nil {
+				caption = node
+			}
+		case "colgroup":
+			colgroups = append(colgroups, node)
+		}
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+
+	pending := map[int]*pendingSpan{}
+	grid := make([][]*html.Node, len(rows))
+	columns := 0
+
+	fill := func(row int, col int) bool {
+		s := pending[col]
+
+		if s == nil || s.rows == 0 {
+			return false
+		}
+
+		grid[row] = append(grid[row], createElement(s.tag))
+		s.rows--
+
+		return true
+	}
+
+	for i, tr := range rows {
+		col := 0
+
+		for _, cell := range children(tr) {
+			tag := tagName(cell)
+
+			if tag != "td" && tag != "th" {
+				continue
+			}
+
+			for fill(i, col) {
+				col++
+			}
+
+			rowSpan := tableSpan(cell, "rowspan")
+			colSpan := tableSpan(cell, "colspan")
+
+			removeAttribute(cell, "rowspan")
+			removeAttribute(cell, "colspan")
+			tr.RemoveChild(cell)
+			grid[i] = append(grid[i], cell)
+
+			for j := 0; j < colSpan; j++ {
+				if j > 0 {
+					grid[i] = append(grid[i], createElement(tag))
+				}
+
+				if rowSpan > 1 {
+					pending[col+j] = &pendingSpan{tag: tag, rows: rowSpan - 1}
+				}
+			}
+
+			col += colSpan
+		}
+
+		// Cells spanning into the positions after the last cell of the row.
+		for end := col; end <= maxPendingColumn(pending); end++ {
+			if !fill(i, end) {
+				grid[i] = append(grid[i], createElement("td"))
+			}
+		}
+
+		if len(grid[i]) > columns {
+			columns = len(grid[i])
+		}
+	}
+
+	var head, body, foot []*html.Node
+
+	for i, tr := range rows {
+		row := createElement("tr")
+		row.Attr = tr.Attr
+
+		for _, cell := range grid[i] {
+			row.AppendChild(cell)
+		}
+
+		for j := len(grid[i]); j < columns; j++ {
+			row.AppendChild(createElement("td"))
+		}
+
+		switch {
+		case tagName(tr.Parent) == "thead":
+			head = append(head, row)
+		case tagName(tr.Parent) == "tfoot":
+			foot = append(foot, row)
+		case len(head) == 0 && len(body) == 0 && isHeaderRow(grid[i]):
+			head = append(head, row)
+		default:
+			body = append(body, row)
+		}
+	}
+
+	if caption != nil {
+		caption.Parent.RemoveChild(caption)
+	}
+
+	for table.FirstChild != nil {
+		table.RemoveChild(table.FirstChild)
+	}
+
+	if caption != nil {
+		table.AppendChild(caption)
+	}
+
+	for _, colgroup := range colgroups {
+		normalizeColgroup(colgroup)
+		table.AppendChild(detach(colgroup))
+	}
+
+	for _, section := range []struct {
+		tag  string
+		rows []*html.Node
+	}{{"thead", head}, {"tbody", body}, {"tfoot", foot}} {
+		if len(section.rows) == 0 {
+			continue
+		}
+
+		group := createElement(section.tag)
+
+		for _, row := range section.rows {
+			group.AppendChild(row)
+		}
+
+		table.AppendChild(group)
+	}
+}
+
+// normalizeColgroup limits the spans of the column group and of its columns,
+// and drops the span of a group with columns, which the browsers ignore.
+func normalizeColgroup(colgroup *html.Node) {
+	cols := getElementsByTagName(colgroup, "col")
+
+	if len(cols) > 0 {
+		removeAttribute(colgroup, "span")
+	} else if hasAttribute(colgroup, "span") {
+		setAttribute(colgroup, "span", strconv.Itoa(tableSpan(colgroup, "span")))
+	}
+
+	for _, col := range cols {
+		if hasAttribute(col, "span") {
+			setAttribute(col, "span", strconv.Itoa(tableSpan(col, "span")))
+		}
+	}
+}
+
+// maxPendingColumn returns the last column with a cell spanning into the next
+// rows, or -1 if there is none.
+func maxPendingColumn(pending map[int]*pendingSpan) int {
+	last := -1
+
+	for col, s := range pending {
+		if s.rows > 0 && col > last {
+			last = col
+		}
+	}
+
+	return last
+}
+
+// isHeaderRow returns true if all the cells of the row are header cells.
+func isHeaderRow(cells []*html.Node) bool {
+	for _, cell := range cells {
+		if tagName(cell) != "th" {
+			return false
+		}
+	}
+
+	return len(cells) > 0
+}
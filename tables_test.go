@@ -0,0 +1,133 @@
+package readability
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestTables(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<table>
+					<caption>Population</caption>
+					<thead><tr><th>City</th><th>Country</th><th>People</th></tr></thead>
+					<tbody>
+						<tr><td>Lima</td><td>Peru</td><td>9,751,717</td></tr>
+						<tr><td colspan="2">Other, "rural"</td><td>1,000</td></tr>
+					</tbody>
+				</table>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(a.Tables) != 1 || a.Tables[0].Caption != "Population" {
+		t.Fatalf("expecting one data table: %#v", a.Tables)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := a.Tables[0].WriteCSV(&buffer); err != nil {
+		t.Fatalf("failed to write CSV: %s", err)
+	}
+
+	expected := "City,Country,People\n" +
+		"Lima,Peru,\"9,751,717\"\n" +
+		"\"Other, \"\"rural\"\"\",,\"1,000\"\n"
+
+	if buffer.String() != expected {
+		t.Fatalf("unexpected CSV:\n%s", buffer.String())
+	}
+}
+
+func TestNormalizeTables(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>hello world</title>
+		</head>
+		<body>
+			<article>
+				<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+				<table summary="results">
+					<tr><th>Team</th><th colspan="2">Score</th></tr>
+					<tr><td rowspan="2">Red</td><td>1</td><td>2</td></tr>
+					<tr><td>3</td></tr>
+				</table>
+			</article>
+		</body>
+		</html>`)
+
+	parser := New()
+	parser.NormalizeTables = true
+	a, err := parser.Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := `<table summary="results">` +
+		`<thead><tr><th>Team</th><th>Score</th><th></th></tr></thead>` +
+		`<tbody><tr><td>Red</td><td>1</td><td>2</td></tr><tr><td></td><td>3</td><td></td></tr></tbody>` +
+		`</table>`
+
+	if !strings.Contains(a.Content, expected) {
+		t.Fatalf("unexpected table:\n%s", a.Content)
+	}
+}
+
+func TestTableRowSpanLimit(t *testing.T) {
+	tr := createElement("tr")
+
+	for _, span := range []string{"30000000", "2000", "3"} {
+		cell := createElement("td")
+		setAttribute(cell, "colspan", span)
+		tr.AppendChild(cell)
+	}
+
+	row, _ := New().tableRow(tr)
+
+	if len(row) != maxTableColumns {
+		t.Fatalf("expecting %d cells; got %d", maxTableColumns, len(row))
+	}
+}
+
+func TestNormalizeTableColgroups(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<table>` +
+		`<caption>Scores</caption>` +
+		`<colgroup span="5000"></colgroup>` +
+		`<colgroup span="2"><col class="name"><col span="9999"></colgroup>` +
+		`<tr><th>Team</th><th>Score</th></tr>` +
+		`<tr><td>Red</td><td>1</td></tr>` +
+		`</table>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	table := getElementsByTagName(doc, "table")[0]
+	normalizeTable(table)
+
+	expected := `<table><caption>Scores</caption>` +
+		`<colgroup span="1000"></colgroup>` +
+		`<colgroup><col class="name"/><col span="1000"/></colgroup>` +
+		`<thead><tr><th>Team</th><th>Score</th></tr></thead>` +
+		`<tbody><tr><td>Red</td><td>1</td></tr></tbody></table>`
+
+	if output := outerHTML(table); output != expected {
+		t.Fatalf("unexpected table:\n%s", output)
+	}
+}
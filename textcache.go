@@ -0,0 +1,47 @@
+package readability
+
+import (
+	"golang.org/x/net/html"
+)
+
+// startTextCache memoizes the normalized text of the nodes until
+// stopTextCache is called, so the text of the same subtree is only extracted
+// once, even if getInnerText and getLinkDensity are called on it, or on its
+// links, many times.
+//
+// The cache is only valid while the document is not modified. The nodes
+// removed with removeNodes or removeAndGetNext invalidate the text of their
+// ancestors, any other modification must call invalidateText.
+func (r *Readability) startTextCache() {
+	r.texts = map[*html.Node]string{}
+}
+
+// stopTextCache discards the memoized text of the nodes.
+func (r *Readability) stopTextCache() {
+	r.texts = nil
+}
+
+// cachedText returns the normalized text of the node, extracting it only if
+// the text is not in the cache yet.
+func (r *Readability) cachedText(node *html.Node) string {
+	if text, ok := r.texts[node]; ok {
+		return text
+	}
+
+	text := normalizedText(node)
+	r.texts[node] = text
+
+	return text
+}
+
+// invalidateText removes the text of the node and of its ancestors from the
+// cache, because a descendant of the node was added, removed or modified.
+func (r *Readability) invalidateText(node *html.Node) {
+	if r.texts == nil {
+		return
+	}
+
+	for ; node != nil; node = node.Parent {
+		delete(r.texts, node)
+	}
+}
@@ -0,0 +1,42 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestTextCache(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<div id="outer"><div id="inner"><p>lorem</p><p><a href="/">ipsum</a></p></div></div>`))
+
+	if err != nil {
+		t.Fatalf("cannot parse document: %s", err)
+	}
+
+	r := New()
+	divs := getElementsByTagName(doc, "div")
+	outer, inner := divs[0], divs[1]
+
+	r.startTextCache()
+	defer r.stopTextCache()
+
+	if text := r.getInnerText(outer, true); text != "loremipsum" {
+		t.Fatalf("unexpected text: %q", text)
+	}
+
+	if density := r.getLinkDensity(outer); density != 0.5 {
+		t.Fatalf("unexpected link density: %f", density)
+	}
+
+	// The cached text must be discarded when a descendant is removed.
+	r.removeNodes(getElementsByTagName(inner, "a"), nil)
+
+	if text := r.getInnerText(outer, true); text != "lorem" {
+		t.Fatalf("the text of the ancestors was not invalidated: %q", text)
+	}
+
+	if density := r.getLinkDensity(inner); density != 0 {
+		t.Fatalf("unexpected link density after the removal: %f", density)
+	}
+}
@@ -0,0 +1,287 @@
+package readability
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// rxThreadPostType matches the schema.org types of the posts of a thread.
+var rxThreadPostType = regexp.MustCompile(`(?i)schema\.org/(Question|Answer|Comment|DiscussionForumPosting|SocialMediaPosting)$`)
+
+// rxPostAuthor matches the class names of the elements with the name of the
+// author of a post.
+var rxPostAuthor = regexp.MustCompile(`(?i)author|username|user-details|poster|byline`)
+
+// rxPostScore matches the class names of the elements with the votes of a post.
+var rxPostScore = regexp.MustCompile(`(?i)vote-count|(^|\s)(score|votes)(\s|$)`)
+
+// threadPostClasses are the class names of the containers of the posts of a
+// thread, used when the posts are not described with schema.org types.
+var threadPostClasses = []string{
+	"post", "answer", "question", "reply", "comment", "message",
+	"topic-post", "forum-post", "postbody",
+}
+
+// threadBodyClasses are the class names of the elements with the content of a
+// post, which excludes the author, the votes and the actions of the post.
+var threadBodyClasses = []string{
+	"s-prose", "post-text", "postbody", "post-body", "post-content",
+	"cooked", "message-body", "message-content", "js-post-body", "content",
+}
+
+// Thread is a discussion extracted from a forum or a Q&A website.
+type Thread struct {
+	// Title is the title of the thread.
+	Title string
+
+	// Posts are the posts of the thread in the same order found in the
+	// document, starting with the question or the opening post.
+	Posts []Post
+}
+
+// Post is one of the messages of a thread.
+type Post struct {
+	// Author is the name of the author of the post.
+	Author string
+
+	// Time is the date when the post was published, as found in the datetime
+	// attribute of its <time> element or, as a fallback, in its text. It is
+	// returned as written, no attempt is made to parse it.
+	Time string
+
+	// Content is the content of the post with HTML tags, cleaned with the
+	// same rules applied to the content of the articles.
+	Content string
+
+	// TextContent is the content of the post without HTML tags.
+	TextContent string
+
+	// Score is the number of votes of the post, or zero if not present.
+	Score int
+
+	// Accepted is true for the answer accepted by the author of a question.
+	Accepted bool
+}
+
+// threadPosts returns the containers of the posts of the thread, in document
+// order. Posts nested inside other posts, like the comments of an answer, are
+// part of the outer post.
+func (r *Readability) threadPosts(doc *html.Node) []*html.Node {
+	var posts []*html.Node
+
+	for _, node := range getElementsByTagName(doc, "*") {
+		if !r.isThreadPost(node) || !r.isProbablyVisible(node) {
+			continue
+		}
+
+		if r.hasAncestorIn(node, posts) || r.getInnerText(node, true) == "" {
+			continue
+		}
+
+		posts = append(posts, node)
+	}
+
+	return posts
+}
+
+// isThreadPost returns true if node looks like the container of a post.
+func (r *Readability) isThreadPost(node *html.Node) bool {
+	if rxThreadPostType.MatchString(getAttribute(node, "itemtype")) || hasAttribute(node, "data-post-id") {
+		return true
+	}
+
+	for _, class := range strings.Fields(className(node)) {
+		if indexOf(threadPostClasses, strings.ToLower(class)) != -1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// postBody returns the element with the content of the post, or the post
+// itself if there is none.
+func postBody(post *html.Node) *html.Node {
+	for _, node := range getElementsByTagName(post, "*") {
+		if getAttribute(node, "itemprop") == "text" {
+			return node
+		}
+
+		for _, class := range strings.Fields(className(node)) {
+			if indexOf(threadBodyClasses, strings.ToLower(class)) != -1 {
+				return node
+			}
+		}
+	}
+
+	return post
+}
+
+// postAuthor returns the name of the author of the post, found outside of the
+// content of the post.
+func (r *Readability) postAuthor(post *html.Node, body *html.Node) string {
+	for _, node := range getElementsByTagName(post, "*") {
+		if body != post && (node == body || isDescendant(node, body)) {
+			continue
+		}
+
+		itemprop := getAttribute(node, "itemprop")
+
+		if itemprop != "author" && getAttribute(node, "rel") != "author" && !rxPostAuthor.MatchString(limitedClassName(node, r.maxMatchStringLength())) {
+			continue
+		}
+
+		// Prefer the name of the author over the rest of the details, like
+		// the reputation or the avatar.
+		for _, name := range getElementsByTagName(node, "*") {
+			if getAttribute(name, "itemprop") == "name" {
+				node = name
+				break
+			}
+		}
+
+		if text := r.getInnerText(node, true); r.isValidByline(text) {
+			return text
+		}
+	}
+
+	return ""
+}
+
+// postTime returns the date when the post was published.
+func (r *Readability) postTime(post *html.Node) string {
+	for _, node := range getElementsByTagName(post, "*") {
+		itemprop := getAttribute(node, "itemprop")
+
+		if tagName(node) != "time" && itemprop != "dateCreated" && itemprop != "datePublished" {
+			continue
+		}
+
+		for _, attrName := range []string{"datetime", "content", "title"} {
+			if value := strings.TrimSpace(getAttribute(node, attrName)); value != "" {
+				return value
+			}
+		}
+
+		if text := r.getInnerText(node, true); text != "" {
+			return text
+		}
+	}
+
+	return ""
+}
+
+// postScore returns the number of votes of the post, if present.
+func (r *Readability) postScore(post *html.Node) int {
+	for _, node := range getElementsByTagName(post, "*") {
+		if getAttribute(node, "itemprop") != "upvoteCount" && !rxPostScore.MatchString(limitedClassName(node, r.maxMatchStringLength())) {
+			continue
+		}
+
+		for _, value := range []string{getAttribute(node, "data-value"), getAttribute(node, "content"), r.getInnerText(node, true)} {
+			if score, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				return score
+			}
+		}
+	}
+
+	return 0
+}
+
+// isAcceptedPost returns true if the post is the accepted answer.
+func isAcceptedPost(post *html.Node) bool {
+	if getAttribute(post, "itemprop") == "acceptedAnswer" {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(className(post)), "accepted")
+}
+
+// threadPost extracts the details of a post and cleans its content.
+func (r *Readability) threadPost(post *html.Node) Post {
+	body := postBody(post)
+
+	// The posts are usually short, the conditional cleaning would remove
+	// most of their content.
+	r.flags.stripUnlikelys = true
+	r.flags.useWeightClasses = true
+	r.flags.cleanConditionally = false
+
+	content := createElement("div")
+	content.AppendChild(cloneNode(body))
+	r.prepArticle(content)
+	r.postProcessContent(content)
+	r.scrubAttributes(content)
+
+	return Post{
+		Author:      r.postAuthor(post, body),
+		Time:        r.postTime(post),
+		Content:     innerHTML(content),
+		TextContent: r.transformText(strings.TrimSpace(textContent(content))),
+		Score:       r.postScore(post),
+		Accepted:    isAcceptedPost(post),
+	}
+}
+
+// ParseThread parses a forum thread or a Q&A page, like the ones of Stack
+// Overflow, Discourse or phpBB, and returns each one of its posts instead of
+// a single article.
+//
+// If the page does not contain at least two posts, the thread contains one
+// post with the same article returned by Parse.
+func (r *Readability) ParseThread(input io.Reader, pageURL string) (Thread, error) {
+	doc, err := html.Parse(input)
+
+	if err != nil {
+		return Thread{}, fmt.Errorf("failed to parse input: %v", err)
+	}
+
+	if r.documentURI, err = url.ParseRequestURI(pageURL); err != nil {
+		return Thread{}, fmt.Errorf("failed to parse URL: %v", err)
+	}
+
+	prepared := cloneNode(doc)
+	r.removeScripts(prepared)
+	r.removeNodes(getElementsByTagName(prepared, "style"), nil)
+
+	posts := r.threadPosts(prepared)
+
+	if len(posts) < 2 {
+		result, err := r.analyzeDocument(doc, pageURL, time.Now())
+
+		if err != nil {
+			return Thread{}, err
+		}
+
+		article := result.article()
+
+		return Thread{
+			Title: article.Title,
+			Posts: []Post{{
+				Author:      article.Byline,
+				Time:        article.PublishedTime,
+				Content:     article.Content,
+				TextContent: article.TextContent,
+			}},
+		}, nil
+	}
+
+	r.doc = prepared
+	r.articleTitle = r.getArticleTitle()
+	r.anchorTargets = nil
+
+	thread := Thread{Title: r.articleTitle}
+
+	for _, post := range posts {
+		thread.Posts = append(thread.Posts, r.threadPost(post))
+	}
+
+	return thread, nil
+}
@@ -0,0 +1,64 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseThread(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head>
+			<title>How to parse HTML in Go?</title>
+		</head>
+		<body>
+			<div class="header"><a href="/">Home</a></div>
+			<div id="question" class="question" itemscope itemtype="https://schema.org/Question">
+				<div class="js-vote-count" data-value="12">12</div>
+				<div class="s-prose" itemprop="text"><p>Which package should I use to parse HTML documents in Go?</p></div>
+				<div class="user-details" itemprop="author"><a href="/users/1" itemprop="name">alice</a> 1,024</div>
+				<time datetime="2020-01-02T03:04:05">Jan 2, 2020</time>
+			</div>
+			<div id="answers">
+				<div class="answer accepted-answer" itemprop="acceptedAnswer" itemscope itemtype="https://schema.org/Answer">
+					<div class="js-vote-count" data-value="-3">-3</div>
+					<div class="s-prose" itemprop="text"><p>Use <code>golang.org/x/net/html</code>.</p><div class="share"><a href="/share">Share</a></div></div>
+					<div class="user-details"><a href="/users/2">bob</a></div>
+					<div class="comment"><span class="comment-copy">Thanks!</span></div>
+				</div>
+				<div class="answer" itemscope itemtype="https://schema.org/Answer">
+					<div class="s-prose" itemprop="text"><p>Or goquery.</p></div>
+				</div>
+			</div>
+		</body>
+		</html>`)
+
+	thread, err := New().ParseThread(input, "https://cixtor.com/questions/1")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if thread.Title != "How to parse HTML in Go?" || len(thread.Posts) != 3 {
+		t.Fatalf("unexpected thread: %#v", thread)
+	}
+
+	question := thread.Posts[0]
+
+	if question.Author != "alice" || question.Time != "2020-01-02T03:04:05" || question.Score != 12 || question.Accepted {
+		t.Fatalf("unexpected question: %#v", question)
+	}
+
+	answer := thread.Posts[1]
+
+	if answer.Author != "bob" || answer.Score != -3 || !answer.Accepted {
+		t.Fatalf("unexpected answer: %#v", answer)
+	}
+
+	if answer.Content != "<div><p>Use <code>golang.org/x/net/html</code>.</p></div>" {
+		t.Fatalf("unexpected answer content: %s", answer.Content)
+	}
+
+	if thread.Posts[2].TextContent != "Or goquery." {
+		t.Fatalf("unexpected text of the last answer: %q", thread.Posts[2].TextContent)
+	}
+}
@@ -0,0 +1,50 @@
+package readability
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// TimeMeta is a <time> element found in the content of the article.
+type TimeMeta struct {
+	// DateTime is the machine-readable value of the datetime attribute, like
+	// "2024-05-01T10:30:00Z", or an empty string if the element has none.
+	DateTime string
+
+	// Text is the text of the element, as displayed to the reader.
+	Text string
+}
+
+// extractTimes returns the <time> elements of the content, in the same order
+// found in the document, skipping the ones with neither a datetime attribute
+// nor text.
+func (r *Readability) extractTimes(articleContent *html.Node) []TimeMeta {
+	var times []TimeMeta
+
+	for _, node := range getElementsByTagName(articleContent, "time") {
+		meta := TimeMeta{
+			DateTime: strings.TrimSpace(getAttribute(node, "datetime")),
+			Text:     strings.Join(strings.Fields(textContent(node)), "\x20"),
+		}
+
+		if meta.DateTime != "" || meta.Text != "" {
+			times = append(times, meta)
+		}
+	}
+
+	return times
+}
+
+// firstDateTime returns the datetime attribute of the first <time> element
+// that has one. The first date of the content is usually the one next to the
+// byline, which is the date when the article was published.
+func firstDateTime(times []TimeMeta) string {
+	for _, meta := range times {
+		if meta.DateTime != "" {
+			return meta.DateTime
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,48 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractTimes(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head><title>hello world</title></head>
+		<body>
+			<article>
+				<p>Published <time datetime="2024-05-01T10:30:00Z" class="stamp" data-tracking="1">May 1</time>, lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore.</p>
+				<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat, since <time>yesterday</time>.</p>
+				<p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+			</article>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	expected := []TimeMeta{
+		{DateTime: "2024-05-01T10:30:00Z", Text: "May 1"},
+		{Text: "yesterday"},
+	}
+
+	if len(a.Times) != len(expected) {
+		t.Fatalf("unexpected times: %#v", a.Times)
+	}
+
+	for i, meta := range expected {
+		if a.Times[i] != meta {
+			t.Fatalf("unexpected time #%d: %#v", i, a.Times[i])
+		}
+	}
+
+	if !strings.Contains(a.Content, `<time datetime="2024-05-01T10:30:00Z">May 1</time>`) {
+		t.Fatalf("the datetime attribute was not kept: %s", a.Content)
+	}
+
+	if a.PublishedTime != "2024-05-01T10:30:00Z" {
+		t.Fatalf("unexpected published time: %q", a.PublishedTime)
+	}
+}
@@ -0,0 +1,134 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// rxTranscriptLine matches the blocks of a transcript, which start with the
+// name of the speaker, in upper case or capitalized, followed by a colon, like
+// "JANE DOE: Thank you for having me" or "Host: Welcome back".
+var rxTranscriptLine = regexp.MustCompile(`^([A-Z][A-Z0-9.'’ -]{0,38}[A-Z0-9.]|[A-Z][\p{L}.'’-]*(?: [A-Z][\p{L}.'’-]*){0,2}):\s+\S`)
+
+// transcriptAttr marks the blocks of a transcript while they are processed.
+// Its value is the name of the speaker.
+const transcriptAttr = "data-readability-speaker"
+
+// transcriptMinLines is the minimum number of blocks of a transcript.
+const transcriptMinLines = 4
+
+// transcriptTags are the elements that can contain a block of a transcript.
+var transcriptTags = []string{"p", "div", "li", "dd", "blockquote"}
+
+// TranscriptLine is one of the blocks of an interview or of the subtitles of
+// a video, spoken by one speaker.
+type TranscriptLine struct {
+	// Speaker is the name of the speaker, as written in the document.
+	Speaker string
+
+	// Text is what the speaker said, without the name of the speaker.
+	Text string
+}
+
+// markTranscript finds the blocks of a transcript and marks them to protect
+// them from conditional cleaning, which usually removes some of them because
+// each block is short. The document is considered a transcript only if it has
+// enough blocks spoken by at least two speakers.
+func (r *Readability) markTranscript(doc *html.Node) {
+	var lines []*html.Node
+	var speakers []string
+
+	for _, node := range r.getAllNodesWithTag(doc, transcriptTags...) {
+		speaker := transcriptSpeaker(node)
+
+		if speaker == "" {
+			continue
+		}
+
+		// The container of the blocks may start with the first one, keep
+		// only the innermost blocks.
+		for len(lines) > 0 && isDescendant(node, lines[len(lines)-1]) {
+			lines = lines[:len(lines)-1]
+			speakers = speakers[:len(speakers)-1]
+		}
+
+		lines = append(lines, node)
+		speakers = append(speakers, speaker)
+	}
+
+	if len(lines) < transcriptMinLines {
+		return
+	}
+
+	distinct := false
+
+	for _, speaker := range speakers {
+		if speaker != speakers[0] {
+			distinct = true
+			break
+		}
+	}
+
+	if !distinct {
+		return
+	}
+
+	for i, line := range lines {
+		setAttribute(line, transcriptAttr, speakers[i])
+	}
+}
+
+// transcriptSpeaker returns the name of the speaker of the block, or an empty
+// string if the block does not look like a block of a transcript.
+func transcriptSpeaker(node *html.Node) string {
+	text := strings.Join(strings.Fields(textContent(node)), "\x20")
+	match := rxTranscriptLine.FindStringSubmatch(text)
+
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// isProtectedTranscriptLine returns true if node is, contains or is inside one
+// of the blocks of the transcript marked by markTranscript.
+func (r *Readability) isProtectedTranscriptLine(node *html.Node) bool {
+	for parent := node; parent != nil; parent = parent.Parent {
+		if hasAttribute(parent, transcriptAttr) {
+			return true
+		}
+	}
+
+	for _, elem := range getElementsByTagName(node, "*") {
+		if hasAttribute(elem, transcriptAttr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractTranscript returns the blocks of the transcript found in the article
+// content, in document order, and removes the marks set by markTranscript.
+func (r *Readability) extractTranscript(articleContent *html.Node) []TranscriptLine {
+	var lines []TranscriptLine
+
+	for _, node := range getElementsByTagName(articleContent, "*") {
+		if !hasAttribute(node, transcriptAttr) {
+			continue
+		}
+
+		speaker := getAttribute(node, transcriptAttr)
+		removeAttribute(node, transcriptAttr)
+
+		text := strings.Join(strings.Fields(textContent(node)), "\x20")
+		text = strings.TrimSpace(strings.TrimPrefix(text, speaker+":"))
+
+		lines = append(lines, TranscriptLine{Speaker: speaker, Text: text})
+	}
+
+	return lines
+}
@@ -0,0 +1,65 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranscriptSpeaker(t *testing.T) {
+	tests := map[string]string{
+		"JANE DOE: Thank you for having me.":    "JANE DOE",
+		"Host: Welcome back to the show.":       "Host",
+		"Dr. Smith: It depends on the dose.":    "Dr. Smith",
+		"Lorem ipsum: dolor sit amet.":          "",
+		"The answer is: it depends.":            "",
+		"NOTE:":                                 "",
+		"10:30 The meeting starts at the hall.": "",
+	}
+
+	for input, expected := range tests {
+		node := createElement("p")
+		node.AppendChild(createTextNode(input))
+
+		if speaker := transcriptSpeaker(node); speaker != expected {
+			t.Fatalf("transcriptSpeaker(%q) = %q, expecting %q", input, speaker, expected)
+		}
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	input := strings.NewReader(`<html>
+		<head><title>An interview with Jane Doe</title></head>
+		<body>
+			<div class="transcript">
+				<p>This is an edited transcript of our conversation about the new <a href="/book">book</a> by Jane Doe, recorded last week in her studio.</p>
+				<div class="line">HOST: Welcome to the show.</div>
+				<div class="line">JANE DOE: Thank you, it is a pleasure to be here.</div>
+				<div class="line">HOST: Why <a href="/book">this book</a>?</div>
+				<div class="line">JANE DOE: Because nobody else would write it, and I had been collecting the stories of the people of the valley for twenty years.</div>
+				<div class="line">HOST: <a href="/links">Links</a>.</div>
+			</div>
+		</body>
+		</html>`)
+
+	a, err := New().Parse(input, "https://cixtor.com/blog")
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if len(a.Transcript) != 5 {
+		t.Fatalf("expecting five blocks: %#v", a.Transcript)
+	}
+
+	if a.Transcript[1].Speaker != "JANE DOE" || a.Transcript[1].Text != "Thank you, it is a pleasure to be here." {
+		t.Fatalf("unexpected block: %#v", a.Transcript[1])
+	}
+
+	if a.Transcript[4].Speaker != "HOST" || a.Transcript[4].Text != "Links." {
+		t.Fatalf("unexpected block: %#v", a.Transcript[4])
+	}
+
+	if strings.Contains(a.Content, transcriptAttr) {
+		t.Fatalf("transcript marks were not removed: %s", a.Content)
+	}
+}
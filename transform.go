@@ -0,0 +1,49 @@
+package readability
+
+import (
+	"strings"
+)
+
+// TextTransform rewrites the plain text version of the article. Transforms are
+// applied in order to TextContent after the content has been extracted, which
+// makes them suitable for preparing the text for plain-text indexes without
+// affecting the HTML version of the article.
+type TextTransform func(string) string
+
+// typographyReplacer maps typographic characters to their plain ASCII form.
+var typographyReplacer = strings.NewReplacer(
+	// Single quotes, apostrophes and primes.
+	"‘", "'", "’", "'", "‚", "'", "‛", "'",
+	"′", "'", "‹", "'", "›", "'",
+	// Double quotes and double primes.
+	"“", "\"", "”", "\"", "„", "\"", "‟", "\"",
+	"″", "\"", "«", "\"", "»", "\"",
+	// Dashes and hyphens.
+	"‐", "-", "‑", "-", "‒", "-", "–", "-",
+	"—", "--", "―", "--", "−", "-",
+	// Ellipsis.
+	"…", "...",
+	// Spaces left behind by entities like &nbsp; and &thinsp;.
+	"\u00a0", " ", "\u2002", " ", "\u2003", " ", "\u2007", " ",
+	"\u2009", " ", "\u200a", " ", "\u202f", " ",
+	// Invisible characters left behind by entities like &shy; and &zwnj;.
+	"\u00ad", "", "\u200b", "", "\u200c", "", "\u200d", "", "\ufeff", "",
+)
+
+// NormalizeTypography is a TextTransform that converts curly quotes, dashes,
+// ellipses and typographic spaces into their plain ASCII equivalents, and
+// drops invisible characters such as soft hyphens and zero-width spaces.
+func NormalizeTypography(text string) string {
+	return typographyReplacer.Replace(text)
+}
+
+// transformText applies the configured text transforms to the given text.
+func (r *Readability) transformText(text string) string {
+	for _, transform := range r.TextTransforms {
+		if transform != nil {
+			text = transform(text)
+		}
+	}
+
+	return text
+}
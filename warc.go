@@ -0,0 +1,164 @@
+package readability
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// ParseWARC reads records from a Web ARChive (WARC) file and finds the main
+// readable content of the first archived HTML document. The URL of the page
+// is taken from the WARC-Target-URI header of the record, and the payload is
+// decoded according to the archived Content-Encoding header.
+//
+// Records of other types, like "warcinfo" and "request", are skipped, and so
+// are the archived responses without a successful status code or with other
+// content types, like redirects, images, or stylesheets. Records of type
+// "resource" are accepted too, in which case the record block is the document
+// itself instead of an HTTP response.
+//
+// See: https://iipc.github.io/warc-specifications/specifications/warc-format/warc-1.1/
+func (r *Readability) ParseWARC(input io.Reader) (Article, error) {
+	reader := bufio.NewReader(input)
+
+	for {
+		header, err := readWARCHeader(reader)
+
+		if err != nil {
+			return Article{}, err
+		}
+
+		length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+
+		if err != nil {
+			return Article{}, fmt.Errorf("invalid WARC content length: %v", err)
+		}
+
+		block := io.LimitReader(reader, length)
+
+		body, err := readWARCDocument(header, block)
+
+		if err != nil {
+			return Article{}, err
+		}
+
+		if body == nil {
+			if _, err := io.Copy(ioutil.Discard, block); err != nil {
+				return Article{}, fmt.Errorf("failed to skip WARC record: %v", err)
+			}
+			continue
+		}
+
+		targetURI := header.Get("WARC-Target-URI")
+		targetURI = strings.TrimSuffix(strings.TrimPrefix(targetURI, "<"), ">")
+
+		result, err := r.analyze(body, targetURI)
+
+		if err != nil {
+			return Article{}, err
+		}
+
+		return result.article(), nil
+	}
+}
+
+// readWARCDocument returns the HTML document archived in the block of a WARC
+// record, or nil if the record does not contain one.
+func readWARCDocument(header textproto.MIMEHeader, block io.Reader) (io.Reader, error) {
+	switch header.Get("WARC-Type") {
+	case "resource":
+		if !isHTMLContentType(header.Get("Content-Type")) {
+			return nil, nil
+		}
+
+		return block, nil
+
+	case "response":
+		if !isHTTPContentType(header.Get("Content-Type")) {
+			return nil, nil
+		}
+
+		res, err := http.ReadResponse(bufio.NewReader(block), nil)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archived response: %v", err)
+		}
+
+		if res.StatusCode < 200 || res.StatusCode > 299 || !isHTMLContentType(res.Header.Get("Content-Type")) {
+			res.Body.Close()
+			return nil, nil
+		}
+
+		body, err := decodeContent(res.Body, res.Header.Get("Content-Encoding"))
+
+		if err != nil {
+			res.Body.Close()
+			return nil, err
+		}
+
+		return body, nil
+	}
+
+	return nil, nil
+}
+
+// isHTTPContentType returns true if the content type of a WARC response record
+// is an HTTP message. Records without a content type are assumed to be one.
+func isHTTPContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && mediaType == "application/http"
+}
+
+// isHTMLContentType returns true if contentType is an HTML or XHTML document.
+// Documents without a content type are assumed to be HTML.
+func isHTMLContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml")
+}
+
+// readWARCHeader reads the version line and the named fields of the next WARC
+// record, skipping the empty lines that separate records.
+func readWARCHeader(reader *bufio.Reader) (textproto.MIMEHeader, error) {
+	var version string
+
+	for version == "" {
+		line, err := reader.ReadString('\n')
+
+		if err != nil && (err != io.EOF || strings.TrimSpace(line) == "") {
+			if err == io.EOF {
+				return nil, fmt.Errorf("no WARC response record found")
+			}
+			return nil, fmt.Errorf("failed to read WARC record: %v", err)
+		}
+
+		version = strings.TrimSpace(line)
+	}
+
+	if !strings.HasPrefix(version, "WARC/") {
+		return nil, fmt.Errorf("invalid WARC version line: %q", version)
+	}
+
+	header, err := textproto.NewReader(reader).ReadMIMEHeader()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WARC header: %v", err)
+	}
+
+	return header, nil
+}
@@ -0,0 +1,93 @@
+package readability
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseWARC(t *testing.T) {
+	request := "GET /blog HTTP/1.1\r\nHost: cixtor.com\r\n\r\n"
+	response := "HTTP/1.1 200 OK\r\n" +
+		"Content-Type: text/html\r\n" +
+		"\r\n" +
+		`<html><head><title>hello world</title></head><body><p>lorem ipsum</p><a href="/about">about</a></body></html>`
+
+	record := func(recordType string, block string) string {
+		return "WARC/1.1\r\n" +
+			"WARC-Type: " + recordType + "\r\n" +
+			"WARC-Target-URI: https://cixtor.com/blog\r\n" +
+			fmt.Sprintf("Content-Length: %d\r\n", len(block)) +
+			"\r\n" +
+			block + "\r\n\r\n"
+	}
+
+	input := strings.NewReader(record("request", request) + record("response", response))
+
+	a, err := New().ParseWARC(input)
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.TextContent != "lorem ipsumabout" {
+		t.Fatalf("unexpected content: %q", a.TextContent)
+	}
+
+	if !strings.Contains(a.Content, `href="https://cixtor.com/about"`) {
+		t.Fatalf("links were not resolved against the target URI: %s", a.Content)
+	}
+}
+
+func TestParseWARCSkipsOtherResponses(t *testing.T) {
+	record := func(recordType string, contentType string, uri string, block string) string {
+		return "WARC/1.1\r\n" +
+			"WARC-Type: " + recordType + "\r\n" +
+			"WARC-Target-URI: " + uri + "\r\n" +
+			"Content-Type: " + contentType + "\r\n" +
+			fmt.Sprintf("Content-Length: %d\r\n", len(block)) +
+			"\r\n" +
+			block + "\r\n\r\n"
+	}
+
+	response := func(status string, contentType string, body string) string {
+		return "HTTP/1.1 " + status + "\r\n" +
+			"Content-Type: " + contentType + "\r\n" +
+			"\r\n" +
+			body
+	}
+
+	page := `<html><head><title>hello world</title></head><body><p>lorem ipsum</p></body></html>`
+
+	input := strings.NewReader(
+		record("response", "application/http; msgtype=response", "https://cixtor.com/robots.txt", response("200 OK", "text/plain", "User-agent: *")) +
+			record("response", "application/http; msgtype=response", "https://cixtor.com/old", response("301 Moved Permanently", "text/html", "<p>moved</p>")) +
+			record("metadata", "application/warc-fields", "https://cixtor.com/blog", "via: https://cixtor.com/\r\n") +
+			record("response", "text/html", "https://cixtor.com/raw", "<p>not an http message</p>") +
+			record("response", "application/http; msgtype=response", "https://cixtor.com/blog", response("200 OK", "text/html; charset=utf-8", page)),
+	)
+
+	a, err := New().ParseWARC(input)
+
+	if err != nil {
+		t.Fatalf("parser failure: %s", err)
+	}
+
+	if a.TextContent != "lorem ipsum" {
+		t.Fatalf("unexpected content: %q", a.TextContent)
+	}
+}
+
+func TestParseWARCWithoutHTML(t *testing.T) {
+	block := "HTTP/1.1 200 OK\r\nContent-Type: image/png\r\n\r\nPNG"
+	input := strings.NewReader("WARC/1.1\r\n" +
+		"WARC-Type: response\r\n" +
+		"WARC-Target-URI: https://cixtor.com/logo.png\r\n" +
+		fmt.Sprintf("Content-Length: %d\r\n", len(block)) +
+		"\r\n" +
+		block + "\r\n\r\n")
+
+	if _, err := New().ParseWARC(input); err == nil {
+		t.Fatal("expecting an error for an archive without HTML documents")
+	}
+}